@@ -14987,7 +14987,7 @@ func (p *parser) callonPreParseElement1() (any, error) {
 }
 
 func (c *current) onPreParseLine1(content any) (any, error) {
-	return flat(append(content.([]any), &asciidoc.NewLine{})), nil
+	return flat(append(content.([]any), populatePosition(c, &asciidoc.NewLine{}))), nil
 }
 
 func (p *parser) callonPreParseLine1() (any, error) {
@@ -0,0 +1,425 @@
+package parse
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/project-chip/alchemy/asciidoc"
+)
+
+type IncludeOpener func(path string) (io.ReadCloser, error)
+
+// LineSource is the file and line a line of preparsed output came from
+type LineSource struct {
+	Path string
+	Line int
+}
+
+// LineMap holds the source of each line of a preparsed document
+type LineMap []LineSource
+
+// Include is a file included by another while preparsing
+type Include struct {
+	From string
+	To   string
+}
+
+const maxIncludeDepth = 64
+
+// PreParseIncludes preparses a document, replacing include directives with the preparsed contents of the files they name;
+// included files see the attributes set before the directive, and attributes they set remain set after it;
+// the includes that were expanded are returned in the order they were read
+func PreParseIncludes(context *AttributeContext, path string, reader io.Reader, open IncludeOpener) (string, LineMap, []Include, error) {
+	vals, err := ParseReader(path, reader, Entrypoint("PreParse"))
+	if err != nil {
+		return "", nil, nil, err
+	}
+	set, ok := vals.(asciidoc.Set)
+	if !ok {
+		return "", nil, nil, fmt.Errorf("unexpected type in PreParseIncludes: %T", vals)
+	}
+	result := asciidoc.NewWriter(nil)
+	iw := &includeWriter{context: context, open: open, out: result, stack: []string{path}}
+	err = preparse(context, asciidoc.NewReader(set), iw)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if iw.err != nil {
+		return "", nil, nil, iw.err
+	}
+	result.WriteSet(iw.line)
+	parsed, lines, err := renderPreParsedLines(path, result.Set())
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return parsed, lines, iw.includes, nil
+}
+
+type includeWriter struct {
+	context     *AttributeContext
+	open        IncludeOpener
+	out         *asciidoc.Writer
+	line        asciidoc.Set
+	levelOffset int
+	stack       []string
+	includes    []Include
+	err         error
+}
+
+func (iw *includeWriter) Write(el asciidoc.Element) {
+	nl, ok := el.(*asciidoc.NewLine)
+	if !ok {
+		iw.line = append(iw.line, el)
+		return
+	}
+	line := iw.line
+	iw.line = nil
+	if iw.err != nil {
+		return
+	}
+	if target, attributes, ok := includeDirective(line); ok {
+		iw.err = iw.include(nl.Path(), target, attributes)
+		return
+	}
+	if iw.levelOffset != 0 {
+		line = offsetSectionLevel(line, iw.levelOffset)
+	}
+	iw.out.WriteSet(line)
+	iw.out.Write(nl)
+}
+
+var includeDirectivePattern = regexp.MustCompile(`^include::([^\[]+)\[(.*)\]\s*$`)
+
+func includeDirective(line asciidoc.Set) (target string, attributes string, ok bool) {
+	if len(line) == 0 {
+		return
+	}
+	if s, isString := line[0].(*asciidoc.String); !isString || !strings.HasPrefix(s.Value, "include::") {
+		return
+	}
+	text, err := renderPreParsedDoc(line)
+	if err != nil {
+		return
+	}
+	matches := includeDirectivePattern.FindStringSubmatch(text)
+	if matches == nil {
+		return
+	}
+	return matches[1], matches[2], true
+}
+
+func (iw *includeWriter) include(from string, target string, attributes string) error {
+	path := target
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(from), target)
+	}
+	for _, p := range iw.stack {
+		if p == path {
+			return fmt.Errorf("recursive include of %s from %s", path, from)
+		}
+	}
+	if len(iw.stack) >= maxIncludeDepth {
+		return fmt.Errorf("includes nested too deeply at %s", path)
+	}
+	f, err := iw.open(path)
+	if err != nil {
+		slog.Warn("unresolved include", slog.String("path", from), slog.String("target", target), slog.Any("error", err))
+		return nil
+	}
+	b, err := io.ReadAll(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+	iw.includes = append(iw.includes, Include{From: from, To: path})
+	options := parseIncludeAttributes(attributes)
+	vals, err := Parse(path, b, Entrypoint("PreParse"))
+	if err != nil {
+		return err
+	}
+	set, ok := vals.(asciidoc.Set)
+	if !ok {
+		return fmt.Errorf("unexpected type preparsing include %s: %T", path, vals)
+	}
+	if len(options.lines) > 0 {
+		set = filterLines(set, selectLineRanges(b, options.lines))
+	} else if len(options.tags) > 0 {
+		set = filterLines(set, selectTaggedLines(b, options.tags))
+	}
+
+	levelOffset := iw.levelOffset
+	if options.levelOffset != "" {
+		switch options.levelOffset[0] {
+		case '+', '-':
+			offset, _ := strconv.Atoi(options.levelOffset)
+			iw.levelOffset += offset
+		default:
+			iw.levelOffset, _ = strconv.Atoi(options.levelOffset)
+		}
+	}
+	iw.stack = append(iw.stack, path)
+	err = preparse(iw.context, asciidoc.NewReader(set), iw)
+	iw.stack = iw.stack[:len(iw.stack)-1]
+	iw.levelOffset = levelOffset
+	return err
+}
+
+type includeOptions struct {
+	levelOffset string
+	lines       []string
+	tags        []string
+}
+
+func parseIncludeAttributes(attributes string) (options includeOptions) {
+	for _, attribute := range splitIncludeAttributes(attributes) {
+		name, value, ok := strings.Cut(attribute, "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+		switch strings.TrimSpace(name) {
+		case "leveloffset":
+			options.levelOffset = value
+		case "lines":
+			options.lines = strings.FieldsFunc(value, isIncludeListSeparator)
+		case "tag", "tags":
+			options.tags = strings.FieldsFunc(value, isIncludeListSeparator)
+		}
+	}
+	return
+}
+
+// splitIncludeAttributes splits on commas outside of quotes, since quoted lists of lines or tags may contain commas
+func splitIncludeAttributes(attributes string) (split []string) {
+	var inQuotes bool
+	var start int
+	for i, r := range attributes {
+		switch r {
+		case '"':
+			inQuotes = !inQuotes
+		case ',':
+			if !inQuotes {
+				split = append(split, attributes[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(split, attributes[start:])
+}
+
+func isIncludeListSeparator(r rune) bool {
+	return r == ';' || r == ','
+}
+
+func selectLineRanges(b []byte, ranges []string) map[int]bool {
+	lineCount := bytes.Count(b, []byte{'\n'}) + 1
+	selected := make(map[int]bool)
+	for _, r := range ranges {
+		from, to, isRange := strings.Cut(strings.TrimSpace(r), "..")
+		start, err := strconv.Atoi(from)
+		if err != nil {
+			continue
+		}
+		end := start
+		if isRange {
+			end, err = strconv.Atoi(to)
+			if err != nil || end < 0 {
+				end = lineCount
+			}
+		}
+		for l := start; l <= end; l++ {
+			selected[l] = true
+		}
+	}
+	return selected
+}
+
+var tagDirectivePattern = regexp.MustCompile(`\b(tag|end)::(\S+?)\[\]\s*$`)
+
+func selectTaggedLines(b []byte, tags []string) map[int]bool {
+	mentioned := make(map[string]bool)
+	var wildcard, all, onlyNegated = false, false, true
+	for _, t := range tags {
+		switch {
+		case t == "*":
+			wildcard = true
+			onlyNegated = false
+		case t == "**":
+			all = true
+			onlyNegated = false
+		case strings.HasPrefix(t, "!"):
+			mentioned[t[1:]] = false
+		default:
+			mentioned[t] = true
+			onlyNegated = false
+		}
+	}
+	if onlyNegated {
+		all = true
+	}
+	selected := make(map[int]bool)
+	var open []string
+	for i, line := range strings.Split(string(b), "\n") {
+		if matches := tagDirectivePattern.FindStringSubmatch(line); matches != nil {
+			if matches[1] == "tag" {
+				open = append(open, matches[2])
+			} else if len(open) > 0 && open[len(open)-1] == matches[2] {
+				open = open[:len(open)-1]
+			}
+			continue
+		}
+		include := all
+		if len(open) > 0 {
+			include = wildcard || all
+			for j := len(open) - 1; j >= 0; j-- {
+				if m, ok := mentioned[open[j]]; ok {
+					include = m
+					break
+				}
+			}
+		}
+		if include {
+			selected[i+1] = true
+		}
+	}
+	return selected
+}
+
+// filterLines keeps only the preparse elements from the selected lines
+func filterLines(set asciidoc.Set, selected map[int]bool) (filtered asciidoc.Set) {
+	var line asciidoc.Set
+	for _, el := range set {
+		switch el := el.(type) {
+		case *asciidoc.NewLine:
+			l, _, _ := el.Position()
+			if selected[l] {
+				filtered = append(filtered, line...)
+				filtered = append(filtered, el)
+			}
+			line = nil
+		case *asciidoc.IfDefBlock:
+			el.Set = filterLines(el.Set, selected)
+			if len(el.Set) > 0 {
+				filtered = append(filtered, el)
+			}
+		case *asciidoc.IfNDefBlock:
+			el.Set = filterLines(el.Set, selected)
+			if len(el.Set) > 0 {
+				filtered = append(filtered, el)
+			}
+		case *asciidoc.IfEvalBlock:
+			el.Set = filterLines(el.Set, selected)
+			if len(el.Set) > 0 {
+				filtered = append(filtered, el)
+			}
+		case *asciidoc.AttributeEntry:
+			l, _, _ := el.Position()
+			if selected[l] {
+				filtered = append(filtered, el)
+			}
+		case *asciidoc.AttributeReset:
+			l, _, _ := el.Position()
+			if selected[l] {
+				filtered = append(filtered, el)
+			}
+		default:
+			line = append(line, el)
+		}
+	}
+	return
+}
+
+var sectionTitlePattern = regexp.MustCompile(`^(=+)(\s+\S)`)
+
+func offsetSectionLevel(line asciidoc.Set, offset int) asciidoc.Set {
+	if len(line) == 0 {
+		return line
+	}
+	s, ok := line[0].(*asciidoc.String)
+	if !ok {
+		return line
+	}
+	matches := sectionTitlePattern.FindStringSubmatchIndex(s.Value)
+	if matches == nil {
+		return line
+	}
+	level := max(matches[3]-matches[2]+offset, 1)
+	out := make(asciidoc.Set, len(line))
+	copy(out, line)
+	out[0] = asciidoc.NewString(strings.Repeat("=", level) + s.Value[matches[3]:])
+	return out
+}
+
+func renderPreParsedLines(path string, els asciidoc.Set) (string, LineMap, error) {
+	var sb strings.Builder
+	var lines LineMap
+	last := LineSource{Path: path}
+	for _, el := range els {
+		switch el := el.(type) {
+		case *asciidoc.String:
+			sb.WriteString(el.Value)
+		case *asciidoc.NewLine:
+			sb.WriteRune('\n')
+			source := LineSource{Path: el.Path()}
+			var column int
+			source.Line, column, _ = el.Position()
+			if column == 0 {
+				// The parser counts a newline as the start of the next line, so an empty line is positioned on the line after it
+				source.Line--
+			}
+			if source.Path == "" {
+				// Lines without a position, such as those from attribute values, take the position of the line before
+				source = last
+			}
+			lines = append(lines, source)
+			last = source
+		case asciidoc.EmptyLine:
+			sb.WriteRune('\n')
+			lines = append(lines, last)
+		case *asciidoc.CharacterReplacementReference:
+			sb.WriteString(el.ReplacementValue())
+		default:
+			return "", nil, fmt.Errorf("unexpected type rendering preparsed doc: %T", el)
+		}
+	}
+	return sb.String(), lines, nil
+}
+
+// Apply moves the positions of parsed elements from lines of the preparsed document to the files and lines they came from
+func (lm LineMap) Apply(els asciidoc.Set) {
+	lm.apply(els, make(map[asciidoc.HasPosition]struct{}))
+}
+
+func (lm LineMap) apply(els asciidoc.Set, visited map[asciidoc.HasPosition]struct{}) {
+	for _, el := range els {
+		if hp, ok := el.(asciidoc.HasPosition); ok {
+			if _, ok := visited[hp]; ok {
+				continue
+			}
+			visited[hp] = struct{}{}
+			line, column, offset := hp.Position()
+			if column == 0 && line > 1 && line <= len(lm)+1 {
+				// Elements positioned on a newline sit at the start of the line after it, so keep them there
+				source := lm[line-2]
+				hp.SetPath(source.Path)
+				hp.SetPosition(source.Line+1, column, offset)
+			} else if line > 0 && line <= len(lm) {
+				source := lm[line-1]
+				hp.SetPath(source.Path)
+				hp.SetPosition(source.Line, column, offset)
+			}
+		}
+		if s, ok := el.(*asciidoc.Section); ok {
+			lm.apply(s.Title, visited)
+		}
+		if he, ok := el.(asciidoc.HasElements); ok {
+			lm.apply(he.Elements(), visited)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package parse
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var includeTestFiles = map[string]string{
+	"/doc/section.adoc": "= Included\n\nIncluded text\n",
+	"/doc/tagged.adoc":  "before\n// tag::wanted[]\nkept\n// end::wanted[]\nafter\n",
+	"/doc/lines.adoc":   "one\ntwo\nthree\nfour\n",
+	"/doc/nested.adoc":  "nested\ninclude::lines.adoc[lines=4]\n",
+	"/doc/attr.adoc":    "ifdef::flag[]\nflagged\nendif::[]\n",
+}
+
+var includeTests = []struct {
+	Name     string
+	Document string
+	Expected string
+	Lines    LineMap
+	Includes []Include
+}{
+	{
+		Name:     "leveloffset",
+		Document: "= Doc\n\ninclude::section.adoc[leveloffset=+1]\n\nend\n",
+		Expected: "= Doc\n\n== Included\n\nIncluded text\n\nend\n",
+		Lines: LineMap{
+			{Path: "/doc/main.adoc", Line: 1},
+			{Path: "/doc/main.adoc", Line: 2},
+			{Path: "/doc/section.adoc", Line: 1},
+			{Path: "/doc/section.adoc", Line: 2},
+			{Path: "/doc/section.adoc", Line: 3},
+			{Path: "/doc/main.adoc", Line: 4},
+			{Path: "/doc/main.adoc", Line: 5},
+		},
+	},
+	{
+		Name:     "tags",
+		Document: "start\ninclude::tagged.adoc[tags=wanted]\nend\n",
+		Expected: "start\nkept\nend\n",
+		Lines: LineMap{
+			{Path: "/doc/main.adoc", Line: 1},
+			{Path: "/doc/tagged.adoc", Line: 3},
+			{Path: "/doc/main.adoc", Line: 3},
+		},
+	},
+	{
+		Name:     "negated tags",
+		Document: "include::tagged.adoc[tags=!wanted]\n",
+		Expected: "before\nafter\n",
+		Lines: LineMap{
+			{Path: "/doc/tagged.adoc", Line: 1},
+			{Path: "/doc/tagged.adoc", Line: 5},
+		},
+	},
+	{
+		Name:     "lines",
+		Document: "start\ninclude::lines.adoc[lines=2..3]\nend\n",
+		Expected: "start\ntwo\nthree\nend\n",
+		Lines: LineMap{
+			{Path: "/doc/main.adoc", Line: 1},
+			{Path: "/doc/lines.adoc", Line: 2},
+			{Path: "/doc/lines.adoc", Line: 3},
+			{Path: "/doc/main.adoc", Line: 3},
+		},
+	},
+	{
+		Name:     "nested",
+		Document: "include::nested.adoc[]\nend\n",
+		Expected: "nested\nfour\nend\n",
+		Lines: LineMap{
+			{Path: "/doc/nested.adoc", Line: 1},
+			{Path: "/doc/lines.adoc", Line: 4},
+			{Path: "/doc/main.adoc", Line: 2},
+		},
+		Includes: []Include{
+			{From: "/doc/main.adoc", To: "/doc/nested.adoc"},
+			{From: "/doc/nested.adoc", To: "/doc/lines.adoc"},
+		},
+	},
+	{
+		Name:     "inherited attributes",
+		Document: ":flag:\ninclude::attr.adoc[]\n",
+		Expected: "flagged\n",
+		Lines: LineMap{
+			{Path: "/doc/attr.adoc", Line: 2},
+		},
+	},
+	{
+		Name:     "unresolved",
+		Document: "start\ninclude::missing.adoc[]\nend\n",
+		Expected: "start\nend\n",
+		Lines: LineMap{
+			{Path: "/doc/main.adoc", Line: 1},
+			{Path: "/doc/main.adoc", Line: 3},
+		},
+	},
+}
+
+func openIncludeTestFile(path string) (io.ReadCloser, error) {
+	s, ok := includeTestFiles[path]
+	if !ok {
+		return nil, fmt.Errorf("no such file: %s", path)
+	}
+	return io.NopCloser(strings.NewReader(s)), nil
+}
+
+func TestPreParseIncludes(t *testing.T) {
+	for _, it := range includeTests {
+		out, lines, includes, err := PreParseIncludes(&AttributeContext{}, "/doc/main.adoc", strings.NewReader(it.Document), openIncludeTestFile)
+		if err != nil {
+			t.Errorf("%s: failed preparsing: %v", it.Name, err)
+			continue
+		}
+		if out != it.Expected {
+			t.Errorf("%s: unexpected output; expected %q, got %q", it.Name, it.Expected, out)
+		}
+		if !slices.Equal(lines, it.Lines) {
+			t.Errorf("%s: unexpected line sources; expected %v, got %v", it.Name, it.Lines, lines)
+		}
+		if it.Includes != nil && !slices.Equal(includes, it.Includes) {
+			t.Errorf("%s: unexpected includes; expected %v, got %v", it.Name, it.Includes, includes)
+		}
+	}
+}
+
+func TestPreParseIncludesRecursive(t *testing.T) {
+	_, _, _, err := PreParseIncludes(&AttributeContext{}, "/doc/main.adoc", strings.NewReader("include::main.adoc[]\n"), func(path string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("include::main.adoc[]\n")), nil
+	})
+	if err == nil {
+		t.Errorf("expected an error for a recursive include")
+	}
+}
@@ -52,7 +52,11 @@ func PreParseReader(context *AttributeContext, path string, reader io.Reader) (s
 	}
 }
 
-func preparse(context *AttributeContext, r *asciidoc.Reader, w *asciidoc.Writer) (err error) {
+type preparseWriter interface {
+	Write(el asciidoc.Element)
+}
+
+func preparse(context *AttributeContext, r *asciidoc.Reader, w preparseWriter) (err error) {
 
 	for {
 		el := r.Read()
@@ -91,7 +95,7 @@ func preparse(context *AttributeContext, r *asciidoc.Reader, w *asciidoc.Writer)
 	}
 }
 
-func renderReference(context *AttributeContext, name asciidoc.AttributeName, w *asciidoc.Writer) error {
+func renderReference(context *AttributeContext, name asciidoc.AttributeName, w preparseWriter) error {
 	a := context.Get(string(name))
 	if a == nil {
 		w.Write(asciidoc.NewString(fmt.Sprintf("{%s}", name)))
@@ -129,7 +133,7 @@ func renderPreParsedDoc(els asciidoc.Set) (string, error) {
 	return sb.String(), nil
 }
 
-func renderCounter(context *AttributeContext, c *asciidoc.Counter, w *asciidoc.Writer) error {
+func renderCounter(context *AttributeContext, c *asciidoc.Counter, w preparseWriter) error {
 
 	cc, ok := context.counters[c.Name]
 	if !ok {
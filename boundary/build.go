@@ -0,0 +1,255 @@
+package boundary
+
+import (
+	"log/slog"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func buildClusterVectors(cluster *matter.Cluster) *ClusterVectors {
+	cv := &ClusterVectors{Cluster: cluster.Name}
+	if cluster.ID.Valid() {
+		cv.ClusterID = cluster.ID.HexString()
+	}
+	for _, a := range cluster.Attributes {
+		if a.Access.Write == matter.PrivilegeUnknown || skipField(cluster.Attributes, a) {
+			continue
+		}
+		ev := FieldVectors(cluster, a, cluster.Attributes)
+		if ev != nil {
+			cv.Attributes = append(cv.Attributes, ev)
+		}
+	}
+	for _, c := range cluster.Commands {
+		if conformance.IsZigbee(cluster.Commands, c.Conformance) || conformance.IsDisallowed(c.Conformance) {
+			continue
+		}
+		cmv := &CommandVectors{Name: c.Name}
+		if c.ID.Valid() {
+			cmv.ID = c.ID.HexString()
+		}
+		for _, f := range c.Fields {
+			if skipField(c.Fields, f) {
+				continue
+			}
+			ev := FieldVectors(cluster, f, c.Fields)
+			if ev != nil {
+				cmv.Fields = append(cmv.Fields, ev)
+			}
+		}
+		cv.Commands = append(cv.Commands, cmv)
+	}
+	return cv
+}
+
+func skipField(fields matter.FieldSet, f *matter.Field) bool {
+	return conformance.IsZigbee(fields, f.Conformance) || conformance.IsDisallowed(f.Conformance)
+}
+
+// FieldVectors returns the test values for a field, or nil if its type has no boundaries we know how to exercise
+func FieldVectors(cluster *matter.Cluster, f *matter.Field, fields matter.FieldSet) *ElementVectors {
+	if f.Type == nil {
+		return nil
+	}
+	ev := &ElementVectors{Name: f.Name, Type: typeName(f.Type), Nullable: f.Quality.Has(matter.QualityNullable)}
+	if f.ID.Valid() {
+		ev.ID = f.ID.HexString()
+	}
+	cc := &matter.ConstraintContext{Field: f, Fields: fields}
+	var ok bool
+	switch {
+	case f.Type.IsArray(), f.Type.HasLength():
+		ok = ev.addLengths(f, cc)
+	case f.Type.BaseType == types.BaseDataTypeBoolean:
+		ev.Valid = append(ev.Valid, &Vector{Kind: KindMin, Value: false}, &Vector{Kind: KindMax, Value: true})
+		ok = true
+	default:
+		switch entity := f.Type.Entity.(type) {
+		case *matter.Enum:
+			ok = ev.addEnum(entity)
+		case *matter.Bitmap:
+			ok = ev.addBitmap(entity)
+		default:
+			ok = ev.addNumbers(f, cc)
+		}
+	}
+	if !ok {
+		slog.Debug("no boundary values for field", slog.String("cluster", cluster.Name), slog.String("field", f.Name), slog.String("type", ev.Type))
+		return nil
+	}
+	if ev.Nullable {
+		ev.Valid = append(ev.Valid, &Vector{Kind: KindNull, Null: true})
+	} else {
+		ev.Invalid = append(ev.Invalid, &Vector{Kind: KindNull, Null: true})
+	}
+	return ev
+}
+
+func (ev *ElementVectors) addNumbers(f *matter.Field, cc *matter.ConstraintContext) bool {
+	typeMin, typeMax := f.Type.Min(ev.Nullable), f.Type.Max(ev.Nullable)
+	if !typeMin.Defined() && typeMax.Type == types.DataTypeExtremeTypeUInt64 {
+		// Unsigned types don't list their minimum
+		typeMin = types.NewUintDataTypeExtreme(0, typeMax.Format)
+	}
+	if !typeMin.IsNumeric() || !typeMax.IsNumeric() {
+		return false
+	}
+	minimum, maximum := toBig(typeMin), toBig(typeMax)
+	if f.Constraint != nil {
+		if m := f.Constraint.Min(cc); m.IsNumeric() {
+			minimum = toBig(m)
+		}
+		if m := f.Constraint.Max(cc); m.IsNumeric() {
+			maximum = toBig(m)
+		}
+	}
+	if minimum.Cmp(maximum) > 0 {
+		return false
+	}
+	ev.addRange(minimum, maximum)
+	if def := defaultValue(f, cc); def.IsNumeric() {
+		ev.Valid = append(ev.Valid, numberVector(KindDefault, toBig(def)))
+	}
+	// Values outside the data type's own range can't be encoded, so they aren't tested
+	if belowMin := new(big.Int).Sub(minimum, big.NewInt(1)); belowMin.Cmp(toBig(typeMin)) >= 0 {
+		ev.Invalid = append(ev.Invalid, numberVector(KindBelowMin, belowMin))
+	}
+	if aboveMax := new(big.Int).Add(maximum, big.NewInt(1)); aboveMax.Cmp(toBig(typeMax)) <= 0 {
+		ev.Invalid = append(ev.Invalid, numberVector(KindAboveMax, aboveMax))
+	}
+	return true
+}
+
+func (ev *ElementVectors) addEnum(e *matter.Enum) bool {
+	values := make([]uint64, 0, len(e.Values))
+	defined := make(map[uint64]struct{}, len(e.Values))
+	for _, v := range e.Values {
+		if !v.Value.Valid() {
+			continue
+		}
+		values = append(values, v.Value.Value())
+		defined[v.Value.Value()] = struct{}{}
+	}
+	if len(values) == 0 {
+		return false
+	}
+	slices.Sort(values)
+	values = slices.Compact(values)
+	minimum, maximum := values[0], values[len(values)-1]
+	ev.Valid = append(ev.Valid, numberVector(KindMin, new(big.Int).SetUint64(minimum)), numberVector(KindMax, new(big.Int).SetUint64(maximum)))
+	// The midpoint between the minimum and maximum may not be defined, so the typical value is the median of the defined values
+	if typical := values[(len(values)-1)/2]; typical != minimum && typical != maximum {
+		ev.Valid = append(ev.Valid, numberVector(KindTypical, new(big.Int).SetUint64(typical)))
+	}
+	if e.Type == nil {
+		return true
+	}
+	typeMax := e.Type.Max(ev.Nullable)
+	if !typeMax.IsNumeric() {
+		return true
+	}
+	for value := uint64(0); value <= typeMax.UInt64; value++ {
+		if _, ok := defined[value]; !ok {
+			ev.Invalid = append(ev.Invalid, numberVector(KindUndefined, new(big.Int).SetUint64(value)))
+			break
+		}
+	}
+	return true
+}
+
+func (ev *ElementVectors) addBitmap(bm *matter.Bitmap) bool {
+	var mask uint64
+	for _, b := range bm.Bits {
+		m, err := b.Mask()
+		if err != nil {
+			continue
+		}
+		mask |= m
+	}
+	ev.Valid = append(ev.Valid, numberVector(KindMin, big.NewInt(0)), numberVector(KindMax, new(big.Int).SetUint64(mask)))
+	if bm.Type == nil {
+		return true
+	}
+	typeMax := bm.Type.Max(false)
+	if !typeMax.IsNumeric() {
+		return true
+	}
+	for bit := uint64(1); bit != 0 && bit <= typeMax.UInt64; bit <<= 1 {
+		if mask&bit == 0 {
+			ev.Invalid = append(ev.Invalid, numberVector(KindUndefined, new(big.Int).SetUint64(bit)))
+			break
+		}
+	}
+	return true
+}
+
+func (ev *ElementVectors) addLengths(f *matter.Field, cc *matter.ConstraintContext) bool {
+	minimum, maximum := 0, -1
+	if f.Constraint != nil {
+		if m := f.Constraint.Min(cc); m.IsNumeric() && toBig(m).IsInt64() {
+			minimum = int(toBig(m).Int64())
+		}
+		if m := f.Constraint.Max(cc); m.IsNumeric() && toBig(m).IsInt64() {
+			maximum = int(toBig(m).Int64())
+		}
+	}
+	if maximum >= 0 && minimum > maximum {
+		return false
+	}
+	ev.Valid = append(ev.Valid, lengthVector(KindMinLength, minimum, lengthValue(f.Type, minimum)))
+	if maximum < 0 {
+		return true
+	}
+	ev.Valid = append(ev.Valid, lengthVector(KindMaxLength, maximum, lengthValue(f.Type, maximum)))
+	ev.Invalid = append(ev.Invalid, lengthVector(KindOverLength, maximum+1, lengthValue(f.Type, maximum+1)))
+	return true
+}
+
+// addRange adds the minimum and maximum, and a typical value between them when there is one
+func (ev *ElementVectors) addRange(minimum *big.Int, maximum *big.Int) {
+	ev.Valid = append(ev.Valid, numberVector(KindMin, minimum), numberVector(KindMax, maximum))
+	typical := new(big.Int).Add(minimum, maximum)
+	typical.Rsh(typical, 1)
+	if typical.Cmp(minimum) != 0 && typical.Cmp(maximum) != 0 {
+		ev.Valid = append(ev.Valid, numberVector(KindTypical, typical))
+	}
+}
+
+func defaultValue(f *matter.Field, cc *matter.ConstraintContext) types.DataTypeExtreme {
+	if f.Default == "" {
+		return types.DataTypeExtreme{}
+	}
+	c, err := constraint.ParseString(f.Default)
+	if err != nil {
+		return types.DataTypeExtreme{}
+	}
+	return c.Default(cc)
+}
+
+// lengthValue is a character string of the given length; octet strings and lists are described by their length alone
+func lengthValue(dt *types.DataType, length int) any {
+	if dt.BaseType != types.BaseDataTypeString {
+		return nil
+	}
+	return strings.Repeat("a", length)
+}
+
+func toBig(e types.DataTypeExtreme) *big.Int {
+	if e.Type == types.DataTypeExtremeTypeUInt64 {
+		return new(big.Int).SetUint64(e.UInt64)
+	}
+	return big.NewInt(e.Int64)
+}
+
+func typeName(dt *types.DataType) string {
+	if dt.IsArray() && dt.EntryType != nil {
+		return "list[" + dt.EntryType.Name + "]"
+	}
+	return dt.Name
+}
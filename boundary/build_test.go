@@ -0,0 +1,149 @@
+package boundary
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+type vectorTest struct {
+	Name       string
+	Type       string
+	Nullable   bool
+	Constraint string
+	Default    string
+	Entity     types.Entity
+	Valid      string
+	Invalid    string
+}
+
+var vectorTests = []vectorTest{
+	{
+		Name:    "uint8",
+		Type:    "uint8",
+		Valid:   "min=0 max=255 typical=127",
+		Invalid: "null",
+	},
+	{
+		Name:       "constrained uint8",
+		Type:       "uint8",
+		Constraint: "10 to 20",
+		Default:    "12",
+		Valid:      "min=10 max=20 typical=15 default=12",
+		Invalid:    "belowMin=9 aboveMax=21 null",
+	},
+	{
+		Name:     "nullable uint8",
+		Type:     "uint8",
+		Nullable: true,
+		Valid:    "min=0 max=254 typical=127 null",
+		Invalid:  "",
+	},
+	{
+		Name: "enum",
+		Type: "enum8",
+		Entity: &matter.Enum{Name: "ModeEnum", Type: types.ParseDataType("enum8", false), Values: matter.EnumValueSet{
+			{Value: matter.NewNumber(0), Name: "Off"},
+			{Value: matter.NewNumber(1), Name: "On"},
+			{Value: matter.NewNumber(3), Name: "Toggle"},
+		}},
+		Valid:   "min=0 max=3 typical=1",
+		Invalid: "undefined=2 null",
+	},
+	{
+		Name: "enum with undefined midpoint",
+		Type: "enum8",
+		Entity: &matter.Enum{Name: "StepEnum", Type: types.ParseDataType("enum8", false), Values: matter.EnumValueSet{
+			{Value: matter.NewNumber(0), Name: "First"},
+			{Value: matter.NewNumber(1), Name: "Second"},
+			{Value: matter.NewNumber(5), Name: "Last"},
+		}},
+		Valid:   "min=0 max=5 typical=1",
+		Invalid: "undefined=2 null",
+	},
+	{
+		Name: "bitmap",
+		Type: "map8",
+		Entity: &matter.Bitmap{Name: "OptionsBitmap", Type: types.ParseDataType("map8", false), Bits: []matter.Bit{
+			matter.NewBitmapBit("0", "Alpha", "", nil),
+			matter.NewBitmapBit("1", "Beta", "", nil),
+		}},
+		Valid:   "min=0 max=3",
+		Invalid: "undefined=4 null",
+	},
+	{
+		Name:       "string",
+		Type:       "string",
+		Constraint: "max 4",
+		Valid:      "minLength=0:\"\" maxLength=4:\"aaaa\"",
+		Invalid:    "overLength=5:\"aaaaa\" null",
+	},
+	{
+		Name:       "length range octstr",
+		Type:       "octstr",
+		Constraint: "2 to 8",
+		Nullable:   true,
+		Valid:      "minLength=2 maxLength=8 null",
+		Invalid:    "overLength=9",
+	},
+	{
+		Name:    "unbounded string",
+		Type:    "string",
+		Valid:   "minLength=0:\"\"",
+		Invalid: "null",
+	},
+}
+
+func TestFieldVectors(t *testing.T) {
+	cluster := &matter.Cluster{Name: "Test"}
+	for _, vt := range vectorTests {
+		f := matter.NewAttribute()
+		f.Name = vt.Name
+		f.Type = types.ParseDataType(vt.Type, false)
+		f.Type.Entity = vt.Entity
+		f.Default = vt.Default
+		if vt.Nullable {
+			f.Quality = matter.QualityNullable
+		}
+		if vt.Constraint != "" {
+			c, err := constraint.ParseString(vt.Constraint)
+			if err != nil {
+				t.Errorf("failed parsing constraint %s: %v", vt.Constraint, err)
+				continue
+			}
+			f.Constraint = c
+		}
+		ev := FieldVectors(cluster, f, matter.FieldSet{f})
+		if ev == nil {
+			t.Errorf("%s: no boundary values", vt.Name)
+			continue
+		}
+		if valid := describeVectors(ev.Valid); valid != vt.Valid {
+			t.Errorf("%s: unexpected valid values; expected \"%s\", got \"%s\"", vt.Name, vt.Valid, valid)
+		}
+		if invalid := describeVectors(ev.Invalid); invalid != vt.Invalid {
+			t.Errorf("%s: unexpected invalid values; expected \"%s\", got \"%s\"", vt.Name, vt.Invalid, invalid)
+		}
+	}
+}
+
+func describeVectors(vectors []*Vector) string {
+	var descriptions []string
+	for _, v := range vectors {
+		switch {
+		case v.Null:
+			descriptions = append(descriptions, string(v.Kind))
+		case v.Length != nil && v.Value != nil:
+			descriptions = append(descriptions, fmt.Sprintf("%s=%d:%q", v.Kind, *v.Length, v.Value))
+		case v.Length != nil:
+			descriptions = append(descriptions, fmt.Sprintf("%s=%d", v.Kind, *v.Length))
+		default:
+			descriptions = append(descriptions, fmt.Sprintf("%s=%v", v.Kind, v.Value))
+		}
+	}
+	return strings.Join(descriptions, " ")
+}
@@ -0,0 +1,57 @@
+package boundary
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+type Generator struct {
+	root string
+}
+
+func NewGenerator(root string) *Generator {
+	return &Generator{root: root}
+}
+
+func (g Generator) Name() string {
+	return "Generating boundary values"
+}
+
+func (g Generator) Type() pipeline.ProcessorType {
+	return pipeline.ProcessorTypeIndividual
+}
+
+func (g *Generator) Process(cxt context.Context, input *pipeline.Data[*spec.Doc], index int32, total int32) (outputs []*pipeline.Data[string], extras []*pipeline.Data[*spec.Doc], err error) {
+	var entities []types.Entity
+	entities, err = input.Content.Entities()
+	if err != nil {
+		return
+	}
+	var clusters []*matter.Cluster
+	for _, e := range entities {
+		switch e := e.(type) {
+		case *matter.ClusterGroup:
+			clusters = append(clusters, e.Clusters...)
+		case *matter.Cluster:
+			clusters = append(clusters, e)
+		}
+	}
+	for _, cluster := range clusters {
+		var b []byte
+		b, err = json.MarshalIndent(buildClusterVectors(cluster), "", "  ")
+		if err != nil {
+			return
+		}
+		path := filepath.Join(g.root, strings.ToLower(strcase.ToSnake(matter.Case(cluster.Name)))+".json")
+		outputs = append(outputs, pipeline.NewData(path, string(b)+"\n"))
+	}
+	return
+}
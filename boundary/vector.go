@@ -0,0 +1,58 @@
+package boundary
+
+import "math/big"
+
+// Kind describes what boundary a test value exercises
+type Kind string
+
+const (
+	KindMin        Kind = "min"
+	KindMax        Kind = "max"
+	KindDefault    Kind = "default"
+	KindTypical    Kind = "typical"
+	KindNull       Kind = "null"
+	KindBelowMin   Kind = "belowMin"
+	KindAboveMax   Kind = "aboveMax"
+	KindUndefined  Kind = "undefined"
+	KindMinLength  Kind = "minLength"
+	KindMaxLength  Kind = "maxLength"
+	KindOverLength Kind = "overLength"
+)
+
+// Vector is a single test value; strings carry their value and length, octet strings and lists only their length
+type Vector struct {
+	Kind   Kind `json:"kind"`
+	Value  any  `json:"value,omitempty"`
+	Null   bool `json:"null,omitempty"`
+	Length *int `json:"length,omitempty"`
+}
+
+type ElementVectors struct {
+	ID       string    `json:"id,omitempty"`
+	Name     string    `json:"name"`
+	Type     string    `json:"type"`
+	Nullable bool      `json:"nullable,omitempty"`
+	Valid    []*Vector `json:"valid,omitempty"`
+	Invalid  []*Vector `json:"invalid,omitempty"`
+}
+
+type CommandVectors struct {
+	ID     string            `json:"id,omitempty"`
+	Name   string            `json:"name"`
+	Fields []*ElementVectors `json:"fields,omitempty"`
+}
+
+type ClusterVectors struct {
+	Cluster    string            `json:"cluster"`
+	ClusterID  string            `json:"clusterId,omitempty"`
+	Attributes []*ElementVectors `json:"attributes,omitempty"`
+	Commands   []*CommandVectors `json:"commands,omitempty"`
+}
+
+func numberVector(kind Kind, n *big.Int) *Vector {
+	return &Vector{Kind: kind, Value: n}
+}
+
+func lengthVector(kind Kind, length int, value any) *Vector {
+	return &Vector{Kind: kind, Value: value, Length: &length}
+}
@@ -0,0 +1,77 @@
+package boundary
+
+import (
+	"context"
+	"os"
+
+	"github.com/project-chip/alchemy/boundary"
+	"github.com/project-chip/alchemy/cmd/common"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "boundary",
+	Short: "generate boundary test values for writable attributes and command fields as JSON",
+	RunE:  boundaryValues,
+}
+
+func init() {
+	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
+	Command.Flags().String("outputRoot", "boundary", "the directory to write the generated test values to")
+}
+
+func boundaryValues(cmd *cobra.Command, args []string) (err error) {
+	cxt := context.Background()
+
+	specRoot, _ := cmd.Flags().GetString("specRoot")
+	outputRoot, _ := cmd.Flags().GetString("outputRoot")
+
+	asciiSettings := common.ASCIIDocAttributes(cmd)
+	fileOptions := files.Flags(cmd)
+	pipelineOptions := pipeline.Flags(cmd)
+
+	specFiles, err := pipeline.Start[struct{}](cxt, spec.Targeter(specRoot))
+	if err != nil {
+		return err
+	}
+
+	docParser := spec.NewParser(asciiSettings, common.ParserOptions(cmd)...)
+	specDocs, err := pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docParser, specFiles)
+	if err != nil {
+		return err
+	}
+
+	var specBuilder spec.Builder
+	specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, &specBuilder, specDocs)
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		filter := files.NewPathFilter[*spec.Doc](args)
+		specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, filter, specDocs)
+		if err != nil {
+			return err
+		}
+	}
+
+	generator := boundary.NewGenerator(outputRoot)
+	outputs, err := pipeline.Process[*spec.Doc, string](cxt, pipelineOptions, generator, specDocs)
+	if err != nil {
+		return err
+	}
+
+	if !fileOptions.DryRun {
+		err = os.MkdirAll(outputRoot, os.ModePerm)
+		if err != nil {
+			return err
+		}
+	}
+
+	writer := files.NewWriter[string]("Writing boundary values", fileOptions)
+	_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, writer, outputs)
+	return
+}
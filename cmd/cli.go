@@ -3,12 +3,23 @@
 package cmd
 
 import (
+	"github.com/project-chip/alchemy/cmd/boundary"
 	"github.com/project-chip/alchemy/cmd/compare"
 	"github.com/project-chip/alchemy/cmd/disco"
 	"github.com/project-chip/alchemy/cmd/dm"
 	"github.com/project-chip/alchemy/cmd/dump"
+	"github.com/project-chip/alchemy/cmd/endpoints"
 	"github.com/project-chip/alchemy/cmd/format"
+	"github.com/project-chip/alchemy/cmd/graph"
+	"github.com/project-chip/alchemy/cmd/ids"
+	"github.com/project-chip/alchemy/cmd/predicates"
+	"github.com/project-chip/alchemy/cmd/rename"
+	"github.com/project-chip/alchemy/cmd/size"
 	"github.com/project-chip/alchemy/cmd/testplan"
+	"github.com/project-chip/alchemy/cmd/types"
+	"github.com/project-chip/alchemy/cmd/validate"
+	"github.com/project-chip/alchemy/cmd/variants"
+	"github.com/project-chip/alchemy/cmd/whereis"
 	"github.com/project-chip/alchemy/cmd/zap"
 )
 
@@ -17,6 +28,7 @@ func init() {
 	rootCmd.PersistentFlags().BoolP("patch", "p", false, "generate patch file")
 	rootCmd.PersistentFlags().Bool("serial", false, "process files one-by-one")
 	rootCmd.PersistentFlags().StringSliceP("attribute", "a", []string{}, "attribute for pre-processing asciidoc; this flag can be provided more than once")
+	rootCmd.PersistentFlags().Bool("expandIncludes", false, "parse each document with its included files expanded in place, as Asciidoctor does")
 
 	rootCmd.AddCommand(format.Command)
 	rootCmd.AddCommand(disco.Command)
@@ -26,4 +38,15 @@ func init() {
 	rootCmd.AddCommand(dump.Command)
 	rootCmd.AddCommand(dm.Command)
 	rootCmd.AddCommand(testplan.Command)
+	rootCmd.AddCommand(size.Command)
+	rootCmd.AddCommand(ids.Command)
+	rootCmd.AddCommand(rename.Command)
+	rootCmd.AddCommand(graph.Command)
+	rootCmd.AddCommand(types.Command)
+	rootCmd.AddCommand(variants.Command)
+	rootCmd.AddCommand(whereis.Command)
+	rootCmd.AddCommand(predicates.Command)
+	rootCmd.AddCommand(boundary.Command)
+	rootCmd.AddCommand(endpoints.Command)
+	rootCmd.AddCommand(validate.Command)
 }
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/matter/spec"
 	"github.com/spf13/cobra"
 )
 
@@ -19,3 +20,11 @@ func ASCIIDocAttributes(cmd *cobra.Command) (settings []asciidoc.AttributeName)
 	}
 	return
 }
+
+func ParserOptions(cmd *cobra.Command) (options []spec.ParserOption) {
+	expandIncludes, _ := cmd.Flags().GetBool("expandIncludes")
+	if expandIncludes {
+		options = append(options, spec.ExpandIncludes(true))
+	}
+	return
+}
@@ -0,0 +1,16 @@
+package common
+
+import (
+	"errors"
+
+	"github.com/project-chip/alchemy/internal/files"
+)
+
+// CollectStale sets aside out of date files found in check mode, so every writer gets a chance to report them before the command fails
+func CollectStale(stale *error, err error) error {
+	if errors.Is(err, files.ErrStale) {
+		*stale = errors.Join(*stale, err)
+		return nil
+	}
+	return err
+}
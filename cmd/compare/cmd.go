@@ -3,8 +3,11 @@ package compare
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/project-chip/alchemy/cmd/common"
 	"github.com/project-chip/alchemy/compare"
@@ -29,6 +32,8 @@ func init() {
 	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
 	Command.Flags().String("sdkRoot", "connectedhomeip", "the src root of your clone of project-chip/connectedhomeip")
 	Command.Flags().Bool("text", false, "output as text")
+	Command.Flags().String("baseline", "", "a baseline file of known differences to suppress; compare fails if any other differences are found")
+	Command.Flags().String("writeBaseline", "", "write a baseline file suppressing all current differences")
 }
 
 func compareSpec(cmd *cobra.Command, args []string) (err error) {
@@ -38,6 +43,8 @@ func compareSpec(cmd *cobra.Command, args []string) (err error) {
 	specRoot, _ := cmd.Flags().GetString("specRoot")
 	sdkRoot, _ := cmd.Flags().GetString("sdkRoot")
 	text, _ := cmd.Flags().GetBool("text")
+	baselinePath, _ := cmd.Flags().GetString("baseline")
+	writeBaselinePath, _ := cmd.Flags().GetString("writeBaseline")
 
 	asciiSettings := common.ASCIIDocAttributes(cmd)
 	pipelineOptions := pipeline.Flags(cmd)
@@ -48,7 +55,7 @@ func compareSpec(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	docParser := spec.NewParser(asciiSettings)
+	docParser := spec.NewParser(asciiSettings, common.ParserOptions(cmd)...)
 	specDocs, err := pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docParser, specFiles)
 	if err != nil {
 		return err
@@ -127,16 +134,69 @@ func compareSpec(cmd *cobra.Command, args []string) (err error) {
 		return
 	}
 
+	var deviceTypeDiffs []*compare.DeviceTypeDifferences
+	deviceTypesXMLPath := filepath.Join(sdkRoot, "src/app/zap-templates/zcl/data-model/chip/matter-devices.xml")
+	var deviceTypesXML []byte
+	deviceTypesXML, err = os.ReadFile(deviceTypesXMLPath)
+	if err == nil {
+		deviceTypeDiffs, err = compare.DeviceTypes(specBuilder.Spec, deviceTypesXML)
+		if err != nil {
+			return
+		}
+	} else if os.IsNotExist(err) {
+		slog.Warn("missing device types XML; skipping device type comparison", slog.String("path", deviceTypesXMLPath))
+		err = nil
+	} else {
+		return
+	}
+
+	var baseline *compare.Baseline
+	var stale []*compare.Suppression
+	allDiffs, allDeviceTypeDiffs := diffs, deviceTypeDiffs
+	if baselinePath != "" {
+		baseline, err = compare.ReadBaseline(baselinePath)
+		if err != nil {
+			return fmt.Errorf("error reading baseline %s: %w", baselinePath, err)
+		}
+		diffs, deviceTypeDiffs = baseline.Apply(diffs, deviceTypeDiffs)
+		stale = baseline.Stale()
+		for _, s := range stale {
+			slog.Warn("stale suppression", slog.String("path", strings.Join(s.Path, " > ")), slog.String("entity", s.Entity.String()), slog.String("property", s.Property.String()))
+		}
+	}
+
 	if fileOptions.DryRun {
 		return nil
 	}
 
+	if writeBaselinePath != "" {
+		err = compare.NewBaseline(allDiffs, allDeviceTypeDiffs, baseline).Write(writeBaselinePath)
+		if err != nil {
+			return
+		}
+	}
+
 	if text {
-		writeText(os.Stdout, diffs)
-		return
+		writeText(os.Stdout, diffs, deviceTypeDiffs)
+		writeStaleSuppressions(os.Stdout, stale)
+	} else {
+		out := make([]compare.Diff, 0, len(diffs)+len(deviceTypeDiffs))
+		for _, d := range diffs {
+			out = append(out, d)
+		}
+		for _, d := range deviceTypeDiffs {
+			out = append(out, d)
+		}
+		jm := json.NewEncoder(os.Stdout)
+		jm.SetIndent("", "\t")
+		err = jm.Encode(out)
+		if err != nil {
+			return
+		}
 	}
 
-	jm := json.NewEncoder(os.Stdout)
-	jm.SetIndent("", "\t")
-	return jm.Encode(diffs)
+	if baseline != nil && len(diffs)+len(deviceTypeDiffs) > 0 {
+		return fmt.Errorf("found %d clusters and %d device types with differences not in baseline %s", len(diffs), len(deviceTypeDiffs), baselinePath)
+	}
+	return
 }
@@ -12,10 +12,30 @@ import (
 	"github.com/project-chip/alchemy/matter/types"
 )
 
-func writeText(w io.Writer, diffs []*compare.ClusterDifferences) {
+func writeText(w io.Writer, diffs []*compare.ClusterDifferences, deviceTypeDiffs []*compare.DeviceTypeDifferences) {
 	for _, cd := range diffs {
 		writeClusterDifference(w, cd)
 	}
+	for _, dd := range deviceTypeDiffs {
+		writeDeviceTypeDifference(w, dd)
+	}
+}
+
+func writeStaleSuppressions(w io.Writer, stale []*compare.Suppression) {
+	if len(stale) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "Stale suppressions:")
+	for _, s := range stale {
+		fmt.Fprintf(w, "\t%s", strings.Join(s.Path, " > "))
+		if s.Entity != types.EntityTypeUnknown {
+			fmt.Fprintf(w, " %s", s.Entity)
+		}
+		if s.Property != compare.DiffPropertyUnknown {
+			fmt.Fprintf(w, " %s", s.Property)
+		}
+		fmt.Fprintln(w)
+	}
 }
 
 func writeClusterDifference(w io.Writer, cd *compare.ClusterDifferences) {
@@ -29,6 +49,12 @@ func writeClusterDifference(w io.Writer, cd *compare.ClusterDifferences) {
 	writeEntityDiffs(w, 1, cd.Commands, types.EntityTypeCommand)
 }
 
+func writeDeviceTypeDifference(w io.Writer, dd *compare.DeviceTypeDifferences) {
+	writeEntityDiff(w, 0, &dd.IdentifiedDiff, types.EntityTypeDeviceType)
+	writeEntityDiffs(w, 1, dd.Clusters, types.EntityTypeCluster)
+	writeEntityDiffs(w, 1, dd.Conditions, types.EntityTypeCondition)
+}
+
 func writeEntityDiffs(w io.Writer, indent int, diffs []compare.Diff, entityType types.EntityType) {
 	prefix := fmt.Sprintf("%*s", indent, "\t")
 	var missing []*compare.MissingDiff
@@ -180,6 +206,16 @@ func writeBoolDiff(w io.Writer, sd *compare.BoolDiff, entityType types.EntityTyp
 }
 
 func writeConformanceDiff(w io.Writer, sd *compare.ConformanceDiff, entityType types.EntityType, name string, prefix string) {
+	if len(sd.Configurations) > 0 {
+		// Configurations are sorted simplest first
+		c := sd.Configurations[0]
+		combinations := "combinations"
+		if len(sd.Configurations) == 1 {
+			combinations = "combination"
+		}
+		fmt.Fprintf(w, "%s%s %s %s is %s, but should be %s; they disagree in %d %s, e.g. %s instead of %s when %s\n", prefix, name, entityType, sd.Property.String(), sd.ZAPConformance.ASCIIDocString(), sd.SpecConfornance.ASCIIDocString(), len(sd.Configurations), combinations, c.ZAP, c.Spec, describeConfiguration(c))
+		return
+	}
 	if sd.ZAP == conformance.StateMandatory {
 		if len(sd.SpecConfornance) > 0 {
 			mc, ok := sd.SpecConfornance[0].(*conformance.Mandatory)
@@ -193,3 +229,14 @@ func writeConformanceDiff(w io.Writer, sd *compare.ConformanceDiff, entityType t
 	}
 	fmt.Fprintf(w, "%s%s %s %s is marked as %s, but should be %s\n", prefix, name, entityType, sd.Property.String(), sd.ZAP, sd.Spec)
 }
+
+func describeConfiguration(c *compare.ConformanceConfiguration) string {
+	switch len(c.Enabled) {
+	case 0:
+		return "nothing is enabled"
+	case 1:
+		return fmt.Sprintf("only %s is enabled", c.Enabled[0])
+	default:
+		return fmt.Sprintf("only %s are enabled", strings.Join(c.Enabled, ", "))
+	}
+}
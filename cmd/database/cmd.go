@@ -5,12 +5,14 @@ package database
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/project-chip/alchemy/cmd/common"
 	"github.com/project-chip/alchemy/db"
 	"github.com/project-chip/alchemy/internal/pipeline"
 	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
 	"github.com/spf13/cobra"
 )
 
@@ -20,12 +22,26 @@ var Command = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cxt := context.Background()
 		specRoot, _ := cmd.Flags().GetString("specRoot")
+		sdkRoot, _ := cmd.Flags().GetString("sdkRoot")
 
 		asciiSettings := common.ASCIIDocAttributes(cmd)
 
 		address, _ := cmd.Flags().GetString("address")
 		port, _ := cmd.Flags().GetInt("port")
 		raw, _ := cmd.Flags().GetBool("raw")
+		export, _ := cmd.Flags().GetString("export")
+		query, _ := cmd.Flags().GetString("query")
+		queryFile, _ := cmd.Flags().GetString("query-file")
+		format, _ := cmd.Flags().GetString("format")
+
+		if len(queryFile) > 0 {
+			var b []byte
+			b, err = os.ReadFile(queryFile)
+			if err != nil {
+				return err
+			}
+			query = string(b)
+		}
 
 		pipelineOptions := pipeline.Flags(cmd)
 
@@ -34,7 +50,7 @@ var Command = &cobra.Command{
 			return err
 		}
 
-		docParser := spec.NewParser(asciiSettings)
+		docParser := spec.NewParser(asciiSettings, common.ParserOptions(cmd)...)
 		specDocs, err := pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docParser, specFiles)
 		if err != nil {
 			return err
@@ -51,21 +67,50 @@ var Command = &cobra.Command{
 			return true
 		})
 
+		var zapEntities map[string][]types.Entity
+		if len(sdkRoot) > 0 {
+			zapEntities, err = readZAPTemplates(cxt, pipelineOptions, sdkRoot)
+			if err != nil {
+				return err
+			}
+		}
+
 		sc := sql.NewContext(cxt)
 		sc.SetCurrentDatabase("matter")
 
 		h := db.New()
-		err = h.Build(sc, specBuilder.Spec, docs, raw)
+		err = h.Build(sc, specBuilder.Spec, docs, zapEntities, raw)
 		if err != nil {
 			return fmt.Errorf("error building DB: %w", err)
 		}
+		if len(export) > 0 {
+			err = h.Export(sc, export)
+			if err != nil {
+				return fmt.Errorf("error exporting DB: %w", err)
+			}
+			return nil
+		}
+		if len(query) > 0 {
+			var results []*db.QueryResult
+			results, err = h.Query(sc, query)
+			if err != nil {
+				return err
+			}
+			return writeResults(os.Stdout, format, results)
+		}
 		return h.Run(address, port)
 	},
 }
 
 func init() {
 	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
+	Command.Flags().String("sdkRoot", "", "the root of your clone of project-chip/connectedhomeip; when set, the ZAP templates are loaded into zap_ tables alongside the spec")
 	Command.Flags().String("address", "localhost", "the address to host the database server on")
 	Command.Flags().Int("port", 3306, "the port to run the database server on")
 	Command.Flags().Bool("raw", false, "parse the sections directly, bypassing entity building")
+	Command.Flags().String("export", "", "write the database to a file instead of running the server; .db, .sqlite and .sqlite3 files are written as SQLite databases, anything else as a SQL dump for SQLite or PostgreSQL")
+	Command.Flags().String("query", "", "execute the given SQL statements against the database and print the results instead of running the server")
+	Command.Flags().String("query-file", "", "execute the SQL statements in the given file against the database and print the results instead of running the server")
+	Command.Flags().String("format", "table", "the output format for query results: table, csv or json")
+	Command.MarkFlagsMutuallyExclusive("query", "query-file")
 }
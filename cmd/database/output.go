@@ -0,0 +1,91 @@
+//go:build db
+
+package database
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/project-chip/alchemy/db"
+)
+
+func writeResults(w io.Writer, format string, results []*db.QueryResult) error {
+	switch format {
+	case "table", "":
+		return writeTable(w, results)
+	case "csv":
+		return writeCSV(w, results)
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "\t")
+		if results == nil {
+			results = []*db.QueryResult{}
+		}
+		// Always an array, with one result per statement, so the shape doesn't depend on the query
+		return encoder.Encode(results)
+	default:
+		return fmt.Errorf("unknown output format: %s", format)
+	}
+}
+
+func writeTable(w io.Writer, results []*db.QueryResult) error {
+	for i, result := range results {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(result.Columns, "\t"))
+		separators := make([]string, len(result.Columns))
+		for j, col := range result.Columns {
+			separators[j] = strings.Repeat("-", len(col))
+		}
+		fmt.Fprintln(tw, strings.Join(separators, "\t"))
+		for _, row := range result.Rows {
+			fmt.Fprintln(tw, strings.Join(formatRow(row, "NULL"), "\t"))
+		}
+		err := tw.Flush()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "(%d rows)\n", len(result.Rows))
+	}
+	return nil
+}
+
+func writeCSV(w io.Writer, results []*db.QueryResult) error {
+	cw := csv.NewWriter(w)
+	for i, result := range results {
+		if i > 0 {
+			// Separate the result sets of multiple statements with a blank record
+			cw.Write(nil)
+		}
+		err := cw.Write(result.Columns)
+		if err != nil {
+			return err
+		}
+		for _, row := range result.Rows {
+			err = cw.Write(formatRow(row, ""))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatRow(row []any, null string) []string {
+	values := make([]string, len(row))
+	for i, v := range row {
+		if v == nil {
+			values[i] = null
+			continue
+		}
+		values[i] = fmt.Sprint(v)
+	}
+	return values
+}
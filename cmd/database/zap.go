@@ -0,0 +1,39 @@
+//go:build db
+
+package database
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/types"
+	"github.com/project-chip/alchemy/zap/parse"
+)
+
+func readZAPTemplates(cxt context.Context, pipelineOptions pipeline.Options, sdkRoot string) (map[string][]types.Entity, error) {
+	xmlPaths, err := pipeline.Start[struct{}](cxt, files.PathsTargeter(filepath.Join(sdkRoot, "src/app/zap-templates/zcl/data-model/chip/*.xml")))
+	if err != nil {
+		return nil, err
+	}
+
+	xmlFiles, err := pipeline.Process[struct{}, []byte](cxt, pipelineOptions, files.NewReader("Reading ZAP templates"), xmlPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	zapParser := parse.NewZapParser()
+	zapEntities, err := pipeline.Process[[]byte, []types.Entity](cxt, pipelineOptions, zapParser, xmlFiles)
+	if err != nil {
+		return nil, err
+	}
+	zapParser.ResolveReferences()
+
+	entities := make(map[string][]types.Entity, zapEntities.Size())
+	zapEntities.Range(func(path string, data *pipeline.Data[[]types.Entity]) bool {
+		entities[path] = data.Content
+		return true
+	})
+	return entities, nil
+}
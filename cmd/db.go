@@ -8,6 +8,7 @@ import (
 
 func init() {
 	rootCmd.PersistentFlags().StringSliceP("attribute", "a", []string{}, "attribute for pre-processing asciidoc; this flag can be provided more than once")
+	rootCmd.PersistentFlags().Bool("expandIncludes", false, "parse each document with its included files expanded in place, as Asciidoctor does")
 	rootCmd.AddCommand(database.Command)
 	defaultCommand = "db"
 }
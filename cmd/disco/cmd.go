@@ -50,6 +50,7 @@ func init() {
 	Command.Flags().Bool("addSpaceAfterPunctuation", true, "add missing space after punctuation")
 	Command.Flags().Bool("removeExtraSpaces", true, "remove extraneous spaces")
 	Command.Flags().Bool("disambiguateConformanceChoice", false, "ensure conformance choices are only used once per document")
+	Command.Flags().Bool("canonicalizeConformance", false, "rewrite conformance in canonical form, removing redundant and unreachable terms")
 }
 
 type discoOption func(bool) disco.Option
@@ -71,6 +72,7 @@ func getDiscoOptions(cmd *cobra.Command) []disco.Option {
 		"removeExtraSpaces":             disco.RemoveExtraSpaces,
 		"normalizeFeatureNames":         disco.NormalizeFeatureNames,
 		"disambiguateConformanceChoice": disco.DisambiguateConformanceChoice,
+		"canonicalizeConformance":       disco.CanonicalizeConformance,
 	}
 	var discoOptions []disco.Option
 	for name, o := range optionFuncs {
@@ -2,12 +2,15 @@ package dm
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"github.com/project-chip/alchemy/cmd/common"
 	"github.com/project-chip/alchemy/dm"
 	"github.com/project-chip/alchemy/internal/files"
 	"github.com/project-chip/alchemy/internal/pipeline"
 	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/sourcemap"
 	"github.com/spf13/cobra"
 )
 
@@ -23,6 +26,8 @@ func dataModel(cmd *cobra.Command, args []string) (err error) {
 
 	specRoot, _ := cmd.Flags().GetString("specRoot")
 	sdkRoot, _ := cmd.Flags().GetString("sdkRoot")
+	sourceMap, _ := cmd.Flags().GetBool("sourceMap")
+	validate, _ := cmd.Flags().GetBool("validate")
 
 	asciiSettings := common.ASCIIDocAttributes(cmd)
 	fileOptions := files.Flags(cmd)
@@ -33,7 +38,7 @@ func dataModel(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	docParser := spec.NewParser(asciiSettings)
+	docParser := spec.NewParser(asciiSettings, common.ParserOptions(cmd)...)
 	specDocs, err := pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docParser, specFiles)
 	if err != nil {
 		return err
@@ -45,6 +50,12 @@ func dataModel(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
+	docs := make([]*spec.Doc, 0, specDocs.Size())
+	specDocs.Range(func(path string, value *pipeline.Data[*spec.Doc]) bool {
+		docs = append(docs, value.Content)
+		return true
+	})
+
 	if len(args) > 0 {
 		filter := files.NewPathFilter[*spec.Doc](args)
 		specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, filter, specDocs)
@@ -53,12 +64,40 @@ func dataModel(cmd *cobra.Command, args []string) (err error) {
 		}
 	}
 
-	renderer := dm.NewRenderer(sdkRoot)
+	var rendererOptions []dm.RendererOption
+	if sourceMap || validate {
+		rendererOptions = append(rendererOptions, dm.RenderSourceMaps(sourcemap.NewGenerator(specBuilder.Spec, docs)))
+	}
+	renderer := dm.NewRenderer(sdkRoot, rendererOptions...)
 	dataModelDocs, err := pipeline.Process[*spec.Doc, string](cxt, pipelineOptions, renderer, specDocs)
 	if err != nil {
 		return err
 	}
 
+	var validateErr error
+	if validate {
+		validator := dm.NewValidator[string](sdkRoot)
+		_, validateErr = pipeline.Process[string, struct{}](cxt, pipelineOptions, validator, dataModelDocs)
+		if validateErr != nil && !errors.Is(validateErr, dm.ErrSchemaViolation) {
+			return validateErr
+		}
+		// Schema violations fail the command only after writing, so the offending XML can be inspected
+	}
+
+	if !sourceMap {
+		// Source maps rendered only to trace schema violations aren't written
+		var sourceMapPaths []string
+		dataModelDocs.Range(func(path string, value *pipeline.Data[string]) bool {
+			if strings.HasSuffix(path, sourcemap.Path("")) {
+				sourceMapPaths = append(sourceMapPaths, path)
+			}
+			return true
+		})
+		for _, path := range sourceMapPaths {
+			dataModelDocs.Delete(path)
+		}
+	}
+
 	clusterIDJSON, err := renderer.GenerateClusterIDsJson()
 	if err != nil {
 		return err
@@ -70,10 +109,13 @@ func dataModel(cmd *cobra.Command, args []string) (err error) {
 	if err != nil {
 		return err
 	}
-	return
+	return validateErr
 }
 
 func init() {
 	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
 	Command.Flags().String("sdkRoot", "connectedhomeip", "the root of your clone of project-chip/connectedhomeip")
+	Command.Flags().Bool("sourceMap", false, "write a source map alongside each data model XML file, linking its elements to the spec")
+	Command.Flags().Bool("validate", false, "validate the generated data model XML against the XSD schemas in the SDK's data_model directory")
+	Command.Flags().Bool("check", false, "compare the generated data model XML with that on disk instead of writing it, and fail if any is out of date")
 }
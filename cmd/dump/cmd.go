@@ -20,6 +20,7 @@ var Command = &cobra.Command{
 		asciiSettings := common.ASCIIDocAttributes(cmd)
 		asciiOut, _ := cmd.Flags().GetBool("ascii")
 		jsonOut, _ := cmd.Flags().GetBool("json")
+		includes, _ := cmd.Flags().GetBool("includes")
 
 		files, err := files.Paths(args)
 		if err != nil {
@@ -43,7 +44,12 @@ var Command = &cobra.Command{
 				}
 				dumpElements(doc, doc.Elements(), 0)
 			} else if jsonOut {
-				doc, err := spec.ParseFile(f, asciiSettings...)
+				var doc *spec.Doc
+				if includes {
+					doc, err = spec.ParseFileWithIncludes(f, asciiSettings...)
+				} else {
+					doc, err = spec.ParseFile(f, asciiSettings...)
+				}
 				if err != nil {
 					return fmt.Errorf("error opening doc %s: %w", f, err)
 				}
@@ -54,6 +60,12 @@ var Command = &cobra.Command{
 				encoder := json.NewEncoder(os.Stdout)
 				//encoder.SetIndent("", "\t")
 				return encoder.Encode(entities)
+			} else if includes {
+				doc, err := spec.ParseFileWithIncludes(f, asciiSettings...)
+				if err != nil {
+					return fmt.Errorf("error opening doc %s: %w", f, err)
+				}
+				dumpElements(doc, doc.Base.Elements(), 0)
 			} else {
 				doc, err := spec.ReadFile(f)
 				if err != nil {
@@ -69,4 +81,5 @@ var Command = &cobra.Command{
 func init() {
 	Command.Flags().Bool("ascii", false, "dump asciidoc object model")
 	Command.Flags().Bool("json", false, "dump json object model")
+	Command.Flags().Bool("includes", false, "expand included files in place before dumping, with their elements positioned in the files they came from")
 }
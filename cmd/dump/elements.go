@@ -33,24 +33,24 @@ func dumpElements(doc *spec.Doc, els asciidoc.Set, indent int) {
 		case asciidoc.EmptyLine:
 			fmt.Print("{empty}\n")
 		case *asciidoc.NewLine:
-			fmt.Printf("{newline%s}\n", dumpPosition(el))
+			fmt.Printf("{newline%s}\n", dumpPosition(doc, el))
 		case *asciidoc.LineBreak:
-			fmt.Printf("{linebreak%s}\n", dumpPosition(el))
+			fmt.Printf("{linebreak%s}\n", dumpPosition(doc, el))
 		/*case *asciidoc.DelimitedBlock:
 		fmt.Printf("{delim kind=%s}:\n", el.Kind)
 		dumpAttributes(el.Attributes, indent+1)
 		dumpElements(doc, el.Elements, indent+1)*/
 		case *asciidoc.AttributeEntry:
-			fmt.Printf("{attrib%s}: %s", dumpPosition(el), el.Name)
+			fmt.Printf("{attrib%s}: %s", dumpPosition(doc, el), el.Name)
 			dumpElements(doc, el.Elements(), indent+1)
 			fmt.Print("\n")
 		case *asciidoc.Paragraph:
-			fmt.Printf("{para%s}: ", dumpPosition(el))
+			fmt.Printf("{para%s}: ", dumpPosition(doc, el))
 			fmt.Print("\n")
 			dumpAttributes(el.Attributes(), indent+1)
 			dumpElements(doc, el.Elements(), indent+1)
 		case *asciidoc.Section:
-			fmt.Printf("{sec %d%s}:\n", el.Level, dumpPosition(el))
+			fmt.Printf("{sec %d%s}:\n", el.Level, dumpPosition(doc, el))
 			dumpAttributes(el.Attributes(), indent+1)
 			fmt.Print(strings.Repeat("\t", indent+1))
 			fmt.Printf("{title:}\n")
@@ -62,7 +62,7 @@ func dumpElements(doc *spec.Doc, els asciidoc.Set, indent int) {
 			fmt.Print("{str}: ", snippet(el.Value))
 			fmt.Print("\n")
 		case asciidoc.FormattedTextElement:
-			fmt.Printf("{formatted text %d%s}:\n", el.TextFormat(), dumpPosition(el))
+			fmt.Printf("{formatted text %d%s}:\n", el.TextFormat(), dumpPosition(doc, el))
 			if a, ok := el.(asciidoc.Attributable); ok {
 				dumpAttributes(a.Attributes(), indent+1)
 			}
@@ -70,7 +70,7 @@ func dumpElements(doc *spec.Doc, els asciidoc.Set, indent int) {
 			fmt.Printf("{body:}\n")
 			dumpElements(doc, el.Elements(), indent+2)
 		case *asciidoc.Table:
-			fmt.Printf("{tab%s}:\n", dumpPosition(el))
+			fmt.Printf("{tab%s}:\n", dumpPosition(doc, el))
 			dumpAttributes(el.Attributes(), indent+1)
 			dumpTable(doc, el, indent+1)
 		case *asciidoc.IfDef:
@@ -212,9 +212,12 @@ func dumpConditional(attributes asciidoc.AttributeNames, union asciidoc.Conditio
 	fmt.Print("}\n")
 }
 
-func dumpPosition(el asciidoc.Element) string {
+func dumpPosition(doc *spec.Doc, el asciidoc.Element) string {
 	if hp, ok := el.(asciidoc.HasPosition); ok {
 		l, c, _ := hp.Position()
+		if p := hp.Path(); p != "" && p != doc.Path {
+			return fmt.Sprintf(" %s:%d:%d", p, l, c)
+		}
 		return fmt.Sprintf(" %d:%d", l, c)
 	}
 	return ""
@@ -0,0 +1,85 @@
+package endpoints
+
+import (
+	"context"
+	"os"
+
+	"github.com/project-chip/alchemy/cmd/common"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/zap/generate"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "endpoints",
+	Short: "generate example ZAP configurations with an endpoint for each device type",
+	RunE:  endpoints,
+}
+
+func init() {
+	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
+	Command.Flags().String("sdkRoot", "connectedhomeip", "the root of your clone of project-chip/connectedhomeip")
+	Command.Flags().String("outputRoot", "endpoints", "the directory to write the generated ZAP configurations to")
+}
+
+func endpoints(cmd *cobra.Command, args []string) (err error) {
+	cxt := context.Background()
+
+	specRoot, _ := cmd.Flags().GetString("specRoot")
+	sdkRoot, _ := cmd.Flags().GetString("sdkRoot")
+	outputRoot, _ := cmd.Flags().GetString("outputRoot")
+
+	asciiSettings := common.ASCIIDocAttributes(cmd)
+	fileOptions := files.Flags(cmd)
+	pipelineOptions := pipeline.Flags(cmd)
+
+	specFiles, err := pipeline.Start[struct{}](cxt, spec.Targeter(specRoot))
+	if err != nil {
+		return err
+	}
+
+	docParser := spec.NewParser(asciiSettings, common.ParserOptions(cmd)...)
+	specDocs, err := pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docParser, specFiles)
+	if err != nil {
+		return err
+	}
+
+	var specBuilder spec.Builder
+	specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, &specBuilder, specDocs)
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		filter := files.NewPathFilter[*spec.Doc](args)
+		specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, filter, specDocs)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, deviceTypes, err := generate.SplitZAPDocs(cxt, specDocs)
+	if err != nil {
+		return err
+	}
+
+	generator := generate.NewEndpointGenerator(specBuilder.Spec, sdkRoot, outputRoot)
+	outputs, err := pipeline.Process[[]*matter.DeviceType, string](cxt, pipelineOptions, generator, deviceTypes)
+	if err != nil {
+		return err
+	}
+
+	if !fileOptions.DryRun {
+		err = os.MkdirAll(outputRoot, os.ModePerm)
+		if err != nil {
+			return err
+		}
+	}
+
+	writer := files.NewWriter[string]("Writing example endpoints", fileOptions)
+	_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, writer, outputs)
+	return
+}
@@ -0,0 +1,115 @@
+package graph
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/project-chip/alchemy/cmd/common"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/refgraph"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "graph",
+	Short: "export the reference graph between spec documents, clusters and entities",
+	RunE:  graph,
+}
+
+func init() {
+	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
+	Command.Flags().String("granularity", "cluster", "the granularity of the graph: document, cluster or entity")
+	Command.Flags().String("format", "json", "the output format: json, dot or graphml")
+	Command.Flags().String("focus", "", "only include nodes connected to nodes whose ID or label matches this regular expression")
+	Command.Flags().Int("depth", 0, "the maximum number of edges from a focused node; 0 is unlimited")
+	Command.Flags().String("direction", "both", "which edges to follow from a focused node: dependents, dependencies or both")
+	Command.Flags().StringSlice("kind", nil, "only include nodes of these kinds (e.g. cluster, struct, document)")
+	Command.Flags().String("out", "", "the file to write the graph to; defaults to stdout")
+}
+
+func graph(cmd *cobra.Command, args []string) (err error) {
+	cxt := context.Background()
+
+	specRoot, _ := cmd.Flags().GetString("specRoot")
+	granularityFlag, _ := cmd.Flags().GetString("granularity")
+	formatFlag, _ := cmd.Flags().GetString("format")
+	focus, _ := cmd.Flags().GetString("focus")
+	depth, _ := cmd.Flags().GetInt("depth")
+	directionFlag, _ := cmd.Flags().GetString("direction")
+	kinds, _ := cmd.Flags().GetStringSlice("kind")
+	out, _ := cmd.Flags().GetString("out")
+
+	granularity, err := refgraph.ParseGranularity(granularityFlag)
+	if err != nil {
+		return
+	}
+	format, err := refgraph.ParseFormat(formatFlag)
+	if err != nil {
+		return
+	}
+	filter := refgraph.Filter{Depth: depth, Kinds: kinds}
+	filter.Direction, err = refgraph.ParseDirection(directionFlag)
+	if err != nil {
+		return
+	}
+	if focus != "" {
+		filter.Focus, err = regexp.Compile(focus)
+		if err != nil {
+			return
+		}
+	}
+
+	asciiSettings := common.ASCIIDocAttributes(cmd)
+	pipelineOptions := pipeline.Flags(cmd)
+
+	specFiles, err := pipeline.Start[struct{}](cxt, spec.Targeter(specRoot))
+	if err != nil {
+		return err
+	}
+
+	docParser := spec.NewParser(asciiSettings, common.ParserOptions(cmd)...)
+	specDocs, err := pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docParser, specFiles)
+	if err != nil {
+		return err
+	}
+
+	var specBuilder spec.Builder
+	specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, &specBuilder, specDocs)
+	if err != nil {
+		return err
+	}
+
+	var g *refgraph.Graph
+	switch granularity {
+	case refgraph.GranularityDocument:
+		var docs []*spec.Doc
+		specDocs.Range(func(path string, data *pipeline.Data[*spec.Doc]) bool {
+			docs = append(docs, data.Content)
+			return true
+		})
+		g = refgraph.DocumentGraph(docs)
+		for _, cycle := range g.Cycles {
+			slog.Warn("circular include", slog.String("cycle", strings.Join(cycle, " -> ")))
+		}
+	case refgraph.GranularityCluster:
+		g = refgraph.ClusterGraph(specBuilder.Spec)
+	case refgraph.GranularityEntity:
+		g = refgraph.EntityGraph(specBuilder.Spec)
+	}
+
+	g = g.Filter(filter)
+
+	w := os.Stdout
+	if out != "" {
+		w, err = os.Create(out)
+		if err != nil {
+			return
+		}
+		defer w.Close()
+	}
+	return g.Write(w, format)
+}
@@ -0,0 +1,43 @@
+package ids
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/project-chip/alchemy/idaudit"
+)
+
+func writeText(w io.Writer, report *idaudit.Report) {
+	if len(report.Issues) > 0 {
+		fmt.Fprintln(w, "Issues:")
+		for _, i := range report.Issues {
+			fmt.Fprintf(w, "\t%s %s %s%s: %s\n", i.Type, i.Entity, scopePrefix(i.Scope), i.ID, strings.Join(i.Names, ", "))
+		}
+		fmt.Fprintln(w)
+	}
+	if len(report.Gaps) > 0 {
+		fmt.Fprintln(w, "Gaps:")
+		for _, g := range report.Gaps {
+			if g.From == g.To {
+				fmt.Fprintf(w, "\t%s %s%s\n", g.Entity, scopePrefix(g.Scope), g.From)
+			} else {
+				fmt.Fprintf(w, "\t%s %s%s-%s\n", g.Entity, scopePrefix(g.Scope), g.From, g.To)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	if len(report.Suggestions) > 0 {
+		fmt.Fprintln(w, "Next free IDs:")
+		for _, s := range report.Suggestions {
+			fmt.Fprintf(w, "\t%s %s%s\n", s.Entity, scopePrefix(s.Scope), s.ID)
+		}
+	}
+}
+
+func scopePrefix(scope string) string {
+	if scope == "" {
+		return ""
+	}
+	return scope + " "
+}
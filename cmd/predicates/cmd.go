@@ -0,0 +1,90 @@
+package predicates
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/project-chip/alchemy/cmd/common"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/predicate"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "predicates",
+	Short: "compile the conformance of cluster elements into C++ and Python predicate functions",
+	RunE:  predicates,
+}
+
+func init() {
+	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
+	Command.Flags().String("outputRoot", "predicates", "the directory to write the generated predicates to")
+	Command.Flags().StringSlice("language", []string{string(predicate.LanguageCPP), string(predicate.LanguagePython)}, "the languages to generate predicates in (cpp, python)")
+}
+
+func predicates(cmd *cobra.Command, args []string) (err error) {
+	cxt := context.Background()
+
+	specRoot, _ := cmd.Flags().GetString("specRoot")
+	outputRoot, _ := cmd.Flags().GetString("outputRoot")
+	languageFlags, _ := cmd.Flags().GetStringSlice("language")
+
+	asciiSettings := common.ASCIIDocAttributes(cmd)
+	fileOptions := files.Flags(cmd)
+	pipelineOptions := pipeline.Flags(cmd)
+
+	var languages []predicate.Language
+	for _, l := range languageFlags {
+		switch predicate.Language(l) {
+		case predicate.LanguageCPP, predicate.LanguagePython:
+			languages = append(languages, predicate.Language(l))
+		default:
+			return fmt.Errorf("unknown language: %s", l)
+		}
+	}
+
+	specFiles, err := pipeline.Start[struct{}](cxt, spec.Targeter(specRoot))
+	if err != nil {
+		return err
+	}
+
+	docParser := spec.NewParser(asciiSettings, common.ParserOptions(cmd)...)
+	specDocs, err := pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docParser, specFiles)
+	if err != nil {
+		return err
+	}
+
+	var specBuilder spec.Builder
+	specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, &specBuilder, specDocs)
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		filter := files.NewPathFilter[*spec.Doc](args)
+		specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, filter, specDocs)
+		if err != nil {
+			return err
+		}
+	}
+
+	generator := predicate.NewGenerator(outputRoot, languages)
+	outputs, err := pipeline.Process[*spec.Doc, string](cxt, pipelineOptions, generator, specDocs)
+	if err != nil {
+		return err
+	}
+
+	if !fileOptions.DryRun {
+		err = os.MkdirAll(outputRoot, os.ModePerm)
+		if err != nil {
+			return err
+		}
+	}
+
+	writer := files.NewWriter[string]("Writing predicates", fileOptions)
+	_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, writer, outputs)
+	return
+}
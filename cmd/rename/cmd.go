@@ -0,0 +1,67 @@
+package rename
+
+import (
+	"context"
+	"os"
+
+	"github.com/project-chip/alchemy/asciidoc/render"
+	"github.com/project-chip/alchemy/disco"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "rename <cluster>[.<element>] <newName>",
+	Short: "rename a cluster or cluster element throughout the spec and output a patch",
+	Args:  cobra.ExactArgs(2),
+	RunE:  rename,
+}
+
+func init() {
+	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
+}
+
+func rename(cmd *cobra.Command, args []string) (err error) {
+	cxt := context.Background()
+
+	specRoot, _ := cmd.Flags().GetString("specRoot")
+
+	pipelineOptions := pipeline.Flags(cmd)
+
+	specFiles, err := pipeline.Start[struct{}](cxt, spec.Targeter(specRoot))
+	if err != nil {
+		return err
+	}
+
+	docReader := spec.NewReader("Reading spec docs")
+	specDocs, err := pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docReader, specFiles)
+	if err != nil {
+		return err
+	}
+
+	var specBuilder spec.Builder
+	specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, &specBuilder, specDocs)
+	if err != nil {
+		return err
+	}
+
+	renamer := disco.NewRenamer(specBuilder.Spec, args[0], args[1])
+	var renamedDocs pipeline.Map[string, *pipeline.Data[render.InputDocument]]
+	renamedDocs, err = pipeline.Process[*spec.Doc, render.InputDocument](cxt, pipelineOptions, renamer, specDocs)
+	if err != nil {
+		return err
+	}
+
+	renderer := render.NewRenderer()
+	var renders pipeline.Map[string, *pipeline.Data[string]]
+	renders, err = pipeline.Process[render.InputDocument, string](cxt, pipelineOptions, renderer, renamedDocs)
+	if err != nil {
+		return err
+	}
+
+	patcher := files.NewPatcher[string]("Generating patch", os.Stdout)
+	_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, patcher, renders)
+	return
+}
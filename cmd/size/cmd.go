@@ -0,0 +1,98 @@
+package size
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/project-chip/alchemy/cmd/common"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/sizing"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:     "size",
+	Short:   "compute the worst-case encoded size of commands, events and attributes in the spec",
+	Aliases: []string{"sizing"},
+	RunE:    size,
+}
+
+func init() {
+	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
+	Command.Flags().Int("limit", sizing.DefaultMessageLimit, "the maximum size in bytes of a single message")
+	Command.Flags().Bool("all", false, "include elements that are bounded and fit within the limit")
+	Command.Flags().Bool("text", false, "output as text")
+}
+
+func size(cmd *cobra.Command, args []string) (err error) {
+	cxt := context.Background()
+
+	specRoot, _ := cmd.Flags().GetString("specRoot")
+	limit, _ := cmd.Flags().GetInt("limit")
+	all, _ := cmd.Flags().GetBool("all")
+	text, _ := cmd.Flags().GetBool("text")
+
+	asciiSettings := common.ASCIIDocAttributes(cmd)
+	pipelineOptions := pipeline.Flags(cmd)
+
+	specFiles, err := pipeline.Start[struct{}](cxt, spec.Targeter(specRoot))
+	if err != nil {
+		return err
+	}
+
+	docParser := spec.NewParser(asciiSettings, common.ParserOptions(cmd)...)
+	specDocs, err := pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docParser, specFiles)
+	if err != nil {
+		return err
+	}
+
+	var specBuilder spec.Builder
+	specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, &specBuilder, specDocs)
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		filter := files.NewPathFilter[*spec.Doc](args)
+		specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, filter, specDocs)
+		if err != nil {
+			return err
+		}
+	}
+
+	var clusterEntities pipeline.Map[string, *pipeline.Data[[]*matter.Cluster]]
+	clusterEntities, err = pipeline.Process[*spec.Doc, []*matter.Cluster](cxt, pipelineOptions, &common.EntityFilter[*spec.Doc, *matter.Cluster]{}, specDocs)
+	if err != nil {
+		return err
+	}
+
+	var clusters []*matter.Cluster
+	clusterEntities.Range(func(path string, data *pipeline.Data[[]*matter.Cluster]) bool {
+		clusters = append(clusters, data.Content...)
+		return true
+	})
+
+	report := sizing.Analyze(clusters, limit)
+	if !all {
+		var elements []*sizing.Element
+		for _, e := range report.Elements {
+			if e.Unbounded() || (e.ExceedsLimit && !e.LargeMessage) {
+				elements = append(elements, e)
+			}
+		}
+		report.Elements = elements
+	}
+
+	if text {
+		writeText(os.Stdout, report)
+		return
+	}
+
+	jm := json.NewEncoder(os.Stdout)
+	jm.SetIndent("", "\t")
+	return jm.Encode(report)
+}
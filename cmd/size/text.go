@@ -0,0 +1,37 @@
+package size
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/project-chip/alchemy/sizing"
+)
+
+func writeText(w io.Writer, report *sizing.Report) {
+	var cluster string
+	for _, e := range report.Elements {
+		if e.Cluster != cluster {
+			cluster = e.Cluster
+			fmt.Fprintf(w, "%s (%s):\n", e.Cluster, e.ClusterID)
+		}
+		name := e.Name
+		if e.Direction != "" {
+			name = fmt.Sprintf("%s (%s)", e.Name, e.Direction)
+		}
+		var status string
+		switch {
+		case e.ExceedsLimit && !e.LargeMessage:
+			status = fmt.Sprintf(" exceeds %d byte limit without large message quality", report.Limit)
+		case e.ExceedsLimit:
+			status = " large message"
+		}
+		if e.Unbounded() {
+			fmt.Fprintf(w, "\t%s %s %s: at least %d bytes, unbounded%s\n", e.Type, e.ID, name, e.Size.Bytes, status)
+			for _, u := range e.Size.Unbounded {
+				fmt.Fprintf(w, "\t\t%s\n", u)
+			}
+			continue
+		}
+		fmt.Fprintf(w, "\t%s %s %s: %d bytes%s\n", e.Type, e.ID, name, e.Size.Bytes, status)
+	}
+}
@@ -3,6 +3,7 @@ package testplan
 import (
 	"context"
 	"log/slog"
+	"os"
 
 	"github.com/project-chip/alchemy/asciidoc/render"
 	"github.com/project-chip/alchemy/cmd/common"
@@ -27,6 +28,11 @@ func init() {
 	Command.Flags().String("sdkRoot", "connectedhomeip", "the root of your clone of project-chip/connectedhomeip")
 	Command.Flags().String("testRoot", "chip-test-plans", "the root of your clone of CHIP-Specifications/chip-test-plans")
 	Command.Flags().Bool("overwrite", false, "overwrite existing test plans")
+	Command.Flags().Bool("merge", false, "regenerate only the generated regions of existing test plans and print the changes as a patch")
+	Command.Flags().Bool("check", false, "compare the generated regions of test plans with those on disk instead of writing them, and fail if any are out of date or any Python or YAML tests are missing")
+	Command.Flags().Bool("python", false, "also generate Python test skeletons in the SDK's src/python_testing directory")
+	Command.Flags().Bool("yaml", false, "also generate YAML tests in the SDK's src/app/tests/suites/certification directory")
+	Command.Flags().Int("copyrightYear", 0, "the year given in the copyright notice of generated Python and YAML tests; by default, overwritten tests keep the year they have and new tests get the current year")
 }
 
 func tp(cmd *cobra.Command, args []string) (err error) {
@@ -36,6 +42,12 @@ func tp(cmd *cobra.Command, args []string) (err error) {
 	specRoot, _ := cmd.Flags().GetString("specRoot")
 	testRoot, _ := cmd.Flags().GetString("testRoot")
 	overwrite, _ := cmd.Flags().GetBool("overwrite")
+	merge, _ := cmd.Flags().GetBool("merge")
+	check, _ := cmd.Flags().GetBool("check")
+	sdkRoot, _ := cmd.Flags().GetString("sdkRoot")
+	python, _ := cmd.Flags().GetBool("python")
+	yaml, _ := cmd.Flags().GetBool("yaml")
+	copyrightYear, _ := cmd.Flags().GetInt("copyrightYear")
 
 	asciiSettings := common.ASCIIDocAttributes(cmd)
 	fileOptions := files.Flags(cmd)
@@ -46,7 +58,7 @@ func tp(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	docParser := spec.NewParser(asciiSettings)
+	docParser := spec.NewParser(asciiSettings, common.ParserOptions(cmd)...)
 	specDocs, err := pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docParser, specFiles)
 	if err != nil {
 		return err
@@ -86,7 +98,12 @@ func tp(cmd *cobra.Command, args []string) (err error) {
 		}
 	}
 
-	generator := testplan.NewGenerator(testRoot, overwrite)
+	if check && !overwrite {
+		// Existing test plans are mostly hand-written, so only their generated regions are checked
+		merge = true
+	}
+
+	generator := testplan.NewGenerator(testRoot, overwrite, merge)
 	var testplans pipeline.Map[string, *pipeline.Data[string]]
 	testplans, err = pipeline.Process[*spec.Doc, string](cxt, pipelineOptions, generator, specDocs)
 	if err != nil {
@@ -112,11 +129,62 @@ func tp(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	writer := files.NewWriter[string]("Writing test plans", fileOptions)
-	_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, writer, renders)
-	if err != nil {
-		return err
+	var stale error
+	if merge {
+		merger := testplan.NewMerger()
+		renders, err = pipeline.Process[string, string](cxt, pipelineOptions, merger, renders)
+		if err != nil {
+			return err
+		}
+
+		var patcher files.Writer[string]
+		if check {
+			patcher = files.NewWriter[string]("Checking test plans", fileOptions)
+		} else {
+			patcher = files.NewPatcher[string]("Writing test plan patches", os.Stdout)
+		}
+		_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, patcher, renders)
+		if err = common.CollectStale(&stale, err); err != nil {
+			return err
+		}
+	} else {
+		writer := files.NewWriter[string]("Writing test plans", fileOptions)
+		_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, writer, renders)
+		if err = common.CollectStale(&stale, err); err != nil {
+			return err
+		}
+	}
+
+	// Python and YAML tests are skeletons meant to be edited by hand, so checking only looks for missing ones
+	if python {
+		pythonGenerator := testplan.NewPythonGenerator(sdkRoot, overwrite, copyrightYear)
+		var pythonTests pipeline.Map[string, *pipeline.Data[string]]
+		pythonTests, err = pipeline.Process[*spec.Doc, string](cxt, pipelineOptions, pythonGenerator, specDocs)
+		if err != nil {
+			return err
+		}
+
+		pythonWriter := files.NewWriter[string]("Writing Python tests", fileOptions)
+		_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, pythonWriter, pythonTests)
+		if err = common.CollectStale(&stale, err); err != nil {
+			return err
+		}
+	}
+
+	if yaml {
+		yamlGenerator := testplan.NewYAMLGenerator(sdkRoot, overwrite, copyrightYear)
+		var yamlTests pipeline.Map[string, *pipeline.Data[string]]
+		yamlTests, err = pipeline.Process[*spec.Doc, string](cxt, pipelineOptions, yamlGenerator, specDocs)
+		if err != nil {
+			return err
+		}
+
+		yamlWriter := files.NewWriter[string]("Writing YAML tests", fileOptions)
+		_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, yamlWriter, yamlTests)
+		if err = common.CollectStale(&stale, err); err != nil {
+			return err
+		}
 	}
 
-	return
+	return stale
 }
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/project-chip/alchemy/cmd/common"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/typeaudit"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:     "types",
+	Short:   "report unreferenced, single-use, duplicated and unresolved data types in the spec",
+	Aliases: []string{"unused"},
+	RunE:    audit,
+}
+
+func init() {
+	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
+	Command.Flags().Bool("text", false, "output as text")
+}
+
+func audit(cmd *cobra.Command, args []string) (err error) {
+	cxt := context.Background()
+
+	specRoot, _ := cmd.Flags().GetString("specRoot")
+	text, _ := cmd.Flags().GetBool("text")
+
+	asciiSettings := common.ASCIIDocAttributes(cmd)
+	pipelineOptions := pipeline.Flags(cmd)
+
+	specFiles, err := pipeline.Start[struct{}](cxt, spec.Targeter(specRoot))
+	if err != nil {
+		return err
+	}
+
+	docParser := spec.NewParser(asciiSettings, common.ParserOptions(cmd)...)
+	specDocs, err := pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docParser, specFiles)
+	if err != nil {
+		return err
+	}
+
+	var specBuilder spec.Builder
+	_, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, &specBuilder, specDocs)
+	if err != nil {
+		return err
+	}
+
+	report := typeaudit.Audit(specBuilder.Spec)
+	if text {
+		writeText(os.Stdout, report)
+		return
+	}
+
+	jm := json.NewEncoder(os.Stdout)
+	jm.SetIndent("", "\t")
+	return jm.Encode(report)
+}
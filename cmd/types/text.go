@@ -0,0 +1,59 @@
+package types
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/project-chip/alchemy/typeaudit"
+)
+
+func writeText(w io.Writer, report *typeaudit.Report) {
+	if len(report.Unreferenced) > 0 {
+		fmt.Fprintln(w, "Unreferenced types:")
+		for _, t := range report.Unreferenced {
+			fmt.Fprintf(w, "\t%s %s (%s)\n", t.Entity, typeName(t), t.Path)
+		}
+		fmt.Fprintln(w)
+	}
+	if len(report.SingleUse) > 0 {
+		fmt.Fprintln(w, "Global types used by a single cluster:")
+		for _, t := range report.SingleUse {
+			fmt.Fprintf(w, "\t%s %s: %s\n", t.Entity, t.Name, t.UsedBy)
+		}
+		fmt.Fprintln(w)
+	}
+	if len(report.Duplicates) > 0 {
+		fmt.Fprintln(w, "Duplicated cluster types:")
+		for _, d := range report.Duplicates {
+			fmt.Fprintf(w, "\t%s:\n", d.Entity)
+			for _, t := range d.Types {
+				fmt.Fprintf(w, "\t\t%s (%s)\n", typeName(t), t.Path)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	if len(report.Unresolved) > 0 {
+		fmt.Fprintln(w, "Unresolved type references:")
+		for _, u := range report.Unresolved {
+			name := u.Field
+			if u.Parent != "" {
+				name = u.Parent + "." + name
+			}
+			if u.Cluster != "" {
+				name = u.Cluster + "." + name
+			}
+			if u.Source != "" {
+				fmt.Fprintf(w, "\t%s: %s (%s)\n", name, u.TypeName, u.Source)
+			} else {
+				fmt.Fprintf(w, "\t%s: %s\n", name, u.TypeName)
+			}
+		}
+	}
+}
+
+func typeName(t *typeaudit.Type) string {
+	if t.Cluster == "" {
+		return t.Name
+	}
+	return t.Cluster + "." + t.Name
+}
@@ -0,0 +1,51 @@
+package validate
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/project-chip/alchemy/dm"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "validate [data model XML files]",
+	Short: "validate data model XML against the XSD schemas in the SDK's data_model directory",
+	RunE:  validate,
+}
+
+func init() {
+	Command.Flags().String("sdkRoot", "connectedhomeip", "the root of your clone of project-chip/connectedhomeip")
+}
+
+func validate(cmd *cobra.Command, args []string) (err error) {
+	cxt := context.Background()
+
+	sdkRoot, _ := cmd.Flags().GetString("sdkRoot")
+
+	pipelineOptions := pipeline.Flags(cmd)
+
+	paths := args
+	if len(paths) == 0 {
+		paths = []string{
+			filepath.Join(sdkRoot, "data_model/clusters/*.xml"),
+			filepath.Join(sdkRoot, "data_model/device_types/*.xml"),
+		}
+	}
+
+	inputs, err := pipeline.Start[struct{}](cxt, files.PathsTargeter(paths...))
+	if err != nil {
+		return err
+	}
+
+	xmlFiles, err := pipeline.Process[struct{}, []byte](cxt, pipelineOptions, files.NewReader("Reading data model XML"), inputs)
+	if err != nil {
+		return err
+	}
+
+	validator := dm.NewValidator[[]byte](sdkRoot)
+	_, err = pipeline.Process[[]byte, struct{}](cxt, pipelineOptions, validator, xmlFiles)
+	return
+}
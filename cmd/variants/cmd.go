@@ -0,0 +1,131 @@
+package variants
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/cmd/common"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/variant"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "variants",
+	Short: "compare the data model built with different sets of AsciiDoc attributes",
+	RunE:  compareVariants,
+}
+
+func init() {
+	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
+	Command.Flags().StringArray("variant", nil, "a comma-separated set of attributes to set on top of --attribute; this flag can be provided more than once (defaults to in-progress)")
+	Command.Flags().Bool("text", false, "output as text")
+}
+
+func compareVariants(cmd *cobra.Command, args []string) (err error) {
+	cxt := context.Background()
+
+	specRoot, _ := cmd.Flags().GetString("specRoot")
+	variantFlags, _ := cmd.Flags().GetStringArray("variant")
+	text, _ := cmd.Flags().GetBool("text")
+
+	asciiSettings := common.ASCIIDocAttributes(cmd)
+	pipelineOptions := pipeline.Flags(cmd)
+	parserOptions := common.ParserOptions(cmd)
+
+	if len(variantFlags) == 0 {
+		variantFlags = []string{"in-progress"}
+	}
+
+	specFiles, err := pipeline.Start[struct{}](cxt, spec.Targeter(specRoot))
+	if err != nil {
+		return err
+	}
+
+	variants := []*variant.Variant{{Name: variantName(asciiSettings)}}
+	variants[0].Spec, err = buildSpec(cxt, pipelineOptions, asciiSettings, parserOptions, specFiles)
+	if err != nil {
+		return err
+	}
+	for _, vf := range variantFlags {
+		attributes := slices.Clone(asciiSettings)
+		for _, a := range strings.Split(vf, ",") {
+			a = strings.TrimSpace(a)
+			if len(a) > 0 && !slices.Contains(attributes, asciidoc.AttributeName(a)) {
+				attributes = append(attributes, asciidoc.AttributeName(a))
+			}
+		}
+		v := &variant.Variant{Name: variantName(attributes)}
+		v.Spec, err = buildSpec(cxt, pipelineOptions, attributes, parserOptions, specFiles)
+		if err != nil {
+			return err
+		}
+		variants = append(variants, v)
+	}
+
+	report := variant.Compare(variants)
+
+	var paths []string
+	specFiles.Range(func(path string, value *pipeline.Data[struct{}]) bool {
+		paths = append(paths, path)
+		return true
+	})
+	slices.Sort(paths)
+	for _, path := range paths {
+		var issues []*variant.UnbalancedConditional
+		issues, err = checkConditionals(path)
+		if err != nil {
+			return err
+		}
+		report.Conditionals = append(report.Conditionals, issues...)
+	}
+
+	if text {
+		writeText(os.Stdout, report)
+		return
+	}
+
+	jm := json.NewEncoder(os.Stdout)
+	jm.SetIndent("", "\t")
+	return jm.Encode(report)
+}
+
+func buildSpec(cxt context.Context, pipelineOptions pipeline.Options, attributes []asciidoc.AttributeName, parserOptions []spec.ParserOption, specFiles pipeline.Map[string, *pipeline.Data[struct{}]]) (*spec.Specification, error) {
+	docParser := spec.NewParser(attributes, parserOptions...)
+	specDocs, err := pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docParser, specFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	var specBuilder spec.Builder
+	_, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, &specBuilder, specDocs)
+	if err != nil {
+		return nil, err
+	}
+	return specBuilder.Spec, nil
+}
+
+func checkConditionals(path string) ([]*variant.UnbalancedConditional, error) {
+	f, err := spec.OpenFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return variant.CheckConditionals(path, f)
+}
+
+func variantName(attributes []asciidoc.AttributeName) string {
+	if len(attributes) == 0 {
+		return "(none)"
+	}
+	names := make([]string, 0, len(attributes))
+	for _, a := range attributes {
+		names = append(names, string(a))
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,39 @@
+package variants
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/project-chip/alchemy/variant"
+)
+
+func writeText(w io.Writer, report *variant.Report) {
+	if len(report.Differences) > 0 {
+		fmt.Fprintf(w, "Differences (%s):\n", strings.Join(report.Variants, " / "))
+		for _, d := range report.Differences {
+			name := d.Name
+			if d.Cluster != "" && d.Cluster != d.Name {
+				name = d.Cluster + "." + name
+			} else if d.Document != "" {
+				name = fmt.Sprintf("%s (%s)", name, filepath.Base(d.Document))
+			}
+			values := make([]string, len(d.Values))
+			for i, v := range d.Values {
+				if v == "" {
+					v = "-"
+				}
+				values[i] = v
+			}
+			fmt.Fprintf(w, "\t%s %s %s: %s\n", d.Entity, name, d.Property, strings.Join(values, " / "))
+		}
+		fmt.Fprintln(w)
+	}
+	if len(report.Conditionals) > 0 {
+		fmt.Fprintln(w, "Unbalanced conditionals:")
+		for _, c := range report.Conditionals {
+			fmt.Fprintf(w, "\t%s:%d: %s: %s\n", c.Path, c.Line, c.Directive, c.Problem)
+		}
+	}
+}
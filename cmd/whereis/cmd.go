@@ -0,0 +1,37 @@
+package whereis
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/project-chip/alchemy/sourcemap"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "whereis <xml file> <element>",
+	Short: "print the spec location a generated ZAP template or data model XML element came from",
+	Long:  "print the spec location a generated ZAP template or data model XML element came from; the element can be a name (e.g. OnTime or \"On/Off.OnTime\") or an element path from the file's source map, which is written by running zap or dm with --sourceMap",
+	Args:  cobra.ExactArgs(2),
+	RunE:  whereis,
+}
+
+func whereis(cmd *cobra.Command, args []string) (err error) {
+	xmlPath, query := args[0], args[1]
+	sm, err := sourcemap.Read(xmlPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("no source map found for %s; regenerate it with --sourceMap", xmlPath)
+	}
+	if err != nil {
+		return
+	}
+	entries := sm.Find(query)
+	if len(entries) == 0 {
+		return fmt.Errorf("no element matching %s in %s", query, xmlPath)
+	}
+	for _, e := range entries {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s %s\t%s\n", e.Location(), e.Entity, e.Name, e.Element)
+	}
+	return
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/project-chip/alchemy/internal/pipeline"
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/sourcemap"
 	"github.com/project-chip/alchemy/zap"
 	"github.com/project-chip/alchemy/zap/generate"
 	"github.com/spf13/cobra"
@@ -25,6 +26,8 @@ func init() {
 	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
 	Command.Flags().String("sdkRoot", "connectedhomeip", "the root of your clone of project-chip/connectedhomeip")
 	Command.Flags().Bool("featureXML", true, "write new style feature XML")
+	Command.Flags().Bool("sourceMap", false, "write a source map alongside each ZAP template, linking its elements to the spec")
+	Command.Flags().Bool("check", false, "compare the generated ZAP templates with those on disk instead of writing them, and fail if any are out of date")
 }
 
 func zapTemplates(cmd *cobra.Command, args []string) (err error) {
@@ -33,6 +36,7 @@ func zapTemplates(cmd *cobra.Command, args []string) (err error) {
 
 	specRoot, _ := cmd.Flags().GetString("specRoot")
 	sdkRoot, _ := cmd.Flags().GetString("sdkRoot")
+	sourceMap, _ := cmd.Flags().GetBool("sourceMap")
 
 	asciiSettings := common.ASCIIDocAttributes(cmd)
 	fileOptions := files.Flags(cmd)
@@ -43,7 +47,7 @@ func zapTemplates(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	docParser := spec.NewParser(asciiSettings)
+	docParser := spec.NewParser(asciiSettings, common.ParserOptions(cmd)...)
 	specDocs, err := pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docParser, specFiles)
 	if err != nil {
 		return err
@@ -72,6 +76,12 @@ func zapTemplates(cmd *cobra.Command, args []string) (err error) {
 		return
 	})
 
+	docs := make([]*spec.Doc, 0, specDocs.Size())
+	specDocs.Range(func(path string, value *pipeline.Data[*spec.Doc]) bool {
+		docs = append(docs, value.Content)
+		return true
+	})
+
 	if len(args) > 0 { // Filter the spec by whatever extra args were passed
 		filter := files.NewPathFilter[*spec.Doc](args)
 		specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, filter, specDocs)
@@ -92,6 +102,9 @@ func zapTemplates(cmd *cobra.Command, args []string) (err error) {
 	if featureXML {
 		templateOptions = append(templateOptions, generate.GenerateFeatureXML(true))
 	}
+	if sourceMap {
+		templateOptions = append(templateOptions, generate.GenerateSourceMaps(sourcemap.NewGenerator(specBuilder.Spec, docs)))
+	}
 
 	var zapTemplateDocs pipeline.Map[string, *pipeline.Data[string]]
 	var provisionalZclFiles pipeline.Map[string, *pipeline.Data[struct{}]]
@@ -133,10 +146,11 @@ func zapTemplates(cmd *cobra.Command, args []string) (err error) {
 
 	}
 
+	var stale error
 	stringWriter := files.NewWriter[string]("Writing ZAP templates", fileOptions)
 
 	_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, stringWriter, zapTemplateDocs)
-	if err != nil {
+	if err = common.CollectStale(&stale, err); err != nil {
 		return err
 	}
 
@@ -144,7 +158,7 @@ func zapTemplates(cmd *cobra.Command, args []string) (err error) {
 	if provisionalDocs != nil && provisionalDocs.Size() > 0 {
 		byteWriter.SetName("Writing provisional docs")
 		_, err = pipeline.Process[[]byte, struct{}](cxt, pipelineOptions, byteWriter, provisionalDocs)
-		if err != nil {
+		if err = common.CollectStale(&stale, err); err != nil {
 			return err
 		}
 	}
@@ -152,16 +166,15 @@ func zapTemplates(cmd *cobra.Command, args []string) (err error) {
 	if patchedDeviceTypes != nil && patchedDeviceTypes.Size() > 0 {
 		byteWriter.SetName("Writing deviceTypes")
 		_, err = pipeline.Process[[]byte, struct{}](cxt, pipelineOptions, byteWriter, patchedDeviceTypes)
-		if err != nil {
+		if err = common.CollectStale(&stale, err); err != nil {
 			return err
 		}
 	}
 
 	byteWriter.SetName("Writing cluster list")
 	_, err = pipeline.Process[[]byte, struct{}](cxt, pipelineOptions, byteWriter, clusterList)
-	if err != nil {
+	if err = common.CollectStale(&stale, err); err != nil {
 		return err
 	}
-	return
-
+	return stale
 }
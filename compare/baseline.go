@@ -0,0 +1,203 @@
+package compare
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// Baseline is a set of known differences between the spec and ZAP templates which compare shouldn't report
+type Baseline struct {
+	Suppressions []*Suppression `json:"suppressions"`
+}
+
+// Suppression matches differences by the path of names from the cluster or device type down to the differing element,
+// the entity type of that element and the differing property; a "*" in the path matches any name, and omitted fields match anything
+type Suppression struct {
+	Path     []string         `json:"path"`
+	Entity   types.EntityType `json:"entity,omitempty"`
+	Property DiffProperty     `json:"property,omitempty"`
+	Source   Source           `json:"source,omitempty"`
+	Reason   string           `json:"reason,omitempty"`
+
+	matched bool
+}
+
+func ReadBaseline(path string) (*Baseline, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var baseline Baseline
+	err = json.Unmarshal(b, &baseline)
+	if err != nil {
+		return nil, err
+	}
+	return &baseline, nil
+}
+
+// NewBaseline returns a baseline suppressing every difference, carrying over reasons from an existing baseline where they match
+func NewBaseline(clusterDiffs []*ClusterDifferences, deviceTypeDiffs []*DeviceTypeDifferences, existing *Baseline) *Baseline {
+	baseline := &Baseline{}
+	add := func(s *Suppression) bool {
+		if existing != nil {
+			if es := existing.match(s); es != nil {
+				s.Reason = es.Reason
+			}
+		}
+		baseline.Suppressions = append(baseline.Suppressions, s)
+		return true
+	}
+	for _, cd := range clusterDiffs {
+		walkClusterDifferences(cd, add)
+	}
+	for _, dd := range deviceTypeDiffs {
+		walkDeviceTypeDifferences(dd, add)
+	}
+	return baseline
+}
+
+func (b *Baseline) Write(path string) error {
+	out, err := json.MarshalIndent(b, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(out, '\n'), os.ModeAppend|0644)
+}
+
+// Apply removes suppressed differences, returning those remaining
+func (b *Baseline) Apply(clusterDiffs []*ClusterDifferences, deviceTypeDiffs []*DeviceTypeDifferences) ([]*ClusterDifferences, []*DeviceTypeDifferences) {
+	keep := func(s *Suppression) bool {
+		return b.match(s) == nil
+	}
+	var remainingClusterDiffs []*ClusterDifferences
+	for _, cd := range clusterDiffs {
+		if cd = walkClusterDifferences(cd, keep); cd != nil {
+			remainingClusterDiffs = append(remainingClusterDiffs, cd)
+		}
+	}
+	var remainingDeviceTypeDiffs []*DeviceTypeDifferences
+	for _, dd := range deviceTypeDiffs {
+		if dd = walkDeviceTypeDifferences(dd, keep); dd != nil {
+			remainingDeviceTypeDiffs = append(remainingDeviceTypeDiffs, dd)
+		}
+	}
+	return remainingClusterDiffs, remainingDeviceTypeDiffs
+}
+
+// Stale returns the suppressions which didn't match any difference in the last call to Apply
+func (b *Baseline) Stale() (stale []*Suppression) {
+	for _, s := range b.Suppressions {
+		if !s.matched {
+			stale = append(stale, s)
+		}
+	}
+	return
+}
+
+func (b *Baseline) match(diff *Suppression) *Suppression {
+	for _, s := range b.Suppressions {
+		if s.matches(diff) {
+			s.matched = true
+			return s
+		}
+	}
+	return nil
+}
+
+func (s *Suppression) matches(diff *Suppression) bool {
+	if len(s.Path) > len(diff.Path) {
+		return false
+	}
+	for i, name := range s.Path {
+		if name != "*" && name != diff.Path[i] {
+			return false
+		}
+	}
+	if s.Entity != types.EntityTypeUnknown && s.Entity != diff.Entity {
+		return false
+	}
+	if s.Property != DiffPropertyUnknown && s.Property != diff.Property {
+		return false
+	}
+	if s.Source != SourceUnknown && s.Source != diff.Source {
+		return false
+	}
+	return true
+}
+
+func walkClusterDifferences(cd *ClusterDifferences, keep func(s *Suppression) bool) *ClusterDifferences {
+	path := []string{cd.Name}
+	ncd := &ClusterDifferences{IdentifiedDiff: cd.IdentifiedDiff}
+	ncd.Diffs = walkDiffs(path, cd.Entity, cd.Diffs, keep)
+	ncd.Features = walkDiffs(path, types.EntityTypeFeature, cd.Features, keep)
+	ncd.Bitmaps = walkDiffs(path, types.EntityTypeBitmap, cd.Bitmaps, keep)
+	ncd.Enums = walkDiffs(path, types.EntityTypeEnum, cd.Enums, keep)
+	ncd.Structs = walkDiffs(path, types.EntityTypeStruct, cd.Structs, keep)
+	ncd.Attributes = walkDiffs(path, types.EntityTypeAttribute, cd.Attributes, keep)
+	ncd.Events = walkDiffs(path, types.EntityTypeEvent, cd.Events, keep)
+	ncd.Commands = walkDiffs(path, types.EntityTypeCommand, cd.Commands, keep)
+	if len(ncd.Diffs) == 0 && len(ncd.Features) == 0 && len(ncd.Bitmaps) == 0 && len(ncd.Enums) == 0 && len(ncd.Structs) == 0 && len(ncd.Attributes) == 0 && len(ncd.Events) == 0 && len(ncd.Commands) == 0 {
+		return nil
+	}
+	return ncd
+}
+
+func walkDeviceTypeDifferences(dd *DeviceTypeDifferences, keep func(s *Suppression) bool) *DeviceTypeDifferences {
+	path := []string{dd.Name}
+	ndd := &DeviceTypeDifferences{IdentifiedDiff: dd.IdentifiedDiff}
+	ndd.Diffs = walkDiffs(path, dd.Entity, dd.Diffs, keep)
+	ndd.Clusters = walkDiffs(path, types.EntityTypeCluster, dd.Clusters, keep)
+	ndd.Conditions = walkDiffs(path, types.EntityTypeCondition, dd.Conditions, keep)
+	if len(ndd.Diffs) == 0 && len(ndd.Clusters) == 0 && len(ndd.Conditions) == 0 {
+		return nil
+	}
+	return ndd
+}
+
+func walkDiffs(path []string, entityType types.EntityType, diffs []Diff, keep func(s *Suppression) bool) (kept []Diff) {
+	for _, d := range diffs {
+		switch d := d.(type) {
+		case *IdentifiedDiff:
+			nd := *d
+			nd.Diffs = walkDiffs(append(slices.Clone(path), d.Name), d.Entity, d.Diffs, keep)
+			if len(nd.Diffs) > 0 {
+				kept = append(kept, &nd)
+			}
+		case *MissingDiff:
+			s := &Suppression{Path: path, Entity: d.Entity, Property: d.Property, Source: d.Source}
+			// Missing device types are reported within their own differences, so don't repeat their name
+			if d.Name != "" && !(d.Entity == entityType && d.Name == path[len(path)-1]) {
+				s.Path = append(slices.Clone(path), d.Name)
+			}
+			if keep(s) {
+				kept = append(kept, d)
+			}
+		default:
+			if keep(&Suppression{Path: path, Entity: entityType, Property: diffProperty(d)}) {
+				kept = append(kept, d)
+			}
+		}
+	}
+	return
+}
+
+func diffProperty(d Diff) DiffProperty {
+	switch d := d.(type) {
+	case *StringDiff:
+		return d.Property
+	case *BoolDiff:
+		return d.Property
+	case *ConformanceDiff:
+		return d.Property
+	case *ConstraintDiff:
+		return d.Property
+	case *QualityDiff:
+		return d.Property
+	case interface{ diffProperty() DiffProperty }:
+		return d.diffProperty()
+	}
+	return DiffPropertyUnknown
+}
@@ -1,6 +1,8 @@
 package compare
 
 import (
+	"log/slog"
+	"slices"
 	"strings"
 
 	"github.com/project-chip/alchemy/matter"
@@ -20,6 +22,109 @@ func compareConformance(entityType types.EntityType, spec conformance.Set, zap c
 		return
 	}
 
+	// ZAP templates can only say whether an element is mandatory or optional, so anything short of mandatory in the
+	// spec agrees with a plain optional
+	plain := isUnconditional(zap)
+
+	ids := referencedIdentifiers(spec, zap)
+	if len(ids) > maxConformanceIdentifiers {
+		slog.Warn("too many identifiers in conformance; comparing mandatory state only", slog.String("spec", spec.ASCIIDocString()), slog.String("zap", zap.ASCIIDocString()))
+		return compareMandatoryConformance(spec, zap)
+	}
+	var configurations []*ConformanceConfiguration
+	for mask := 0; mask < 1<<len(ids); mask++ {
+		values := make(map[string]any, len(ids)+len(conformanceConstants))
+		for id, v := range conformanceConstants {
+			values[id] = v
+		}
+		enabled := make([]string, 0, len(ids))
+		for i, id := range ids {
+			on := mask&(1<<i) != 0
+			values[id] = on
+			if on {
+				enabled = append(enabled, id)
+			}
+		}
+		cxt := conformance.Context{Values: values}
+		specState, err := evalConformanceState(spec, cxt)
+		if err != nil {
+			slog.Warn("error evaluating spec conformance", slog.String("conformance", spec.ASCIIDocString()), slog.Any("error", err))
+			return compareMandatoryConformance(spec, zap)
+		}
+		zapState, err := evalConformanceState(zap, cxt)
+		if err != nil {
+			slog.Warn("error evaluating ZAP conformance", slog.String("conformance", zap.ASCIIDocString()), slog.Any("error", err))
+			return compareMandatoryConformance(spec, zap)
+		}
+		compared := specState
+		if plain && specState != conformance.StateMandatory {
+			compared = conformance.StateOptional
+		}
+		if compared != zapState {
+			configurations = append(configurations, &ConformanceConfiguration{Enabled: enabled, Spec: specState, ZAP: zapState})
+		}
+	}
+	if len(configurations) == 0 {
+		return
+	}
+	slices.SortStableFunc(configurations, func(a, b *ConformanceConfiguration) int {
+		return len(a.Enabled) - len(b.Enabled)
+	})
+	diff := &ConformanceDiff{Type: DiffTypeMismatch, Property: DiffPropertyConformance, Spec: configurations[0].Spec, ZAP: configurations[0].ZAP, SpecConfornance: spec, ZAPConformance: zap}
+	if len(ids) > 0 {
+		// Without anything to enable there's only the one configuration, which the states already describe
+		diff.Configurations = configurations
+	}
+	diffs = append(diffs, diff)
+	return
+}
+
+// isUnconditional returns whether a conformance is a plain M or O, with no expression or choice
+func isUnconditional(set conformance.Set) bool {
+	if len(set) != 1 {
+		return false
+	}
+	switch c := set[0].(type) {
+	case *conformance.Mandatory:
+		return c.Expression == nil
+	case *conformance.Optional:
+		return c.Expression == nil && c.Choice == nil
+	}
+	return false
+}
+
+// Conformances referencing more identifiers than this are only compared on whether they're mandatory
+const maxConformanceIdentifiers = 12
+
+// Identifiers with a fixed value when evaluating conformance, rather than being enumerated
+var conformanceConstants = map[string]bool{
+	"Matter": true,
+	"Zigbee": false,
+}
+
+func referencedIdentifiers(spec conformance.Set, zap conformance.Set) (ids []string) {
+	for _, id := range append(conformance.ReferencedIdentifiers(spec), conformance.ReferencedIdentifiers(zap)...) {
+		if _, ok := conformanceConstants[id]; ok {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+func evalConformanceState(set conformance.Set, cxt conformance.Context) (conformance.State, error) {
+	state, err := set.Eval(cxt)
+	if err != nil {
+		return conformance.StateUnknown, err
+	}
+	if state == conformance.StateUnknown {
+		return conformance.StateDisallowed, nil
+	}
+	return state, nil
+}
+
+func compareMandatoryConformance(spec conformance.Set, zap conformance.Set) (diffs []Diff) {
 	var specState = conformance.StateOptional
 	var zapState = conformance.StateOptional
 	if conformance.IsMandatory(spec) {
@@ -30,11 +135,12 @@ func compareConformance(entityType types.EntityType, spec conformance.Set, zap c
 	}
 
 	if specState != zapState {
-		diffs = append(diffs, &ConformanceDiff{Type: DiffTypeMismatch, Property: DiffPropertyConformance, Spec: specState, ZAP: zapState, SpecConfornance: spec})
+		diffs = append(diffs, &ConformanceDiff{Type: DiffTypeMismatch, Property: DiffPropertyConformance, Spec: specState, ZAP: zapState, SpecConfornance: spec, ZAPConformance: zap})
 	}
 
 	return
 }
+
 func compareConstraint(entityType types.EntityType, specFieldSet matter.FieldSet, specField *matter.Field, zapFieldSet matter.FieldSet, zapField *matter.Field) (diffs []Diff) {
 	if specField.Constraint == nil && zapField.Constraint == nil {
 		return
@@ -0,0 +1,101 @@
+package compare
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/types"
+	"github.com/project-chip/alchemy/zap/parse"
+)
+
+var zapConformanceXML = `<?xml version="1.0"?>
+<configurator>
+  <cluster>
+    <name>Test</name>
+    <domain>General</domain>
+    <code>0xFFF1</code>
+    <define>TEST_CLUSTER</define>
+    <attribute side="server" code="0x0000" define="MANDATORY" type="int8u">Mandatory</attribute>
+    <attribute side="server" code="0x0001" define="OPTIONAL" type="int8u" optional="true">Optional</attribute>
+  </cluster>
+</configurator>
+`
+
+var conformanceCompareTests = []struct {
+	Spec           string
+	ZAP            string
+	Configurations []string
+}{
+	{Spec: "M", ZAP: "Mandatory"},
+	{Spec: "O", ZAP: "Optional"},
+	{Spec: "AB", ZAP: "Optional", Configurations: []string{"AB: Mandatory"}},
+	{Spec: "AB", ZAP: "Mandatory", Configurations: []string{"none: Disallowed"}},
+	{Spec: "AB, O", ZAP: "Mandatory", Configurations: []string{"none: Optional"}},
+	{Spec: "[AB]", ZAP: "Optional"},
+	{Spec: "AB | CD", ZAP: "Optional", Configurations: []string{"AB: Mandatory", "CD: Mandatory", "AB,CD: Mandatory"}},
+	{Spec: "AB & CD", ZAP: "Mandatory", Configurations: []string{"none: Disallowed", "AB: Disallowed", "CD: Disallowed"}},
+	{Spec: "!AB", ZAP: "Mandatory", Configurations: []string{"AB: Disallowed"}},
+	{Spec: "O", ZAP: "Mandatory", Configurations: []string{}},
+	{Spec: "M", ZAP: "Optional", Configurations: []string{}},
+}
+
+func TestCompareConformanceWithZAP(t *testing.T) {
+	attributes := parseZAPAttributes(t)
+	for _, ct := range conformanceCompareTests {
+		spec := conformance.ParseConformance(ct.Spec)
+		zap, ok := attributes[ct.ZAP]
+		if !ok {
+			t.Fatalf("missing ZAP attribute %s", ct.ZAP)
+		}
+		diffs := compareConformance(types.EntityTypeAttribute, spec, zap.Conformance)
+		if ct.Configurations == nil {
+			if len(diffs) > 0 {
+				t.Errorf("unexpected difference comparing %s with ZAP %s: %v", ct.Spec, zap.Conformance.ASCIIDocString(), diffs[0])
+			}
+			continue
+		}
+		if len(diffs) != 1 {
+			t.Errorf("expected one difference comparing %s with ZAP %s, got %d", ct.Spec, zap.Conformance.ASCIIDocString(), len(diffs))
+			continue
+		}
+		cd, ok := diffs[0].(*ConformanceDiff)
+		if !ok {
+			t.Errorf("unexpected difference type comparing %s with ZAP %s: %T", ct.Spec, zap.Conformance.ASCIIDocString(), diffs[0])
+			continue
+		}
+		var configurations []string
+		for _, c := range cd.Configurations {
+			enabled := strings.Join(c.Enabled, ",")
+			if enabled == "" {
+				enabled = "none"
+			}
+			configurations = append(configurations, enabled+": "+c.Spec.String())
+		}
+		if strings.Join(configurations, "; ") != strings.Join(ct.Configurations, "; ") {
+			t.Errorf("unexpected configurations comparing %s with ZAP %s; expected %v, got %v", ct.Spec, zap.Conformance.ASCIIDocString(), ct.Configurations, configurations)
+		}
+	}
+}
+
+func parseZAPAttributes(t *testing.T) map[string]*matter.Field {
+	parser := parse.NewZapParser()
+	outputs, _, err := parser.Process(context.Background(), pipeline.NewData("test.xml", []byte(zapConformanceXML)), 0, 1)
+	if err != nil {
+		t.Fatalf("failed parsing ZAP XML: %v", err)
+	}
+	attributes := make(map[string]*matter.Field)
+	for _, o := range outputs {
+		for _, e := range o.Content {
+			if c, ok := e.(*matter.Cluster); ok {
+				for _, a := range c.Attributes {
+					attributes[a.Name] = a
+				}
+			}
+		}
+	}
+	return attributes
+}
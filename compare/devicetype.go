@@ -0,0 +1,260 @@
+package compare
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/beevik/etree"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+	"github.com/project-chip/alchemy/zap/generate"
+)
+
+type DeviceTypeDifferences struct {
+	IdentifiedDiff
+
+	Clusters   []Diff `json:"clusters,omitempty"`
+	Conditions []Diff `json:"conditions,omitempty"`
+}
+
+type deviceTypeDefinition struct {
+	id         *matter.Number
+	name       string
+	typeName   string
+	class      string
+	scope      string
+	clusters   map[string]*deviceTypeClusterDefinition
+	conditions []string
+}
+
+type deviceTypeClusterDefinition struct {
+	name       string
+	client     bool
+	server     bool
+	attributes []string
+	commands   []string
+	events     []string
+	features   []string
+}
+
+func DeviceTypes(spec *spec.Specification, deviceTypesXML []byte) (diffs []*DeviceTypeDifferences, err error) {
+	if spec.BaseDeviceType == nil {
+		err = fmt.Errorf("missing base device type")
+		return
+	}
+	doc := etree.NewDocument()
+	err = doc.ReadFromBytes(deviceTypesXML)
+	if err != nil {
+		return
+	}
+	configurator := doc.SelectElement("configurator")
+	if configurator == nil {
+		err = fmt.Errorf("missing configurator element")
+		return
+	}
+	zapDeviceTypes := make(map[uint64]*deviceTypeDefinition)
+	for _, dte := range configurator.SelectElements("deviceType") {
+		zdt := readDeviceTypeElement(dte)
+		if !zdt.id.Valid() {
+			slog.Warn("invalid deviceId", slog.String("name", zdt.name))
+			continue
+		}
+		zapDeviceTypes[zdt.id.Value()] = zdt
+	}
+	for id, dt := range spec.DeviceTypes {
+		zdt, ok := zapDeviceTypes[id]
+		if !ok {
+			diffs = append(diffs, &DeviceTypeDifferences{IdentifiedDiff: IdentifiedDiff{ID: dt.ID, Name: dt.Name, Entity: types.EntityTypeDeviceType, Diffs: []Diff{newMissingDiff(dt.Name, types.EntityTypeDeviceType, SourceZAP, dt.ID)}}})
+			continue
+		}
+		delete(zapDeviceTypes, id)
+		dtd := compareDeviceTypes(dt, specDeviceTypeDefinition(spec, dt), zdt)
+		if dtd != nil {
+			diffs = append(diffs, dtd)
+		}
+	}
+	for _, zdt := range zapDeviceTypes {
+		name := strings.TrimPrefix(zdt.typeName, "Matter ")
+		if name == "" {
+			name = zdt.name
+		}
+		diffs = append(diffs, &DeviceTypeDifferences{IdentifiedDiff: IdentifiedDiff{ID: zdt.id, Name: name, Entity: types.EntityTypeDeviceType, Diffs: []Diff{newMissingDiff(name, types.EntityTypeDeviceType, SourceSpec, zdt.id)}}})
+	}
+	slices.SortFunc(diffs, func(a, b *DeviceTypeDifferences) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return
+}
+
+func readDeviceTypeElement(dte *etree.Element) *deviceTypeDefinition {
+	dt := &deviceTypeDefinition{id: matter.InvalidID, clusters: make(map[string]*deviceTypeClusterDefinition)}
+	for _, e := range dte.ChildElements() {
+		switch e.Tag {
+		case "deviceId":
+			dt.id = matter.ParseNumber(e.Text())
+		case "name":
+			dt.name = e.Text()
+		case "typeName":
+			dt.typeName = e.Text()
+		case "class":
+			dt.class = e.Text()
+		case "scope":
+			dt.scope = e.Text()
+		}
+	}
+	for _, include := range dte.FindElements("clusters/include") {
+		name := include.SelectAttrValue("cluster", "")
+		if name == "" {
+			continue
+		}
+		c := &deviceTypeClusterDefinition{
+			name:   name,
+			client: include.SelectAttrValue("client", "") == "true",
+			server: include.SelectAttrValue("server", "") == "true",
+		}
+		for _, e := range include.ChildElements() {
+			switch e.Tag {
+			case "requireAttribute":
+				c.attributes = append(c.attributes, e.Text())
+			case "requireCommand":
+				c.commands = append(c.commands, e.Text())
+			case "requireEvent":
+				c.events = append(c.events, e.Text())
+			}
+		}
+		for _, f := range include.FindElements("features/feature") {
+			code := f.SelectAttrValue("code", "")
+			if code == "" {
+				code = f.SelectAttrValue("name", "")
+			}
+			c.features = append(c.features, code)
+		}
+		dt.clusters[strings.ToLower(name)] = c
+	}
+	for _, c := range dte.FindElements("conditions/condition") {
+		dt.conditions = append(dt.conditions, c.SelectAttrValue("name", ""))
+	}
+	return dt
+}
+
+func specDeviceTypeDefinition(spec *spec.Specification, deviceType *matter.DeviceType) *deviceTypeDefinition {
+	dt := readDeviceTypeElement(generate.DeviceTypeElement(spec, deviceType))
+	// The patcher doesn't write features or conditions, so they come straight from the device type
+	for _, ers := range [][]*matter.ElementRequirement{deviceType.ElementRequirements, spec.BaseDeviceType.ElementRequirements} {
+		for _, er := range ers {
+			if er.Element != types.EntityTypeFeature {
+				continue
+			}
+			c, ok := dt.clusters[strings.ToLower(er.ClusterName)]
+			if !ok {
+				continue
+			}
+			conf, err := er.Conformance.Eval(conformance.Context{Values: map[string]any{"Matter": true}})
+			if err != nil || conf != conformance.StateMandatory {
+				continue
+			}
+			c.features = append(c.features, featureCode(spec, er))
+		}
+	}
+	for _, c := range deviceType.Conditions {
+		dt.conditions = append(dt.conditions, c.Feature)
+	}
+	return dt
+}
+
+func featureCode(spec *spec.Specification, er *matter.ElementRequirement) string {
+	cluster, ok := spec.ClustersByName[er.ClusterName]
+	if !ok || cluster.Features == nil {
+		return er.Name
+	}
+	for _, b := range cluster.Features.Bits {
+		f, ok := b.(*matter.Feature)
+		if !ok {
+			continue
+		}
+		if f.Code == er.Name || strings.EqualFold(f.Name(), er.Name) {
+			return f.Code
+		}
+	}
+	return er.Name
+}
+
+func compareDeviceTypes(deviceType *matter.DeviceType, specDeviceType *deviceTypeDefinition, zapDeviceType *deviceTypeDefinition) *DeviceTypeDifferences {
+	dd := &DeviceTypeDifferences{IdentifiedDiff: IdentifiedDiff{ID: deviceType.ID, Name: deviceType.Name, Entity: types.EntityTypeDeviceType}}
+	if !strings.EqualFold(specDeviceType.name, zapDeviceType.name) {
+		dd.Diffs = append(dd.Diffs, &StringDiff{Type: DiffTypeMismatch, Property: DiffPropertyName, Spec: specDeviceType.name, ZAP: zapDeviceType.name})
+	}
+	if !strings.EqualFold(specDeviceType.class, zapDeviceType.class) {
+		dd.Diffs = append(dd.Diffs, &StringDiff{Type: DiffTypeMismatch, Property: DiffPropertyClass, Spec: specDeviceType.class, ZAP: zapDeviceType.class})
+	}
+	if !strings.EqualFold(specDeviceType.scope, zapDeviceType.scope) {
+		dd.Diffs = append(dd.Diffs, &StringDiff{Type: DiffTypeMismatch, Property: DiffPropertyScope, Spec: specDeviceType.scope, ZAP: zapDeviceType.scope})
+	}
+	dd.Clusters = compareDeviceTypeClusters(specDeviceType.clusters, zapDeviceType.clusters)
+	dd.Conditions = compareRequiredElements(types.EntityTypeCondition, specDeviceType.conditions, zapDeviceType.conditions)
+	if len(dd.Diffs) == 0 && len(dd.Clusters) == 0 && len(dd.Conditions) == 0 {
+		return nil
+	}
+	return dd
+}
+
+func compareDeviceTypeClusters(specClusters map[string]*deviceTypeClusterDefinition, zapClusters map[string]*deviceTypeClusterDefinition) (diffs []Diff) {
+	names := make([]string, 0, len(specClusters))
+	for name := range specClusters {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		sc := specClusters[name]
+		zc, ok := zapClusters[name]
+		if !ok {
+			diffs = append(diffs, newMissingDiff(sc.name, types.EntityTypeCluster, SourceZAP))
+			continue
+		}
+		cd := &IdentifiedDiff{Type: DiffTypeMismatch, Entity: types.EntityTypeCluster, Name: sc.name}
+		if sc.client != zc.client {
+			cd.Diffs = append(cd.Diffs, &BoolDiff{Type: DiffTypeMismatch, Property: DiffPropertyClient, Spec: sc.client, ZAP: zc.client})
+		}
+		if sc.server != zc.server {
+			cd.Diffs = append(cd.Diffs, &BoolDiff{Type: DiffTypeMismatch, Property: DiffPropertyServer, Spec: sc.server, ZAP: zc.server})
+		}
+		cd.Diffs = append(cd.Diffs, compareRequiredElements(types.EntityTypeFeature, sc.features, zc.features)...)
+		cd.Diffs = append(cd.Diffs, compareRequiredElements(types.EntityTypeAttribute, sc.attributes, zc.attributes)...)
+		cd.Diffs = append(cd.Diffs, compareRequiredElements(types.EntityTypeCommand, sc.commands, zc.commands)...)
+		cd.Diffs = append(cd.Diffs, compareRequiredElements(types.EntityTypeEvent, sc.events, zc.events)...)
+		if len(cd.Diffs) > 0 {
+			diffs = append(diffs, cd)
+		}
+	}
+	var missing []string
+	for name, zc := range zapClusters {
+		if _, ok := specClusters[name]; !ok {
+			missing = append(missing, zc.name)
+		}
+	}
+	slices.Sort(missing)
+	for _, name := range missing {
+		diffs = append(diffs, newMissingDiff(name, types.EntityTypeCluster, SourceSpec))
+	}
+	return
+}
+
+func compareRequiredElements(entityType types.EntityType, specNames []string, zapNames []string) (diffs []Diff) {
+	slices.Sort(specNames)
+	slices.Sort(zapNames)
+	for _, name := range specNames {
+		if !slices.Contains(zapNames, name) {
+			diffs = append(diffs, newMissingDiff(name, entityType, SourceZAP))
+		}
+	}
+	for _, name := range zapNames {
+		if !slices.Contains(specNames, name) {
+			diffs = append(diffs, newMissingDiff(name, entityType, SourceSpec))
+		}
+	}
+	return
+}
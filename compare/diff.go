@@ -77,6 +77,10 @@ const (
 	DiffPropertyMinLength
 	DiffPropertyMax
 	DiffPropertyMin
+	DiffPropertyClass
+	DiffPropertyScope
+	DiffPropertyClient
+	DiffPropertyServer
 )
 
 var (
@@ -108,6 +112,10 @@ var (
 		DiffPropertyMinLength:         "minLength",
 		DiffPropertyMax:               "max",
 		DiffPropertyMin:               "min",
+		DiffPropertyClass:             "class",
+		DiffPropertyScope:             "scope",
+		DiffPropertyClient:            "client",
+		DiffPropertyServer:            "server",
 	}
 	diffPropertyValues = map[string]DiffProperty{
 		"unknown":           DiffPropertyUnknown,
@@ -137,6 +145,10 @@ var (
 		"minLength":         DiffPropertyMinLength,
 		"max":               DiffPropertyMax,
 		"min":               DiffPropertyMin,
+		"class":             DiffPropertyClass,
+		"scope":             DiffPropertyScope,
+		"client":            DiffPropertyClient,
+		"server":            DiffPropertyServer,
 	}
 )
 
@@ -265,11 +277,20 @@ func (d BoolDiff) String() string {
 }
 
 type ConformanceDiff struct {
-	Type            DiffType          `json:"type"`
-	Property        DiffProperty      `json:"property"`
-	Spec            conformance.State `json:"spec"`
-	ZAP             conformance.State `json:"zap"`
-	SpecConfornance conformance.Set   `json:"specConformance"`
+	Type            DiffType                    `json:"type"`
+	Property        DiffProperty                `json:"property"`
+	Spec            conformance.State           `json:"spec"`
+	ZAP             conformance.State           `json:"zap"`
+	SpecConfornance conformance.Set             `json:"specConformance"`
+	ZAPConformance  conformance.Set             `json:"zapConformance,omitempty"`
+	Configurations  []*ConformanceConfiguration `json:"configurations,omitempty"`
+}
+
+// ConformanceConfiguration is a combination of enabled features and elements for which the spec and ZAP conformances disagree
+type ConformanceConfiguration struct {
+	Enabled []string          `json:"enabled"`
+	Spec    conformance.State `json:"spec"`
+	ZAP     conformance.State `json:"zap"`
 }
 
 func (d ConformanceDiff) String() string {
@@ -313,6 +334,10 @@ func (d PropertyDiff[T]) String() string {
 	return diffPropertyNames[d.Property]
 }
 
+func (d PropertyDiff[T]) diffProperty() DiffProperty {
+	return d.Property
+}
+
 func NewPropertyDiff[T ~uint8](diffType DiffType, property DiffProperty, spec T, zap T) *PropertyDiff[T] {
 	return &PropertyDiff[T]{
 		Type:     diffType,
@@ -6,9 +6,10 @@ import (
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
 )
 
-func (h *Host) Build(sc *sql.Context, spec *spec.Specification, docs []*spec.Doc, raw bool) error {
+func (h *Host) Build(sc *sql.Context, spec *spec.Specification, docs []*spec.Doc, zapEntities map[string][]types.Entity, raw bool) error {
 	h.base = &sectionInfo{children: make(map[string][]*sectionInfo)}
 	var sis []*sectionInfo
 	for _, d := range docs {
@@ -22,19 +23,28 @@ func (h *Host) Build(sc *sql.Context, spec *spec.Specification, docs []*spec.Doc
 
 	}
 	h.base.children[documentTable] = sis
-	return h.createTables(sc, h.base)
+	resolveSupersets(h.base)
+	err := h.createTables(sc, h.base, h.tableNames, "")
+	if err != nil {
+		return err
+	}
+	if len(zapEntities) == 0 {
+		return nil
+	}
+	zapBase, zapTableNames := h.indexZAP(sc, zapEntities)
+	return h.createTables(sc, zapBase, zapTableNames, zapTablePrefix)
 }
 
-func (h *Host) createTables(sc *sql.Context, bs *sectionInfo) error {
+func (h *Host) createTables(sc *sql.Context, bs *sectionInfo, tableNames []string, prefix string) error {
 	slog.InfoContext(sc, "Creating tables...")
-	for _, tableName := range h.tableNames {
+	for _, tableName := range tableNames {
 		ts, ok := tableSchema[tableName]
 		if !ok {
 			slog.Error("Table missing", "name", tableName)
 			continue
 		}
 		sis := findSectionInfos(bs, tableName)
-		err := h.createTable(sc, tableName, ts.parent, sis, ts.columns)
+		err := h.createTable(sc, prefix+tableName, tableName, ts, sis)
 		if err != nil {
 			return fmt.Errorf("error creating table %s: %w", tableName, err)
 		}
@@ -28,12 +28,13 @@ func (h *Host) indexClusterModel(cxt context.Context, parent *sectionInfo, clust
 			featureRow.values[matter.TableColumnFeature] = f.Name()
 			featureRow.values[matter.TableColumnSummary] = f.Summary()
 			fci := &sectionInfo{id: h.nextID(featureTable), parent: ci, values: featureRow}
+			h.indexConformance(fci, featureTable, f.Conformance(), cluster)
 			ci.children[featureTable] = append(ci.children[featureTable], fci)
 		}
 	}
 
 	for _, a := range cluster.Attributes {
-		h.readField(a, ci, attributeTable, types.EntityTypeAttribute)
+		h.readField(cluster, a, cluster.Attributes, ci, attributeTable, types.EntityTypeAttribute)
 	}
 
 	err := h.indexDataTypeModels(cxt, ci, cluster)
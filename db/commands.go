@@ -32,8 +32,9 @@ func (h *Host) indexCommandModels(cxt context.Context, parent *sectionInfo, clus
 		}
 		ci := &sectionInfo{id: h.nextID(commandTable), parent: parent, values: row, children: make(map[string][]*sectionInfo)}
 		parent.children[commandTable] = append(parent.children[commandTable], ci)
+		h.indexConformance(ci, commandTable, c.Conformance, cluster)
 		for _, ef := range c.Fields {
-			h.readField(ef, ci, commandFieldTable, types.EntityTypeCommandField)
+			h.readField(cluster, ef, c.Fields, ci, commandFieldTable, types.EntityTypeCommandField)
 		}
 	}
 	return nil
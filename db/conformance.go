@@ -0,0 +1,149 @@
+package db
+
+import (
+	"math"
+
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func (h *Host) indexConformance(entity *sectionInfo, entityTable string, set conformance.Set, store conformance.IdentifierStore) {
+	for i, c := range set {
+		row := newCustomDBRow()
+		row.custom["entity_table"] = entityTable
+		row.custom["entity_id"] = entity.id
+		row.custom["position"] = int32(i)
+		row.custom["type"] = c.Type().String()
+		row.custom["conformance"] = c.ASCIIDocString()
+		var exp conformance.Expression
+		switch c := c.(type) {
+		case *conformance.Mandatory:
+			exp = c.Expression
+		case *conformance.Optional:
+			exp = c.Expression
+			if c.Choice != nil {
+				row.custom["choice"] = c.Choice.Set
+				if c.Choice.Limit != nil {
+					row.custom["choice_limit"] = c.Choice.Limit.ASCIIDocString()
+				}
+			}
+		}
+		ci := &sectionInfo{id: h.nextID(conformanceTable), parent: entity, values: row, children: make(map[string][]*sectionInfo)}
+		if exp != nil {
+			row.custom["expression"] = exp.ASCIIDocString()
+			h.indexConformanceReferences(ci, exp, false, store)
+		}
+		addChild(entity, conformanceTable, ci)
+	}
+}
+
+func (h *Host) indexConformanceReferences(parent *sectionInfo, exp conformance.Expression, negated bool, store conformance.IdentifierStore) {
+	switch exp := exp.(type) {
+	case *conformance.FeatureExpression:
+		h.addConformanceReference(parent, "feature", exp.Feature, negated != exp.Not, store)
+	case *conformance.IdentifierExpression:
+		h.addConformanceReference(parent, "identifier", exp.ID, negated != exp.Not, store)
+	case *conformance.ReferenceExpression:
+		h.addConformanceReference(parent, "reference", exp.Reference, negated != exp.Not, nil)
+	case *conformance.LogicalExpression:
+		// A negated logical expression negates each of its terms
+		negated = negated != exp.Not
+		h.indexConformanceReferences(parent, exp.Left, negated, store)
+		for _, r := range exp.Right {
+			h.indexConformanceReferences(parent, r, negated, store)
+		}
+	case *conformance.EqualityExpression:
+		h.indexConformanceReferences(parent, exp.Left, negated, store)
+		h.indexConformanceReferences(parent, exp.Right, negated, store)
+	}
+}
+
+func (h *Host) addConformanceReference(parent *sectionInfo, referenceType string, reference string, negated bool, store conformance.IdentifierStore) {
+	row := newCustomDBRow()
+	row.custom["reference_type"] = referenceType
+	row.custom["reference"] = reference
+	var not int8
+	if negated {
+		not = 1
+	}
+	row.custom["negated"] = not
+	if store != nil {
+		if e, ok := store.Identifier(reference); ok && e != nil {
+			row.custom["entity_type"] = e.EntityType().String()
+		}
+	}
+	addChild(parent, conformanceReferenceTable, &sectionInfo{id: h.nextID(conformanceReferenceTable), parent: parent, values: row})
+}
+
+func (h *Host) indexConstraint(entity *sectionInfo, entityTable string, c constraint.Constraint, cc constraint.Context, dataType *types.DataType) {
+	appliesTo := "value"
+	if dataType != nil {
+		switch dataType.BaseType {
+		case types.BaseDataTypeString, types.BaseDataTypeOctStr:
+			appliesTo = "length"
+		}
+	}
+	var position int32
+	h.indexConstraintLimits(entity, entityTable, c, cc, dataType, appliesTo, &position)
+}
+
+func (h *Host) indexConstraintLimits(entity *sectionInfo, entityTable string, c constraint.Constraint, cc constraint.Context, dataType *types.DataType, appliesTo string, position *int32) {
+	if c == nil || constraint.IsBlank(c) {
+		return
+	}
+	switch c := c.(type) {
+	case constraint.Set:
+		for _, sc := range c {
+			h.indexConstraintLimits(entity, entityTable, sc, cc, dataType, appliesTo, position)
+		}
+		return
+	case *constraint.ListConstraint:
+		h.indexConstraintLimits(entity, entityTable, c.Constraint, cc, dataType, "count", position)
+		var entryType *types.DataType
+		if dataType != nil {
+			entryType = dataType.EntryType
+		}
+		entryAppliesTo := "entry"
+		if entryType != nil {
+			switch entryType.BaseType {
+			case types.BaseDataTypeString, types.BaseDataTypeOctStr:
+				entryAppliesTo = "entry_length"
+			}
+		}
+		h.indexConstraintLimits(entity, entityTable, c.EntryConstraint, cc, entryType, entryAppliesTo, position)
+		return
+	}
+	row := newCustomDBRow()
+	row.custom["entity_table"] = entityTable
+	row.custom["entity_id"] = entity.id
+	row.custom["position"] = *position
+	row.custom["applies_to"] = appliesTo
+	row.custom["type"] = c.Type().String()
+	row.custom["constraint_text"] = c.ASCIIDocString(dataType)
+	if cc != nil {
+		row.custom["min_value"] = extremeValue(c.Min(cc))
+		row.custom["max_value"] = extremeValue(c.Max(cc))
+	}
+	*position++
+	addChild(entity, constraintLimitTable, &sectionInfo{id: h.nextID(constraintLimitTable), parent: entity, values: row})
+}
+
+func extremeValue(e types.DataTypeExtreme) any {
+	switch e.Type {
+	case types.DataTypeExtremeTypeInt64:
+		return e.Int64
+	case types.DataTypeExtremeTypeUInt64:
+		if e.UInt64 <= math.MaxInt64 {
+			return int64(e.UInt64)
+		}
+	}
+	return nil
+}
+
+func addChild(parent *sectionInfo, tableName string, child *sectionInfo) {
+	if parent.children == nil {
+		parent.children = make(map[string][]*sectionInfo)
+	}
+	parent.children[tableName] = append(parent.children[tableName], child)
+}
@@ -32,6 +32,7 @@ func (h *Host) indexBitmaps(cluster *matter.Cluster, parent *sectionInfo) {
 			bmr.values[matter.TableColumnSummary] = bmv.Summary()
 			bmr.values[matter.TableColumnConformance] = bmv.Conformance().ASCIIDocString()
 			bv := &sectionInfo{id: h.nextID(bitmapValue), parent: bi, values: bmr}
+			h.indexConformance(bv, bitmapValue, bmv.Conformance(), cluster)
 			bi.children[bitmapValue] = append(bi.children[bitmapValue], bv)
 		}
 	}
@@ -51,12 +52,13 @@ func (h *Host) indexEnums(cluster *matter.Cluster, parent *sectionInfo) {
 			bmr.values[matter.TableColumnSummary] = env.Summary
 			bmr.values[matter.TableColumnConformance] = env.Conformance.ASCIIDocString()
 			bv := &sectionInfo{id: h.nextID(enumValue), parent: ei, values: bmr}
+			h.indexConformance(bv, enumValue, env.Conformance, cluster)
 			ei.children[enumValue] = append(ei.children[enumValue], bv)
 		}
 	}
 }
 
-func (h *Host) readField(f *matter.Field, parent *sectionInfo, tableName string, entityType types.EntityType) {
+func (h *Host) readField(cluster *matter.Cluster, f *matter.Field, fields matter.FieldSet, parent *sectionInfo, tableName string, entityType types.EntityType) {
 	sr := newDBRow()
 
 	var t string
@@ -85,6 +87,8 @@ func (h *Host) readField(f *matter.Field, parent *sectionInfo, tableName string,
 	}
 	sv := &sectionInfo{id: h.nextID(tableName), parent: parent, values: sr}
 	parent.children[tableName] = append(parent.children[tableName], sv)
+	h.indexConformance(sv, tableName, f.Conformance, cluster)
+	h.indexConstraint(sv, tableName, f.Constraint, &matter.ConstraintContext{Field: f, Fields: fields}, f.Type)
 }
 
 func (h *Host) indexDataTypes(cxt context.Context, doc *spec.Doc, ds *sectionInfo, dts *spec.Section) (err error) {
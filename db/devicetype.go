@@ -4,10 +4,12 @@ import (
 	"context"
 
 	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
 )
 
 func (h *Host) indexDeviceTypeModel(cxt context.Context, parent *sectionInfo, deviceType *matter.DeviceType) error {
-	deviceTypeRow := newDBRow()
+	deviceTypeRow := newCustomDBRow()
 	deviceTypeRow.values[matter.TableColumnID] = deviceType.ID.IntString()
 	deviceTypeRow.values[matter.TableColumnName] = deviceType.Name
 	deviceTypeRow.values[matter.TableColumnSuperset] = deviceType.Superset
@@ -52,8 +54,65 @@ func (h *Host) indexDeviceTypeModel(cxt context.Context, parent *sectionInfo, de
 		}
 		fci := &sectionInfo{id: h.nextID(deviceTypeClusterRequirementTable), parent: dti, values: row}
 		dti.children[deviceTypeClusterRequirementTable] = append(dti.children[deviceTypeClusterRequirementTable], fci)
+		h.indexConformance(fci, deviceTypeClusterRequirementTable, c.Conformance, nil)
+	}
 
+	for _, er := range deviceType.ElementRequirements {
+		row := newCustomDBRow()
+		row.values[matter.TableColumnID] = er.ID.IntString()
+		row.values[matter.TableColumnCluster] = er.ClusterName
+		row.values[matter.TableColumnElement] = er.Element.String()
+		row.values[matter.TableColumnName] = er.Name
+		row.custom["field"] = er.Field
+		if er.Constraint != nil {
+			row.values[matter.TableColumnConstraint] = er.Constraint.ASCIIDocString(nil)
+		}
+		row.values[matter.TableColumnQuality] = er.Quality.String()
+		row.values[matter.TableColumnAccess] = spec.AccessToASCIIDocString(er.Access, er.Element)
+		if er.Conformance != nil {
+			row.values[matter.TableColumnConformance] = er.Conformance.ASCIIDocString()
+		}
+		eri := &sectionInfo{id: h.nextID(deviceTypeElementRequirementTable), parent: dti, values: row}
+		dti.children[deviceTypeElementRequirementTable] = append(dti.children[deviceTypeElementRequirementTable], eri)
+		h.indexConformance(eri, deviceTypeElementRequirementTable, er.Conformance, er.Cluster)
+		h.indexElementRequirementConstraint(eri, er)
 	}
 	parent.children[deviceTypeTable] = append(parent.children[deviceTypeTable], dti)
 	return nil
 }
+
+func (h *Host) indexElementRequirementConstraint(eri *sectionInfo, er *matter.ElementRequirement) {
+	if er.Constraint == nil {
+		return
+	}
+	// Limits can only be resolved against the attribute the requirement constrains
+	if er.Cluster != nil && er.Element == types.EntityTypeAttribute {
+		for _, a := range er.Cluster.Attributes {
+			if a.Name == er.Name {
+				h.indexConstraint(eri, deviceTypeElementRequirementTable, er.Constraint, &matter.ConstraintContext{Field: a, Fields: er.Cluster.Attributes}, a.Type)
+				return
+			}
+		}
+	}
+	h.indexConstraint(eri, deviceTypeElementRequirementTable, er.Constraint, &matter.ConstraintContext{Field: &matter.Field{Name: er.Name}}, nil)
+}
+
+// resolveSupersets links each device type to the device type it is a superset of, so the hierarchy can be walked with a join
+func resolveSupersets(base *sectionInfo) {
+	deviceTypes := findSectionInfos(base, deviceTypeTable)
+	ids := make(map[string]int32, len(deviceTypes))
+	for _, dt := range deviceTypes {
+		if name, ok := dt.values.values[matter.TableColumnName].(string); ok {
+			ids[name] = dt.id
+		}
+	}
+	for _, dt := range deviceTypes {
+		superset, ok := dt.values.values[matter.TableColumnSuperset].(string)
+		if !ok || superset == "" {
+			continue
+		}
+		if id, ok := ids[superset]; ok && dt.values.custom != nil {
+			dt.values.custom["superset_device_type_id"] = id
+		}
+	}
+}
@@ -23,8 +23,9 @@ func (h *Host) indexEventModels(cxt context.Context, parent *sectionInfo, cluste
 		}
 		ei := &sectionInfo{id: h.nextID(eventTable), parent: parent, values: row, children: make(map[string][]*sectionInfo)}
 		parent.children[eventTable] = append(parent.children[eventTable], ei)
+		h.indexConformance(ei, eventTable, e.Conformance, cluster)
 		for _, ef := range e.Fields {
-			h.readField(ef, ei, eventFieldTable, types.EntityTypeEvent)
+			h.readField(cluster, ef, e.Fields, ei, eventFieldTable, types.EntityTypeEvent)
 		}
 	}
 	return nil
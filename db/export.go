@@ -0,0 +1,236 @@
+package db
+
+import (
+	"bufio"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	mms "github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	_ "modernc.org/sqlite"
+)
+
+// Export writes the database to path; files ending in .db, .sqlite or .sqlite3 are written as SQLite databases, anything else as a SQL dump
+func (h *Host) Export(cxt *mms.Context, path string) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".db", ".sqlite", ".sqlite3":
+		return h.ExportSQLite(cxt, path)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	err = h.WriteSQL(cxt, w)
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// WriteSQL writes the tables as plain SQL statements with double-quoted identifiers, which SQLite and PostgreSQL can load
+func (h *Host) WriteSQL(cxt *mms.Context, w io.Writer) error {
+	_, err := io.WriteString(w, "BEGIN TRANSACTION;\n")
+	if err != nil {
+		return err
+	}
+	err = h.exportStatements(cxt, func(statement string) error {
+		_, err := fmt.Fprintf(w, "%s;\n", statement)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "COMMIT;\n")
+	return err
+}
+
+func (h *Host) ExportSQLite(cxt *mms.Context, path string) error {
+	err := os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	sdb, err := sql.Open("sqlite", path)
+	if err != nil {
+		return err
+	}
+	defer sdb.Close()
+	tx, err := sdb.BeginTx(cxt, nil)
+	if err != nil {
+		return err
+	}
+	err = h.exportStatements(cxt, func(statement string) error {
+		_, err := tx.ExecContext(cxt, statement)
+		if err != nil {
+			return fmt.Errorf("error executing %q: %w", statement, err)
+		}
+		return nil
+	})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (h *Host) exportStatements(cxt *mms.Context, exec func(statement string) error) error {
+	for _, tableName := range h.tableOrder {
+		t, ok := h.tables[tableName]
+		if !ok {
+			continue
+		}
+		schema := t.Schema()
+		err := exec("DROP TABLE IF EXISTS " + quoteIdentifier(tableName))
+		if err != nil {
+			return err
+		}
+		err = exec(createTableStatement(tableName, schema))
+		if err != nil {
+			return err
+		}
+		columns := make([]string, len(schema))
+		for i, col := range schema {
+			columns[i] = quoteIdentifier(col.Name)
+		}
+		insert := fmt.Sprintf("INSERT INTO %s (%s) VALUES (", quoteIdentifier(tableName), strings.Join(columns, ", "))
+		err = rangeRows(cxt, t, func(row mms.Row) error {
+			values := make([]string, len(row))
+			for i, v := range row {
+				values[i] = sqlValue(v)
+			}
+			return exec(insert + strings.Join(values, ", ") + ")")
+		})
+		if err != nil {
+			return fmt.Errorf("error exporting table %s: %w", tableName, err)
+		}
+	}
+	return nil
+}
+
+func createTableStatement(tableName string, schema mms.Schema) string {
+	var sb strings.Builder
+	sb.WriteString("CREATE TABLE ")
+	sb.WriteString(quoteIdentifier(tableName))
+	sb.WriteString(" (\n")
+	var keys []string
+	for i, col := range schema {
+		if i > 0 {
+			sb.WriteString(",\n")
+		}
+		sb.WriteString("\t")
+		sb.WriteString(quoteIdentifier(col.Name))
+		sb.WriteString(" ")
+		sb.WriteString(sqlType(col.Type))
+		if !col.Nullable {
+			sb.WriteString(" NOT NULL")
+			// Extra columns are flagged as keys in the schema, but may be null, so only the ID columns make up the exported key
+			if col.PrimaryKey {
+				keys = append(keys, quoteIdentifier(col.Name))
+			}
+		}
+	}
+	if len(keys) > 0 {
+		sb.WriteString(",\n\tPRIMARY KEY (")
+		sb.WriteString(strings.Join(keys, ", "))
+		sb.WriteString(")")
+	}
+	sb.WriteString("\n)")
+	return sb.String()
+}
+
+func rangeRows(cxt *mms.Context, t mms.Table, callback func(row mms.Row) error) error {
+	partitions, err := t.Partitions(cxt)
+	if err != nil {
+		return err
+	}
+	defer partitions.Close(cxt)
+	for {
+		p, err := partitions.Next(cxt)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		err = rangePartition(cxt, t, p, callback)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func rangePartition(cxt *mms.Context, t mms.Table, p mms.Partition, callback func(row mms.Row) error) error {
+	rows, err := t.PartitionRows(cxt, p)
+	if err != nil {
+		return err
+	}
+	defer rows.Close(cxt)
+	for {
+		row, err := rows.Next(cxt)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		err = callback(row)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func sqlType(t mms.Type) string {
+	switch {
+	case types.IsInteger(t):
+		return "INTEGER"
+	default:
+		return "TEXT"
+	}
+}
+
+func sqlValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case int8:
+		return strconv.FormatInt(int64(v), 10)
+	case int16:
+		return strconv.FormatInt(int64(v), 10)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case string:
+		return quoteString(v)
+	default:
+		return quoteString(fmt.Sprint(v))
+	}
+}
+
+func quoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func quoteIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
@@ -17,6 +17,7 @@ type Host struct {
 
 	tableNames []string
 	tables     map[string]*memory.Table
+	tableOrder []string
 
 	base *sectionInfo
 
@@ -29,6 +30,7 @@ var (
 
 type dbRow struct {
 	values map[matter.TableColumn]any
+	custom map[string]any
 	extras map[string]any
 }
 
@@ -36,6 +38,10 @@ func newDBRow() *dbRow {
 	return &dbRow{values: make(map[matter.TableColumn]any)}
 }
 
+func newCustomDBRow() *dbRow {
+	return &dbRow{values: make(map[matter.TableColumn]any), custom: make(map[string]any)}
+}
+
 func New() *Host {
 
 	h := &Host{
@@ -53,11 +59,7 @@ func (h *Host) Run(address string, port int) error {
 		Protocol: "tcp",
 		Address:  fmt.Sprintf("%s:%d", address, port),
 	}
-	engine := sqle.NewDefault(
-		memory.NewDBProvider(
-			h.db,
-		))
-	s, err := server.NewDefaultServer(config, engine)
+	s, err := server.NewDefaultServer(config, h.engine())
 	if err != nil {
 		return err
 	}
@@ -68,6 +70,13 @@ func (h *Host) Run(address string, port int) error {
 	return nil
 }
 
+func (h *Host) engine() *sqle.Engine {
+	return sqle.NewDefault(
+		memory.NewDBProvider(
+			h.db,
+		))
+}
+
 func (h *Host) nextID(s string) int32 {
 	id, ok := h.ids[s]
 	if !ok {
@@ -0,0 +1,44 @@
+package db
+
+import (
+	"fmt"
+
+	mms "github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+)
+
+type QueryResult struct {
+	Query   string   `json:"query"`
+	Columns []string `json:"columns"`
+	Rows    [][]any  `json:"rows"`
+}
+
+// Query executes one or more semicolon-separated statements directly against the in-memory database
+func (h *Host) Query(cxt *mms.Context, queries string) ([]*QueryResult, error) {
+	statements, err := sqlparser.SplitStatementToPieces(queries)
+	if err != nil {
+		return nil, fmt.Errorf("error splitting statements: %w", err)
+	}
+	cxt.SetCurrentDatabase(dbName)
+	engine := h.engine()
+	var results []*QueryResult
+	for _, statement := range statements {
+		schema, iter, err := engine.Query(cxt, statement)
+		if err != nil {
+			return nil, fmt.Errorf("error executing %q: %w", statement, err)
+		}
+		rows, err := mms.RowIterToRows(cxt, schema, iter)
+		if err != nil {
+			return nil, fmt.Errorf("error reading results of %q: %w", statement, err)
+		}
+		result := &QueryResult{Query: statement, Columns: make([]string, len(schema)), Rows: make([][]any, 0, len(rows))}
+		for i, col := range schema {
+			result.Columns[i] = col.Name
+		}
+		for _, row := range rows {
+			result.Rows = append(result.Rows, []any(row))
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -1,6 +1,10 @@
 package db
 
-import "github.com/project-chip/alchemy/matter"
+import (
+	mms "github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/project-chip/alchemy/matter"
+)
 
 var (
 	documentTable                     = "document"
@@ -22,11 +26,31 @@ var (
 	deviceTypeRevisionTable           = "device_type_revision"
 	deviceTypeConditionTable          = "device_type_condition"
 	deviceTypeClusterRequirementTable = "device_type_cluster_requirement"
+	deviceTypeElementRequirementTable = "device_type_element_requirement"
+	conformanceTable                  = "conformance"
+	conformanceReferenceTable         = "conformance_reference"
+	constraintLimitTable              = "constraint_limit"
 )
 
 type tableSchemaDef struct {
 	parent  string
 	columns []matter.TableColumn
+	custom  []customColumn
+}
+
+// customColumn is a typed column which has no equivalent in the spec's tables
+type customColumn struct {
+	name       string
+	columnType mms.Type
+}
+
+// Conformance and constraint rows can belong to rows in many tables, so they name the table and row they belong to
+func entityColumns(columns ...customColumn) []customColumn {
+	return append([]customColumn{
+		{name: "entity_table", columnType: types.Text},
+		{name: "entity_id", columnType: types.Int32},
+		{name: "position", columnType: types.Int32},
+	}, columns...)
 }
 
 var tableSchema = map[string]tableSchemaDef{
@@ -182,6 +206,9 @@ var tableSchema = map[string]tableSchemaDef{
 			matter.TableColumnClass,
 			matter.TableColumnScope,
 		},
+		custom: []customColumn{
+			{name: "superset_device_type_id", columnType: types.Int32},
+		},
 	},
 	deviceTypeRevisionTable: {
 		parent: deviceTypeTable,
@@ -205,4 +232,47 @@ var tableSchema = map[string]tableSchemaDef{
 			matter.TableColumnDirection,
 		},
 	},
+	deviceTypeElementRequirementTable: {
+		parent: deviceTypeTable,
+		columns: []matter.TableColumn{
+			matter.TableColumnID,
+			matter.TableColumnCluster,
+			matter.TableColumnElement,
+			matter.TableColumnName,
+			matter.TableColumnConstraint,
+			matter.TableColumnQuality,
+			matter.TableColumnAccess,
+			matter.TableColumnConformance,
+		},
+		custom: []customColumn{
+			{name: "field", columnType: types.Text},
+		},
+	},
+	conformanceTable: {
+		custom: entityColumns(
+			customColumn{name: "type", columnType: types.Text},
+			customColumn{name: "expression", columnType: types.Text},
+			customColumn{name: "choice", columnType: types.Text},
+			customColumn{name: "choice_limit", columnType: types.Text},
+			customColumn{name: "conformance", columnType: types.Text},
+		),
+	},
+	conformanceReferenceTable: {
+		parent: conformanceTable,
+		custom: []customColumn{
+			{name: "reference_type", columnType: types.Text},
+			{name: "reference", columnType: types.Text},
+			{name: "entity_type", columnType: types.Text},
+			{name: "negated", columnType: types.Boolean},
+		},
+	},
+	constraintLimitTable: {
+		custom: entityColumns(
+			customColumn{name: "applies_to", columnType: types.Text},
+			customColumn{name: "type", columnType: types.Text},
+			customColumn{name: "min_value", columnType: types.Int64},
+			customColumn{name: "max_value", columnType: types.Int64},
+			customColumn{name: "constraint_text", columnType: types.Text},
+		),
+	},
 }
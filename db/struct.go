@@ -18,7 +18,7 @@ func (h *Host) indexStructs(cluster *matter.Cluster, parent *sectionInfo) {
 		ei := &sectionInfo{id: h.nextID(structTable), parent: parent, values: row, children: make(map[string][]*sectionInfo)}
 		parent.children[structTable] = append(parent.children[structTable], ei)
 		for _, env := range s.Fields {
-			h.readField(env, ei, structField, types.EntityTypeStruct)
+			h.readField(cluster, env, s.Fields, ei, structField, types.EntityTypeStruct)
 		}
 	}
 }
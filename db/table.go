@@ -13,19 +13,24 @@ import (
 	"github.com/project-chip/alchemy/matter/spec"
 )
 
-func (h *Host) createTable(cxt *mms.Context, tableName string, parentTable string, sections []*sectionInfo, columns []matter.TableColumn) error {
-	schema, extra := buildTableSchema(sections, tableName, parentTable, columns)
-	t := memory.NewTable(tableName, mms.NewPrimaryKeySchema(schema), h.db.GetForeignKeyCollection())
-	h.tables[tableName] = t
-	h.db.AddTable(tableName, t)
-	err := populateTable(cxt, t, tableName, parentTable, sections, schema, columns, extra)
+func (h *Host) createTable(cxt *mms.Context, name string, tableName string, ts tableSchemaDef, sections []*sectionInfo) error {
+	schema, extra := buildTableSchema(sections, tableName, ts.parent, ts.columns, ts.custom)
+	for _, col := range schema {
+		// Prefixed tables share their schema with the spec tables, but the columns must name the table they belong to
+		col.Source = name
+	}
+	t := memory.NewTable(name, mms.NewPrimaryKeySchema(schema), h.db.GetForeignKeyCollection())
+	h.tables[name] = t
+	h.tableOrder = append(h.tableOrder, name)
+	h.db.AddTable(name, t)
+	err := populateTable(cxt, t, tableName, ts.parent, sections, schema, ts.columns, ts.custom, extra)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func buildTableSchema(sections []*sectionInfo, tableName string, parentName string, columns []matter.TableColumn) (mms.Schema, []spec.ExtraColumn) {
+func buildTableSchema(sections []*sectionInfo, tableName string, parentName string, columns []matter.TableColumn, custom []customColumn) (mms.Schema, []spec.ExtraColumn) {
 	extraColumns := make(map[string]*spec.ExtraColumn)
 	for _, si := range sections {
 		for e := range si.values.extras {
@@ -64,6 +69,10 @@ func buildTableSchema(sections []*sectionInfo, tableName string, parentName stri
 		schema = append(schema, &mms.Column{Name: columnName, Type: colType, Nullable: true, Source: tableName, PrimaryKey: false})
 	}
 
+	for _, col := range custom {
+		schema = append(schema, &mms.Column{Name: col.name, Type: col.columnType, Nullable: true, Source: tableName, PrimaryKey: false})
+	}
+
 	offset := len(schema)
 	var extra []spec.ExtraColumn
 	for _, e := range extraColumns {
@@ -75,7 +84,7 @@ func buildTableSchema(sections []*sectionInfo, tableName string, parentName stri
 	return schema, extra
 }
 
-func populateTable(cxt *mms.Context, t *memory.Table, tableName string, parentTable string, sections []*sectionInfo, schema mms.Schema, columns []matter.TableColumn, extra []spec.ExtraColumn) error {
+func populateTable(cxt *mms.Context, t *memory.Table, tableName string, parentTable string, sections []*sectionInfo, schema mms.Schema, columns []matter.TableColumn, custom []customColumn, extra []spec.ExtraColumn) error {
 	for _, si := range sections {
 		row := mms.NewRow(si.id)
 		if len(parentTable) > 0 {
@@ -126,6 +135,9 @@ func populateTable(cxt *mms.Context, t *memory.Table, tableName string, parentTa
 				}
 			}
 		}
+		for _, col := range custom {
+			row = append(row, si.values.custom[col.name])
+		}
 		for _, e := range extra {
 			v, ok := si.values.extras[e.Name]
 			if ok {
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"slices"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// ZAP entities are loaded into their own set of tables, named after the spec tables with this prefix
+const zapTablePrefix = "zap_"
+
+// indexZAP indexes the clusters in ZAP templates, along with the top-level bitmaps, enums and structs the parser attaches to them;
+// ZAP device types, and data types not tied to any cluster code, are skipped by the parser and so aren't indexed
+func (h *Host) indexZAP(cxt context.Context, entities map[string][]types.Entity) (base *sectionInfo, tableNames []string) {
+	// ZAP tables are numbered independently of the spec tables
+	specIDs, specTableNames := h.ids, h.tableNames
+	h.ids, h.tableNames = make(map[string]int32), nil
+	defer func() {
+		h.ids, h.tableNames = specIDs, specTableNames
+	}()
+
+	paths := make([]string, 0, len(entities))
+	for path := range entities {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	base = &sectionInfo{children: make(map[string][]*sectionInfo)}
+	for _, path := range paths {
+		slog.InfoContext(cxt, "Indexing", "path", path)
+		ds := &sectionInfo{id: h.nextID(documentTable), values: &dbRow{}, children: make(map[string][]*sectionInfo)}
+		ds.values.values = map[matter.TableColumn]any{matter.TableColumnName: filepath.Base(path), matter.TableColumnType: "ZAP"}
+		ds.values.extras = map[string]any{"path": path}
+		for _, e := range entities[path] {
+			var err error
+			switch e := e.(type) {
+			case *matter.ClusterGroup:
+				for _, c := range e.Clusters {
+					err = h.indexClusterModel(cxt, ds, c)
+					if err != nil {
+						break
+					}
+				}
+			case *matter.Cluster:
+				err = h.indexClusterModel(cxt, ds, e)
+			}
+			if err != nil {
+				slog.WarnContext(cxt, "Error indexing ZAP template", "path", path, "error", err)
+			}
+		}
+		base.children[documentTable] = append(base.children[documentTable], ds)
+	}
+	tableNames = h.tableNames
+	return
+}
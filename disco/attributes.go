@@ -36,7 +36,7 @@ func (b *Ball) organizeAttributesSection(cxt *discoContext, dp *docParse) (err e
 			return err
 		}
 
-		err = fixConformanceCells(dp, attributesTable.rows, attributesTable.columnMap)
+		err = b.fixConformanceCells(dp, attributesTable.rows, attributesTable.columnMap)
 		if err != nil {
 			return err
 		}
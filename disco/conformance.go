@@ -10,7 +10,7 @@ import (
 	"github.com/project-chip/alchemy/matter/spec"
 )
 
-func fixConformanceCells(docParse *docParse, rows []*asciidoc.TableRow, columnMap spec.ColumnIndex) (err error) {
+func (b *Ball) fixConformanceCells(docParse *docParse, rows []*asciidoc.TableRow, columnMap spec.ColumnIndex) (err error) {
 	if len(rows) < 2 {
 		return
 	}
@@ -26,6 +26,9 @@ func fixConformanceCells(docParse *docParse, rows []*asciidoc.TableRow, columnMa
 		}
 
 		conf := conformance.ParseConformance(vc)
+		if b.options.canonicalizeConformance {
+			conf = conformance.Canonicalize(conf)
+		}
 
 		docParse.conformanceCache[cell] = conf
 
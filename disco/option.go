@@ -18,6 +18,7 @@ type options struct {
 	removeExtraSpaces             bool
 	normalizeFeatureNames         bool
 	disambiguateConformanceChoice bool
+	canonicalizeConformance       bool
 }
 
 var defaultOptions = options{
@@ -36,6 +37,7 @@ var defaultOptions = options{
 	removeExtraSpaces:             true,
 	normalizeFeatureNames:         true,
 	disambiguateConformanceChoice: false,
+	canonicalizeConformance:       false,
 }
 
 func LinkIndexTables(link bool) Option {
@@ -127,3 +129,9 @@ func DisambiguateConformanceChoice(add bool) Option {
 		b.options.disambiguateConformanceChoice = add
 	}
 }
+
+func CanonicalizeConformance(canonicalize bool) Option {
+	return func(b *Ball) {
+		b.options.canonicalizeConformance = canonicalize
+	}
+}
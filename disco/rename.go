@@ -0,0 +1,458 @@
+package disco
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/asciidoc/render"
+	"github.com/project-chip/alchemy/internal/parse"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+type Renamer struct {
+	spec    *spec.Specification
+	target  string
+	newName string
+}
+
+func NewRenamer(s *spec.Specification, target string, newName string) *Renamer {
+	return &Renamer{spec: s, target: target, newName: newName}
+}
+
+func (r *Renamer) Name() string {
+	return "Renaming entity"
+}
+
+func (r *Renamer) Type() pipeline.ProcessorType {
+	return pipeline.ProcessorTypeCollective
+}
+
+func (r *Renamer) Process(cxt context.Context, inputs []*pipeline.Data[*spec.Doc]) (outputs []*pipeline.Data[render.InputDocument], err error) {
+	cluster, entity, oldName, err := r.resolve()
+	if err != nil {
+		return
+	}
+
+	docs := make(map[string]*spec.Doc, len(inputs))
+	for _, input := range inputs {
+		docs[input.Path] = input.Content
+	}
+	path, ok := r.spec.DocRefs[cluster]
+	if !ok {
+		err = fmt.Errorf("unable to find document for cluster %s", cluster.Name)
+		return
+	}
+	doc, ok := docs[path]
+	if !ok {
+		err = fmt.Errorf("unable to find document %s", path)
+		return
+	}
+	section := doc.EntitySection(entity)
+	if section == nil {
+		err = fmt.Errorf("unable to find section for %s in %s", r.target, doc.Path)
+		return
+	}
+
+	rn := &renamer{cluster: cluster, entity: entity, doc: doc, oldName: oldName, newName: r.newName, changed: make(map[*spec.Doc]struct{})}
+	rn.pattern, err = regexp.Compile(`(^|[^A-Za-z0-9_])` + regexp.QuoteMeta(oldName) + `($|[^A-Za-z0-9_])`)
+	if err != nil {
+		return
+	}
+
+	if rn.renameSet(section.Base.Title) {
+		section.Name = rn.renameString(section.Name)
+		rn.changed[doc] = struct{}{}
+	}
+
+	err = rn.renameAnchor(doc, section, inputs)
+	if err != nil {
+		return
+	}
+
+	for _, input := range inputs {
+		rn.renameTables(input.Content)
+	}
+
+	for _, input := range inputs {
+		if _, ok := rn.changed[input.Content]; ok {
+			outputs = append(outputs, pipeline.NewData[render.InputDocument](input.Path, input.Content))
+		}
+	}
+	return
+}
+
+func (r *Renamer) resolve() (cluster *matter.Cluster, entity types.Entity, oldName string, err error) {
+	clusterName, elementName, hasElement := strings.Cut(r.target, ".")
+	cluster, ok := r.spec.ClustersByName[clusterName]
+	if !ok {
+		cluster, ok = r.spec.ClustersByName[strings.TrimSuffix(clusterName, " Cluster")]
+		if !ok {
+			err = fmt.Errorf("unknown cluster: %s", clusterName)
+			return
+		}
+	}
+	if !hasElement {
+		return cluster, cluster, cluster.Name, nil
+	}
+	oldName = elementName
+	for _, a := range cluster.Attributes {
+		if a.Name == elementName {
+			entity = a
+			return
+		}
+	}
+	for _, c := range cluster.Commands {
+		if c.Name == elementName {
+			entity = c
+			return
+		}
+	}
+	for _, e := range cluster.Events {
+		if e.Name == elementName {
+			entity = e
+			return
+		}
+	}
+	for _, s := range cluster.Structs {
+		if s.Name == elementName {
+			entity = s
+			return
+		}
+	}
+	for _, e := range cluster.Enums {
+		if e.Name == elementName {
+			entity = e
+			return
+		}
+	}
+	for _, bm := range cluster.Bitmaps {
+		if bm.Name == elementName {
+			entity = bm
+			return
+		}
+	}
+	err = fmt.Errorf("unknown element %s in cluster %s", elementName, cluster.Name)
+	return
+}
+
+type renamer struct {
+	cluster *matter.Cluster
+	entity  types.Entity
+	doc     *spec.Doc
+
+	oldName string
+	newName string
+	pattern *regexp.Regexp
+	changed map[*spec.Doc]struct{}
+}
+
+func (rn *renamer) renameString(s string) string {
+	return rn.pattern.ReplaceAllString(s, "${1}"+strings.ReplaceAll(rn.newName, "$", "$$")+"${2}")
+}
+
+func (rn *renamer) renameSet(set asciidoc.Set) (changed bool) {
+	parse.Search(set, func(s *asciidoc.String) parse.SearchShould {
+		renamed := rn.renameString(s.Value)
+		if renamed != s.Value {
+			s.Value = renamed
+			changed = true
+		}
+		return parse.SearchShouldContinue
+	})
+	return
+}
+
+func (rn *renamer) renameAnchor(doc *spec.Doc, section *spec.Section, inputs []*pipeline.Data[*spec.Doc]) error {
+	anchors, err := doc.Anchors()
+	if err != nil {
+		return err
+	}
+	var anchor *spec.Anchor
+	for _, as := range anchors {
+		for _, a := range as {
+			if a.Element == asciidoc.Element(section.Base) {
+				anchor = a
+				break
+			}
+		}
+	}
+	if anchor == nil {
+		return fmt.Errorf("no anchor found for %s in %s; cross references to it can not be renamed", rn.oldName, doc.Path)
+	}
+
+	oldID := anchor.ID
+	newID := strings.Replace(oldID, matter.Case(rn.oldName), matter.Case(rn.newName), 1)
+
+	// Find the cross references before the anchor changes, so we only touch those which resolve to this anchor
+	var xrefs []*spec.CrossReference
+	for _, input := range inputs {
+		d := input.Content
+		refs, ok := d.CrossReferences()[oldID]
+		if !ok || d.FindAnchor(oldID) != anchor {
+			continue
+		}
+		xrefs = append(xrefs, refs...)
+	}
+
+	if len(anchor.LabelElements) > 0 {
+		rn.renameSet(anchor.LabelElements)
+	}
+	anchor.SyncToDoc(newID)
+	rn.changed[doc] = struct{}{}
+
+	for _, xref := range xrefs {
+		xref.SyncToDoc(newID)
+		if len(xref.Reference.Set) > 0 {
+			rn.renameSet(xref.Reference.Set)
+		}
+		rn.changed[xref.Document] = struct{}{}
+	}
+	return nil
+}
+
+// renameTables renames the table cells which name or refer to the entity: its own row, rows in device type requirements
+// which name it, and conformance, constraint and type cells whose identifiers resolve to it
+func (rn *renamer) renameTables(doc *spec.Doc) {
+	parse.Search(doc.Elements(), func(s *spec.Section) parse.SearchShould {
+		for _, table := range parse.Skim[*asciidoc.Table](s.Elements()) {
+			rn.renameTable(doc, s, table)
+		}
+		return parse.SearchShouldContinue
+	})
+}
+
+func (rn *renamer) renameTable(doc *spec.Doc, section *spec.Section, table *asciidoc.Table) {
+	rows := table.TableRows()
+	headerRow, columnMap, _, err := spec.MapTableColumns(doc, rows)
+	if err != nil {
+		return
+	}
+	_, isCluster := rn.entity.(*matter.Cluster)
+	// Outside the attributes table, identifiers naming another row of the same table (e.g. a sibling struct field) refer to that row
+	var local bool
+	if section.SecType != matter.SectionAttributes {
+		for i, row := range rows {
+			if i <= headerRow {
+				continue
+			}
+			if name, ok := renderCell(row, columnMap, matter.TableColumnName); ok && name == rn.oldName {
+				local = true
+				break
+			}
+		}
+	}
+	for i, row := range rows {
+		if i <= headerRow {
+			continue
+		}
+		switch section.SecType {
+		case matter.SectionClusterRequirements, matter.SectionElementRequirements:
+			if !rn.requiresCluster(doc, row, columnMap) {
+				continue
+			}
+			if isCluster {
+				rn.renameCell(doc, row, columnMap, matter.TableColumnCluster)
+			} else if section.SecType == matter.SectionElementRequirements && rn.requiresElement(doc, row, columnMap) {
+				rn.renameCell(doc, row, columnMap, matter.TableColumnName)
+			}
+			continue
+		}
+		if doc != rn.doc {
+			continue
+		}
+		if rn.defines(doc, section, row, columnMap) {
+			rn.renameCell(doc, row, columnMap, matter.TableColumnName)
+		}
+		if isCluster || local {
+			continue
+		}
+		if rn.refersTo(conformanceIdentifiers(row, columnMap)) {
+			rn.renameCell(doc, row, columnMap, matter.TableColumnConformance)
+		}
+		if rn.refersTo(constraintIdentifiers(row, columnMap)) {
+			rn.renameCell(doc, row, columnMap, matter.TableColumnConstraint)
+		}
+		if rn.refersTo(typeIdentifiers(row, columnMap)) {
+			rn.renameCell(doc, row, columnMap, matter.TableColumnType)
+		}
+	}
+}
+
+// defines returns whether the row is the one which defines the entity, e.g. its row in the cluster's attributes table
+func (rn *renamer) defines(doc *spec.Doc, section *spec.Section, row *asciidoc.TableRow, columnMap spec.ColumnIndex) bool {
+	var sectionType matter.Section
+	switch rn.entity.(type) {
+	case *matter.Cluster:
+		sectionType = matter.SectionClusterID
+	case *matter.Field:
+		sectionType = matter.SectionAttributes
+	case *matter.Command:
+		sectionType = matter.SectionCommands
+	case *matter.Event:
+		sectionType = matter.SectionEvents
+	default:
+		return false
+	}
+	if section.SecType != sectionType {
+		return false
+	}
+	name, err := spec.ReadRowValue(doc, row, columnMap, matter.TableColumnName)
+	if err != nil {
+		return false
+	}
+	name = strings.TrimSpace(name)
+	return name == rn.oldName || strings.TrimSuffix(name, " Command") == rn.oldName || matter.StripTypeSuffixes(name) == rn.oldName
+}
+
+func (rn *renamer) requiresCluster(doc *spec.Doc, row *asciidoc.TableRow, columnMap spec.ColumnIndex) bool {
+	name, err := spec.ReadRowValue(doc, row, columnMap, matter.TableColumnCluster)
+	if err != nil {
+		return false
+	}
+	name = strings.TrimSpace(name)
+	return name == rn.cluster.Name || name == rn.cluster.Name+" Cluster"
+}
+
+func (rn *renamer) requiresElement(doc *spec.Doc, row *asciidoc.TableRow, columnMap spec.ColumnIndex) bool {
+	element, err := spec.ReadRowValue(doc, row, columnMap, matter.TableColumnElement)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(element)) {
+	case "attribute":
+		if _, ok := rn.entity.(*matter.Field); !ok {
+			return false
+		}
+	case "command", "command field":
+		if _, ok := rn.entity.(*matter.Command); !ok {
+			return false
+		}
+	case "event":
+		if _, ok := rn.entity.(*matter.Event); !ok {
+			return false
+		}
+	default:
+		return false
+	}
+	name, err := spec.ReadRowValue(doc, row, columnMap, matter.TableColumnName)
+	if err != nil {
+		return false
+	}
+	name = strings.TrimSpace(name)
+	// Command fields are named <command>.<field>
+	return name == rn.oldName || strings.HasPrefix(name, rn.oldName+".")
+}
+
+// refersTo returns whether any of the identifiers resolves to the entity in its cluster
+func (rn *renamer) refersTo(identifiers []string) bool {
+	for _, id := range identifiers {
+		if id != rn.oldName {
+			continue
+		}
+		if e, ok := rn.cluster.Identifier(id); ok && e == rn.entity {
+			return true
+		}
+	}
+	return false
+}
+
+func (rn *renamer) renameCell(doc *spec.Doc, row *asciidoc.TableRow, columnMap spec.ColumnIndex, column matter.TableColumn) {
+	offset, ok := columnMap[column]
+	if !ok {
+		return
+	}
+	cell := row.Cell(offset)
+	if cell == nil {
+		return
+	}
+	if rn.renameSet(cell.Elements()) {
+		rn.changed[doc] = struct{}{}
+	}
+}
+
+func renderCell(row *asciidoc.TableRow, columnMap spec.ColumnIndex, column matter.TableColumn) (string, bool) {
+	offset, ok := columnMap[column]
+	if !ok {
+		return "", false
+	}
+	cell := row.Cell(offset)
+	if cell == nil {
+		return "", false
+	}
+	value, err := spec.RenderTableCell(cell)
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(value), true
+}
+
+func conformanceIdentifiers(row *asciidoc.TableRow, columnMap spec.ColumnIndex) []string {
+	value, ok := renderCell(row, columnMap, matter.TableColumnConformance)
+	if !ok {
+		return nil
+	}
+	return conformance.ReferencedIdentifiers(conformance.ParseConformance(value))
+}
+
+func constraintIdentifiers(row *asciidoc.TableRow, columnMap spec.ColumnIndex) []string {
+	value, ok := renderCell(row, columnMap, matter.TableColumnConstraint)
+	if !ok {
+		return nil
+	}
+	c, err := constraint.ParseString(value)
+	if err != nil {
+		return nil
+	}
+	return constraintReferences(c)
+}
+
+func constraintReferences(c constraint.Constraint) (references []string) {
+	switch c := c.(type) {
+	case constraint.Set:
+		for _, cs := range c {
+			references = append(references, constraintReferences(cs)...)
+		}
+	case *constraint.ListConstraint:
+		references = append(constraintReferences(c.Constraint), constraintReferences(c.EntryConstraint)...)
+	case *constraint.ExactConstraint:
+		references = limitReferences(c.Value)
+	case *constraint.MinConstraint:
+		references = limitReferences(c.Minimum)
+	case *constraint.MaxConstraint:
+		references = limitReferences(c.Maximum)
+	case *constraint.RangeConstraint:
+		references = append(limitReferences(c.Minimum), limitReferences(c.Maximum)...)
+	}
+	return
+}
+
+func limitReferences(l constraint.Limit) []string {
+	switch l := l.(type) {
+	case *constraint.ReferenceLimit:
+		return []string{l.Value}
+	case *constraint.LengthLimit:
+		return []string{l.Value}
+	case *constraint.MathExpressionLimit:
+		return append(limitReferences(l.Left), limitReferences(l.Right)...)
+	}
+	return nil
+}
+
+func typeIdentifiers(row *asciidoc.TableRow, columnMap spec.ColumnIndex) []string {
+	value, ok := renderCell(row, columnMap, matter.TableColumnType)
+	if !ok {
+		return nil
+	}
+	if strings.HasPrefix(strings.ToLower(value), "list[") && strings.HasSuffix(value, "]") {
+		value = strings.TrimSpace(value[5 : len(value)-1])
+	}
+	return []string{value}
+}
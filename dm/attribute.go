@@ -9,9 +9,10 @@ import (
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/conformance"
 	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/sourcemap"
 )
 
-func renderAttributes(doc *spec.Doc, cluster *matter.Cluster, c *etree.Element) (err error) {
+func renderAttributes(doc *spec.Doc, cluster *matter.Cluster, c *etree.Element, sm *sourcemap.Recorder) (err error) {
 	if len(cluster.Attributes) == 0 {
 		return
 	}
@@ -26,6 +27,7 @@ func renderAttributes(doc *spec.Doc, cluster *matter.Cluster, c *etree.Element)
 			continue
 		}
 		ax := attributes.CreateElement("attribute")
+		sm.Record(ax, a)
 		ax.CreateAttr("id", a.ID.HexString())
 		ax.CreateAttr("name", a.Name)
 		renderDataType(a, ax)
@@ -65,7 +67,7 @@ func renderAnonymousType(doc *spec.Doc, cluster *matter.Cluster, ax *etree.Eleme
 func renderAnonymousEnum(doc *spec.Doc, cluster *matter.Cluster, ax *etree.Element, an *matter.AnonymousEnum) (err error) {
 	en := ax.CreateElement("enum")
 	for index, v := range an.Values {
-		err = renderEnumValue(doc, cluster, en, index, v)
+		err = renderEnumValue(doc, cluster, en, index, v, nil)
 		if err != nil {
 			return
 		}
@@ -77,7 +79,7 @@ func renderAnonymousBitmap(doc *spec.Doc, cluster *matter.Cluster, ax *etree.Ele
 	en := ax.CreateElement("bitmap")
 	size := bm.Size()
 	for _, v := range bm.Bits {
-		err = renderBit(doc, cluster, en, v, size)
+		err = renderBit(doc, cluster, en, v, size, nil)
 		if err != nil {
 			return
 		}
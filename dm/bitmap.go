@@ -8,9 +8,10 @@ import (
 	"github.com/beevik/etree"
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/sourcemap"
 )
 
-func renderBitmaps(doc *spec.Doc, cluster *matter.Cluster, dt *etree.Element) (err error) {
+func renderBitmaps(doc *spec.Doc, cluster *matter.Cluster, dt *etree.Element, sm *sourcemap.Recorder) (err error) {
 	bitmaps := make([]*matter.Bitmap, len(cluster.Bitmaps))
 	copy(bitmaps, cluster.Bitmaps)
 	slices.SortFunc(bitmaps, func(a, b *matter.Bitmap) int {
@@ -18,10 +19,11 @@ func renderBitmaps(doc *spec.Doc, cluster *matter.Cluster, dt *etree.Element) (e
 	})
 	for _, bm := range bitmaps {
 		en := dt.CreateElement("bitmap")
+		sm.Record(en, bm)
 		en.CreateAttr("name", bm.Name)
 		size := bm.Size() / 4
 		for _, v := range bm.Bits {
-			err = renderBit(doc, cluster, en, v, size)
+			err = renderBit(doc, cluster, en, v, size, sm)
 			if err != nil {
 				return
 			}
@@ -30,8 +32,9 @@ func renderBitmaps(doc *spec.Doc, cluster *matter.Cluster, dt *etree.Element) (e
 	return
 }
 
-func renderBit(doc *spec.Doc, cluster *matter.Cluster, en *etree.Element, v matter.Bit, size int) (err error) {
+func renderBit(doc *spec.Doc, cluster *matter.Cluster, en *etree.Element, v matter.Bit, size int, sm *sourcemap.Recorder) (err error) {
 	i := en.CreateElement("bitfield")
+	sm.Record(i, v)
 	i.CreateAttr("name", v.Name())
 	val := matter.ParseNumber(v.Bit())
 	if val.Valid() {
@@ -10,6 +10,7 @@ import (
 	"github.com/beevik/etree"
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/sourcemap"
 )
 
 func getAppClusterPath(sdkRoot string, path string, clusterName string) string {
@@ -26,8 +27,9 @@ type clusterID struct {
 	name string
 }
 
-func (p *Renderer) renderAppCluster(doc *spec.Doc, clusters ...*matter.Cluster) (output string, err error) {
+func (p *Renderer) renderAppCluster(doc *spec.Doc, clusters ...*matter.Cluster) (output string, sm *sourcemap.Recorder, err error) {
 	x := etree.NewDocument()
+	sm = p.sourceMaps.Recorder(x)
 
 	x.CreateProcInst("xml", `version="1.0"`)
 	x.CreateComment(getLicense())
@@ -42,6 +44,7 @@ func (p *Renderer) renderAppCluster(doc *spec.Doc, clusters ...*matter.Cluster)
 	cluster := clusters[0]
 
 	c := root.CreateElement("cluster")
+	sm.Record(c, cluster)
 	c.CreateAttr("xmlns:xsi", "http://www.w3.org/2001/XMLSchema-instance")
 	c.CreateAttr("xsi:schemaLocation", "types types.xsd cluster cluster.xsd")
 	if cluster.ID.Valid() {
@@ -87,23 +90,23 @@ func (p *Renderer) renderAppCluster(doc *spec.Doc, clusters ...*matter.Cluster)
 	class.CreateAttr("picsCode", cluster.PICS)
 	class.CreateAttr("scope", cluster.Scope)
 
-	err = renderFeatures(doc, cluster, c)
+	err = renderFeatures(doc, cluster, c, sm)
 	if err != nil {
 		return
 	}
-	err = renderDataTypes(doc, cluster, c)
+	err = renderDataTypes(doc, cluster, c, sm)
 	if err != nil {
 		return
 	}
-	err = renderAttributes(doc, cluster, c)
+	err = renderAttributes(doc, cluster, c, sm)
 	if err != nil {
 		return
 	}
-	err = renderCommands(doc, cluster, c)
+	err = renderCommands(doc, cluster, c, sm)
 	if err != nil {
 		return
 	}
-	err = renderEvents(doc, cluster, c)
+	err = renderEvents(doc, cluster, c, sm)
 	if err != nil {
 		return
 	}
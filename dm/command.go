@@ -8,9 +8,10 @@ import (
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/conformance"
 	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/sourcemap"
 )
 
-func renderCommands(doc *spec.Doc, cluster *matter.Cluster, c *etree.Element) (err error) {
+func renderCommands(doc *spec.Doc, cluster *matter.Cluster, c *etree.Element, sm *sourcemap.Recorder) (err error) {
 	if len(cluster.Commands) == 0 {
 		return
 	}
@@ -39,6 +40,7 @@ func renderCommands(doc *spec.Doc, cluster *matter.Cluster, c *etree.Element) (e
 	commands := c.CreateElement("commands")
 	for _, cmd := range cmds {
 		cx := commands.CreateElement("command")
+		sm.Record(cx, cmd)
 		cx.CreateAttr("id", cmd.ID.ShortHexString())
 		cx.CreateAttr("name", cmd.Name)
 		switch cmd.Direction {
@@ -79,6 +81,7 @@ func renderCommands(doc *spec.Doc, cluster *matter.Cluster, c *etree.Element) (e
 
 		for _, f := range cmd.Fields {
 			i := cx.CreateElement("field")
+			sm.Record(i, f)
 			if f.ID.Valid() {
 				i.CreateAttr("id", f.ID.IntString())
 			}
@@ -8,6 +8,7 @@ import (
 	"github.com/project-chip/alchemy/matter/constraint"
 	"github.com/project-chip/alchemy/matter/spec"
 	"github.com/project-chip/alchemy/matter/types"
+	"github.com/project-chip/alchemy/sourcemap"
 )
 
 func dataModelName(dataType *types.DataType) string {
@@ -135,21 +136,21 @@ func dataModelName(dataType *types.DataType) string {
 	}
 }
 
-func renderDataTypes(doc *spec.Doc, cluster *matter.Cluster, c *etree.Element) (err error) {
+func renderDataTypes(doc *spec.Doc, cluster *matter.Cluster, c *etree.Element, sm *sourcemap.Recorder) (err error) {
 	if len(cluster.Enums) == 0 && len(cluster.Bitmaps) == 0 && len(cluster.Structs) == 0 {
 		return
 	}
 	dt := c.CreateElement("dataTypes")
-	err = renderEnums(doc, cluster, dt)
+	err = renderEnums(doc, cluster, dt, sm)
 	if err != nil {
 		return
 	}
-	err = renderBitmaps(doc, cluster, dt)
+	err = renderBitmaps(doc, cluster, dt, sm)
 	if err != nil {
 		return
 	}
 
-	err = renderStructs(doc, cluster, dt)
+	err = renderStructs(doc, cluster, dt, sm)
 	return
 }
 
@@ -12,6 +12,7 @@ import (
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/spec"
 	"github.com/project-chip/alchemy/matter/types"
+	"github.com/project-chip/alchemy/sourcemap"
 )
 
 func getDeviceTypePath(sdkRoot string, path string) string {
@@ -19,13 +20,15 @@ func getDeviceTypePath(sdkRoot string, path string) string {
 	return filepath.Join(sdkRoot, fmt.Sprintf("/data_model/device_types/%s.xml", strings.TrimSuffix(path, filepath.Ext(path))))
 }
 
-func renderDeviceType(doc *spec.Doc, deviceTypes []*matter.DeviceType) (output string, err error) {
+func (p *Renderer) renderDeviceType(doc *spec.Doc, deviceTypes []*matter.DeviceType) (output string, sm *sourcemap.Recorder, err error) {
 	x := etree.NewDocument()
+	sm = p.sourceMaps.Recorder(x)
 
 	x.CreateProcInst("xml", `version="1.0"`)
 	x.CreateComment(getLicense())
 	for _, deviceType := range deviceTypes {
 		c := x.CreateElement("deviceType")
+		sm.Record(c, deviceType)
 		c.CreateAttr("xmlns:xsi", "http://www.w3.org/2001/XMLSchema-instance")
 		c.CreateAttr("xsi:schemaLocation", "types types.xsd devicetype devicetype.xsd")
 		c.CreateAttr("id", deviceType.ID.HexString())
@@ -9,9 +9,10 @@ import (
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/spec"
 	"github.com/project-chip/alchemy/matter/types"
+	"github.com/project-chip/alchemy/sourcemap"
 )
 
-func renderEnums(doc *spec.Doc, cluster *matter.Cluster, dt *etree.Element) (err error) {
+func renderEnums(doc *spec.Doc, cluster *matter.Cluster, dt *etree.Element, sm *sourcemap.Recorder) (err error) {
 	enums := make([]*matter.Enum, len(cluster.Enums))
 	copy(enums, cluster.Enums)
 	slices.SortFunc(enums, func(a, b *matter.Enum) int {
@@ -19,9 +20,10 @@ func renderEnums(doc *spec.Doc, cluster *matter.Cluster, dt *etree.Element) (err
 	})
 	for _, e := range enums {
 		en := dt.CreateElement("enum")
+		sm.Record(en, e)
 		en.CreateAttr("name", e.Name)
 		for index, v := range e.Values {
-			err = renderEnumValue(doc, cluster, en, index, v)
+			err = renderEnumValue(doc, cluster, en, index, v, sm)
 			if err != nil {
 				return
 			}
@@ -30,7 +32,7 @@ func renderEnums(doc *spec.Doc, cluster *matter.Cluster, dt *etree.Element) (err
 	return
 }
 
-func renderEnumValue(doc *spec.Doc, cluster *matter.Cluster, en *etree.Element, index int, v *matter.EnumValue) (err error) {
+func renderEnumValue(doc *spec.Doc, cluster *matter.Cluster, en *etree.Element, index int, v *matter.EnumValue, sm *sourcemap.Recorder) (err error) {
 	var val, from, to *matter.Number
 	var valFormat, fromFormat, toFormat types.NumberFormat
 	if v.Value.Valid() {
@@ -44,6 +46,7 @@ func renderEnumValue(doc *spec.Doc, cluster *matter.Cluster, en *etree.Element,
 	}
 
 	i := en.CreateElement("item")
+	sm.Record(i, v)
 	if val.Valid() {
 		switch valFormat {
 		case types.NumberFormatAuto, types.NumberFormatInt:
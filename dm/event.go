@@ -8,9 +8,10 @@ import (
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/conformance"
 	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/sourcemap"
 )
 
-func renderEvents(doc *spec.Doc, cluster *matter.Cluster, c *etree.Element) (err error) {
+func renderEvents(doc *spec.Doc, cluster *matter.Cluster, c *etree.Element, sm *sourcemap.Recorder) (err error) {
 	if len(cluster.Events) == 0 {
 		return
 	}
@@ -29,6 +30,7 @@ func renderEvents(doc *spec.Doc, cluster *matter.Cluster, c *etree.Element) (err
 	for _, e := range evs {
 
 		cx := events.CreateElement("event")
+		sm.Record(cx, e)
 		cx.CreateAttr("id", e.ID.ShortHexString())
 		cx.CreateAttr("name", e.Name)
 		if len(e.Priority) > 0 {
@@ -47,7 +49,7 @@ func renderEvents(doc *spec.Doc, cluster *matter.Cluster, c *etree.Element) (err
 			return
 		}
 
-		err = renderFields(doc, cluster, e.Fields, cx)
+		err = renderFields(doc, cluster, e.Fields, cx, sm)
 		if err != nil {
 			return
 		}
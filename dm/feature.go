@@ -6,18 +6,19 @@ import (
 	"github.com/beevik/etree"
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/sourcemap"
 )
 
-func renderFeatures(doc *spec.Doc, cluster *matter.Cluster, c *etree.Element) (err error) {
+func renderFeatures(doc *spec.Doc, cluster *matter.Cluster, c *etree.Element, sm *sourcemap.Recorder) (err error) {
 	if cluster.Features == nil || len(cluster.Features.Bits) == 0 {
 		return
 	}
 	features := c.CreateElement("features")
-	err = RenderFeatureElements(doc, cluster, features)
+	err = RenderFeatureElements(doc, cluster, features, sm)
 	return
 }
 
-func RenderFeatureElements(doc *spec.Doc, cluster *matter.Cluster, features *etree.Element) (err error) {
+func RenderFeatureElements(doc *spec.Doc, cluster *matter.Cluster, features *etree.Element, sm *sourcemap.Recorder) (err error) {
 	for _, b := range cluster.Features.Bits {
 		f, ok := b.(*matter.Feature)
 		if !ok {
@@ -29,6 +30,7 @@ func RenderFeatureElements(doc *spec.Doc, cluster *matter.Cluster, features *etr
 			continue
 		}
 		feature := features.CreateElement("feature")
+		sm.Record(feature, f)
 		feature.CreateAttr("bit", bit.IntString())
 		feature.CreateAttr("code", f.Code)
 		feature.CreateAttr("name", f.Name())
@@ -18,17 +18,32 @@ import (
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/spec"
 	"github.com/project-chip/alchemy/matter/types"
+	"github.com/project-chip/alchemy/sourcemap"
 )
 
 type Renderer struct {
-	sdkRoot string
+	sdkRoot    string
+	sourceMaps *sourcemap.Generator
 
 	clusters     []*matter.Cluster
 	clustersLock sync.Mutex
 }
 
-func NewRenderer(sdkRoot string) *Renderer {
-	return &Renderer{sdkRoot: sdkRoot}
+type RendererOption func(r *Renderer)
+
+// RenderSourceMaps records the spec entity each element is rendered from, and writes a source map alongside each data model XML file
+func RenderSourceMaps(g *sourcemap.Generator) RendererOption {
+	return func(r *Renderer) {
+		r.sourceMaps = g
+	}
+}
+
+func NewRenderer(sdkRoot string, options ...RendererOption) *Renderer {
+	r := &Renderer{sdkRoot: sdkRoot}
+	for _, o := range options {
+		o(r)
+	}
+	return r
 }
 
 func (p *Renderer) Name() string {
@@ -58,33 +73,50 @@ func (p *Renderer) Process(cxt context.Context, input *pipeline.Data[*spec.Doc],
 		}
 	}
 
+	var sourceMaps []*pipeline.Data[string]
+	addSourceMap := func(path string, sm *sourcemap.Recorder) (err error) {
+		var data *pipeline.Data[string]
+		data, err = sm.Data(path)
+		if data != nil {
+			sourceMaps = append(sourceMaps, data)
+		}
+		return
+	}
+
 	if len(appClusters) == 1 {
 		var s string
+		var sm *sourcemap.Recorder
 		switch e := appClusters[0].(type) {
 		case *matter.ClusterGroup:
 			if len(e.Clusters) == 0 {
 				err = fmt.Errorf("empty cluster group %s", doc.Path)
 				return
 			}
-			s, err = p.renderAppCluster(doc, e.Clusters...)
+			s, sm, err = p.renderAppCluster(doc, e.Clusters...)
 		case *matter.Cluster:
-			s, err = p.renderAppCluster(doc, e)
+			s, sm, err = p.renderAppCluster(doc, e)
 		}
 		if err != nil {
 			err = fmt.Errorf("failed rendering app clusters %s: %w", doc.Path, err)
 			return
 		}
-		outputs = append(outputs, &pipeline.Data[string]{Path: getAppClusterPath(p.sdkRoot, doc.Path, ""), Content: s})
+		path := getAppClusterPath(p.sdkRoot, doc.Path, "")
+		outputs = append(outputs, &pipeline.Data[string]{Path: path, Content: s})
+		err = addSourceMap(path, sm)
+		if err != nil {
+			return
+		}
 	} else if len(appClusters) > 1 {
 		for _, e := range appClusters {
 			var s string
+			var sm *sourcemap.Recorder
 			var clusterName string
 			switch e := e.(type) {
 			case *matter.ClusterGroup:
-				s, err = p.renderAppCluster(doc, e.Clusters...)
+				s, sm, err = p.renderAppCluster(doc, e.Clusters...)
 				clusterName = e.Clusters[0].Name
 			case *matter.Cluster:
-				s, err = p.renderAppCluster(doc, e)
+				s, sm, err = p.renderAppCluster(doc, e)
 				clusterName = e.Name
 			}
 			if err != nil {
@@ -92,18 +124,29 @@ func (p *Renderer) Process(cxt context.Context, input *pipeline.Data[*spec.Doc],
 				return
 			}
 			clusterName = strcase.ToCamel(clusterName + " Cluster")
-			outputs = append(outputs, &pipeline.Data[string]{Path: getAppClusterPath(p.sdkRoot, doc.Path, clusterName), Content: s})
+			path := getAppClusterPath(p.sdkRoot, doc.Path, clusterName)
+			outputs = append(outputs, &pipeline.Data[string]{Path: path, Content: s})
+			err = addSourceMap(path, sm)
+			if err != nil {
+				return
+			}
 		}
 	}
 
 	if len(deviceTypes) > 0 {
 		var s string
-		s, err = renderDeviceType(doc, deviceTypes)
+		var sm *sourcemap.Recorder
+		s, sm, err = p.renderDeviceType(doc, deviceTypes)
 		if err != nil {
 			err = fmt.Errorf("failed rendering device types %s: %w", doc.Path, err)
 			return
 		}
-		outputs = append(outputs, &pipeline.Data[string]{Path: getDeviceTypePath(p.sdkRoot, doc.Path), Content: s})
+		path := getDeviceTypePath(p.sdkRoot, doc.Path)
+		outputs = append(outputs, &pipeline.Data[string]{Path: path, Content: s})
+		err = addSourceMap(path, sm)
+		if err != nil {
+			return
+		}
 	}
 	for _, o := range outputs {
 		o.Content, err = patchLicense(o.Content, o.Path)
@@ -112,6 +155,7 @@ func (p *Renderer) Process(cxt context.Context, input *pipeline.Data[*spec.Doc],
 			return
 		}
 	}
+	outputs = append(outputs, sourceMaps...)
 	return
 }
 
@@ -7,9 +7,10 @@ import (
 	"github.com/beevik/etree"
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/sourcemap"
 )
 
-func renderStructs(doc *spec.Doc, cluster *matter.Cluster, dt *etree.Element) (err error) {
+func renderStructs(doc *spec.Doc, cluster *matter.Cluster, dt *etree.Element, sm *sourcemap.Recorder) (err error) {
 	structs := make([]*matter.Struct, len(cluster.Structs))
 	copy(structs, cluster.Structs)
 	slices.SortFunc(structs, func(a, b *matter.Struct) int {
@@ -17,8 +18,9 @@ func renderStructs(doc *spec.Doc, cluster *matter.Cluster, dt *etree.Element) (e
 	})
 	for _, s := range structs {
 		en := dt.CreateElement("struct")
+		sm.Record(en, s)
 		en.CreateAttr("name", s.Name)
-		err = renderFields(doc, cluster, s.Fields, en)
+		err = renderFields(doc, cluster, s.Fields, en, sm)
 		if err != nil {
 			return
 		}
@@ -29,18 +31,19 @@ func renderStructs(doc *spec.Doc, cluster *matter.Cluster, dt *etree.Element) (e
 	return
 }
 
-func renderFields(doc *spec.Doc, cluster *matter.Cluster, fs matter.FieldSet, parent *etree.Element) (err error) {
+func renderFields(doc *spec.Doc, cluster *matter.Cluster, fs matter.FieldSet, parent *etree.Element, sm *sourcemap.Recorder) (err error) {
 	for _, f := range fs {
-		err = renderField(doc, cluster, fs, f, parent)
+		err = renderField(doc, cluster, fs, f, parent, sm)
 	}
 	return
 }
 
-func renderField(doc *spec.Doc, cluster *matter.Cluster, fs matter.FieldSet, f *matter.Field, parent *etree.Element) (err error) {
+func renderField(doc *spec.Doc, cluster *matter.Cluster, fs matter.FieldSet, f *matter.Field, parent *etree.Element, sm *sourcemap.Recorder) (err error) {
 	if !f.ID.Valid() {
 		return
 	}
 	i := parent.CreateElement("field")
+	sm.Record(i, f)
 	i.CreateAttr("id", f.ID.IntString())
 	i.CreateAttr("name", f.Name)
 	renderDataType(f, i)
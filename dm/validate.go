@@ -0,0 +1,145 @@
+package dm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/beevik/etree"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/internal/xsd"
+	"github.com/project-chip/alchemy/sourcemap"
+)
+
+var ErrSchemaViolation = errors.New("data model XML does not conform to its schema")
+
+// Validator checks data model XML against the XSD schemas in the SDK's data_model directory
+type Validator[T string | []byte] struct {
+	sdkRoot string
+
+	schemas     map[string]*xsd.Schema
+	schemasLock sync.Mutex
+}
+
+// NewValidator creates a validator; violations are traced back to the spec with the source maps among its inputs, or with those on disk if there are none
+func NewValidator[T string | []byte](sdkRoot string) *Validator[T] {
+	return &Validator[T]{sdkRoot: sdkRoot, schemas: make(map[string]*xsd.Schema)}
+}
+
+func (v *Validator[T]) Name() string {
+	return "Validating data model"
+}
+
+func (v *Validator[T]) Type() pipeline.ProcessorType {
+	return pipeline.ProcessorTypeCollective
+}
+
+func (v *Validator[T]) Process(cxt context.Context, inputs []*pipeline.Data[T]) (outputs []*pipeline.Data[struct{}], err error) {
+	pipeline.SortData[T](inputs)
+	sourceMaps := make(map[string]*pipeline.Data[T])
+	for _, input := range inputs {
+		sourceMaps[input.Path] = input
+	}
+	var violations, invalid, checked int
+	for _, input := range inputs {
+		if filepath.Ext(input.Path) != ".xml" {
+			continue
+		}
+		checked++
+		x := etree.NewDocument()
+		err = x.ReadFromBytes([]byte(input.Content))
+		if err != nil {
+			err = fmt.Errorf("error reading data model XML %s: %w", input.Path, err)
+			return
+		}
+		root := x.Root()
+		if root == nil {
+			continue
+		}
+		locations := schemaLocations(root.SelectAttrValue("xsi:schemaLocation", ""))
+		if len(locations) == 0 {
+			slog.Debug("data model XML has no schema location; skipping", "path", input.Path)
+			continue
+		}
+		var schema *xsd.Schema
+		schema, err = v.schema(input.Path, locations)
+		if err != nil {
+			return
+		}
+		problems := schema.Validate(x)
+		if len(problems) == 0 {
+			continue
+		}
+		invalid++
+		violations += len(problems)
+		var sm *sourcemap.SourceMap
+		if data, ok := sourceMaps[sourcemap.Path(input.Path)]; ok {
+			sm, _ = sourcemap.Parse(input.Path, []byte(data.Content))
+		} else {
+			sm, _ = sourcemap.Read(input.Path)
+		}
+		for _, p := range problems {
+			element := sourcemap.ElementPath(p.Element)
+			args := []any{"path", input.Path, "element", element, "error", p.Message}
+			if sm != nil {
+				if e := sm.Locate(element); e != nil {
+					args = append(args, "source", e.Location())
+				}
+			}
+			slog.WarnContext(cxt, "schema violation in data model XML", args...)
+		}
+	}
+	if violations > 0 {
+		err = fmt.Errorf("%d violations in %d of %d files: %w", violations, invalid, checked, ErrSchemaViolation)
+	}
+	return
+}
+
+// schemaLocations returns the schema files named in an xsi:schemaLocation attribute, which alternates namespaces and locations
+func schemaLocations(attr string) (locations []string) {
+	fields := strings.Fields(attr)
+	for i := 1; i < len(fields); i += 2 {
+		locations = append(locations, fields[i])
+	}
+	return
+}
+
+func (v *Validator[T]) schema(path string, locations []string) (*xsd.Schema, error) {
+	v.schemasLock.Lock()
+	defer v.schemasLock.Unlock()
+	dirs := []string{filepath.Dir(path), filepath.Join(v.sdkRoot, "data_model")}
+	paths := make([]string, 0, len(locations))
+	for _, l := range locations {
+		var found string
+		for _, dir := range dirs {
+			p := filepath.Join(dir, l)
+			exists, err := files.Exists(p)
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				found = p
+				break
+			}
+		}
+		if found == "" {
+			return nil, fmt.Errorf("unable to find schema %s for %s in %s", l, path, strings.Join(dirs, " or "))
+		}
+		paths = append(paths, found)
+	}
+	key := strings.Join(paths, " ")
+	if s, ok := v.schemas[key]; ok {
+		return s, nil
+	}
+	s, err := xsd.Load(paths...)
+	if err != nil {
+		return nil, err
+	}
+	v.schemas[key] = s
+	return s, nil
+}
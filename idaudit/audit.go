@@ -0,0 +1,307 @@
+package idaudit
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+type allocation struct {
+	id   uint64
+	name string
+}
+
+type auditor struct {
+	report Report
+}
+
+func Audit(s *spec.Specification) *Report {
+	var a auditor
+
+	var clusters []*matter.Cluster
+	var deviceTypes []*matter.DeviceType
+	var bitmaps []*matter.Bitmap
+	var enums []*matter.Enum
+	var structs []*matter.Struct
+	for e := range s.DocRefs {
+		switch e := e.(type) {
+		case *matter.Cluster:
+			clusters = append(clusters, e)
+		case *matter.DeviceType:
+			deviceTypes = append(deviceTypes, e)
+		case *matter.Bitmap:
+			bitmaps = append(bitmaps, e)
+		case *matter.Enum:
+			enums = append(enums, e)
+		case *matter.Struct:
+			structs = append(structs, e)
+		}
+	}
+	sortEntities(s, clusters, func(c *matter.Cluster) (string, *matter.Number) { return c.Name, c.ID })
+	sortEntities(s, deviceTypes, func(dt *matter.DeviceType) (string, *matter.Number) { return dt.Name, dt.ID })
+	sortEntities(s, bitmaps, func(bm *matter.Bitmap) (string, *matter.Number) { return bm.Name, nil })
+	sortEntities(s, enums, func(e *matter.Enum) (string, *matter.Number) { return e.Name, nil })
+	sortEntities(s, structs, func(st *matter.Struct) (string, *matter.Number) { return st.Name, nil })
+
+	var clusterIDs []allocation
+	for _, c := range clusters {
+		if c.ID.Valid() {
+			clusterIDs = append(clusterIDs, allocation{id: c.ID.Value(), name: c.Name})
+		}
+	}
+	a.auditIDs(types.EntityTypeCluster, "", clusterIDs)
+
+	var deviceTypeIDs []allocation
+	for _, dt := range deviceTypes {
+		if dt.ID.Valid() {
+			deviceTypeIDs = append(deviceTypeIDs, allocation{id: dt.ID.Value(), name: dt.Name})
+		}
+	}
+	a.auditIDs(types.EntityTypeDeviceType, "", deviceTypeIDs)
+
+	for _, c := range clusters {
+		a.auditCluster(c)
+	}
+
+	for _, bm := range bitmaps {
+		a.auditBitmap(scopeName(s, bm, bm.Name), bm)
+	}
+	for _, e := range enums {
+		a.auditEnum(scopeName(s, e, e.Name), e)
+	}
+	for _, st := range structs {
+		a.auditFields(scopeName(s, st, st.Name), st.Fields)
+	}
+	return &a.report
+}
+
+func (a *auditor) auditCluster(c *matter.Cluster) {
+	var attributes []allocation
+	for _, attr := range c.Attributes {
+		if attr.ID.Valid() {
+			attributes = append(attributes, allocation{id: attr.ID.Value(), name: attr.Name})
+		}
+	}
+	a.auditIDs(types.EntityTypeAttribute, c.Name, attributes)
+
+	// Requests and responses are allocated from separate ID spaces
+	var requests, responses []allocation
+	for _, cmd := range c.Commands {
+		if !cmd.ID.Valid() {
+			continue
+		}
+		switch cmd.Direction {
+		case matter.InterfaceClient:
+			responses = append(responses, allocation{id: cmd.ID.Value(), name: cmd.Name})
+		default:
+			requests = append(requests, allocation{id: cmd.ID.Value(), name: cmd.Name})
+		}
+		a.auditFields(c.Name+"."+cmd.Name, cmd.Fields)
+	}
+	a.auditIDs(types.EntityTypeCommand, c.Name+" (request)", requests)
+	a.auditIDs(types.EntityTypeCommand, c.Name+" (response)", responses)
+
+	var events []allocation
+	for _, e := range c.Events {
+		if e.ID.Valid() {
+			events = append(events, allocation{id: e.ID.Value(), name: e.Name})
+		}
+		a.auditFields(c.Name+"."+e.Name, e.Fields)
+	}
+	a.auditIDs(types.EntityTypeEvent, c.Name, events)
+
+	if c.Features != nil {
+		a.auditBitmap(c.Name+".Features", &c.Features.Bitmap)
+	}
+}
+
+func (a *auditor) auditFields(scope string, fields matter.FieldSet) {
+	var ids []allocation
+	for _, f := range fields {
+		if f.ID.Valid() {
+			ids = append(ids, allocation{id: f.ID.Value(), name: f.Name})
+		}
+	}
+	a.auditIDs(types.EntityTypeField, scope, ids)
+}
+
+func (a *auditor) auditIDs(entityType types.EntityType, scope string, allocations []allocation) {
+	if len(allocations) == 0 {
+		return
+	}
+	space, known := idSpaceForEntity(entityType)
+
+	byID := make(map[uint64][]string)
+	for _, al := range allocations {
+		byID[al.id] = append(byID[al.id], al.name)
+	}
+	var ids []uint64
+	for id := range byID {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	var standard []uint64
+	for _, id := range ids {
+		names := byID[id]
+		if len(names) > 1 {
+			a.addIssue(IssueTypeCollision, entityType, scope, id, names)
+		}
+		if !known {
+			continue
+		}
+		switch {
+		case entityType == types.EntityTypeAttribute && globalAttributes.contains(id):
+			a.addIssue(IssueTypeGlobalOverlap, entityType, scope, id, names)
+			continue
+		case entityType == types.EntityTypeField && id == fabricIndexFieldID:
+			continue
+		}
+		it := space.classify(id)
+		if it != IssueTypeUnknown {
+			a.addIssue(it, entityType, scope, id, names)
+			continue
+		}
+		standard = append(standard, id)
+	}
+
+	if !known || entityType == types.EntityTypeField {
+		return
+	}
+
+	if len(standard) == 0 {
+		a.report.Suggestions = append(a.report.Suggestions, &Suggestion{Entity: entityType, Scope: scope, ID: formatID(entityType, space.standard.from)})
+		return
+	}
+	next := space.standard.from
+	for _, id := range standard {
+		if id > next {
+			a.report.Gaps = append(a.report.Gaps, &Gap{Entity: entityType, Scope: scope, From: formatID(entityType, next), To: formatID(entityType, id-1)})
+		}
+		next = id + 1
+	}
+	// New IDs are allocated after the highest one in use; gaps are usually left by deprecated elements and are reported, not reused
+	if space.standard.contains(next) {
+		a.report.Suggestions = append(a.report.Suggestions, &Suggestion{Entity: entityType, Scope: scope, ID: formatID(entityType, next)})
+	}
+}
+
+func (a *auditor) auditBitmap(scope string, bm *matter.Bitmap) {
+	size := bm.Size()
+	var masks []uint64
+	var names []string
+	for _, b := range bm.Bits {
+		mask, err := b.Mask()
+		if err != nil {
+			continue
+		}
+		if size < 64 && mask>>size != 0 {
+			a.addIssue(IssueTypeOutOfRange, types.EntityTypeBitmapValue, scope, mask, []string{b.Name()})
+		}
+		for i, m := range masks {
+			if m&mask != 0 {
+				a.addIssue(IssueTypeCollision, types.EntityTypeBitmapValue, scope, m&mask, []string{names[i], b.Name()})
+			}
+		}
+		masks = append(masks, mask)
+		names = append(names, b.Name())
+	}
+}
+
+func (a *auditor) auditEnum(scope string, e *matter.Enum) {
+	var max uint64 = 0xFF
+	if e.Type != nil && e.Type.BaseType == types.BaseDataTypeEnum16 {
+		max = 0xFFFF
+	}
+	byValue := make(map[uint64][]string)
+	var values []uint64
+	for _, v := range e.Values {
+		if !v.Value.Valid() {
+			continue
+		}
+		val := v.Value.Value()
+		if _, ok := byValue[val]; !ok {
+			values = append(values, val)
+		}
+		byValue[val] = append(byValue[val], v.Name)
+	}
+	slices.Sort(values)
+	for _, val := range values {
+		names := byValue[val]
+		if len(names) > 1 {
+			a.addIssue(IssueTypeCollision, types.EntityTypeEnumValue, scope, val, names)
+		}
+		if val > max {
+			a.addIssue(IssueTypeOutOfRange, types.EntityTypeEnumValue, scope, val, names)
+		}
+	}
+}
+
+func (a *auditor) addIssue(issueType IssueType, entityType types.EntityType, scope string, id uint64, names []string) {
+	a.report.Issues = append(a.report.Issues, &Issue{Type: issueType, Entity: entityType, Scope: scope, ID: formatID(entityType, id), Names: names})
+}
+
+// sortEntities sorts entities by name; entities sharing a name, such as data types defined by more than one cluster, are
+// ordered by the lowest ID of the clusters that own them, then by their own ID and the doc they came from, so reports
+// don't change from run to run
+func sortEntities[T types.Entity](s *spec.Specification, entities []T, key func(T) (string, *matter.Number)) {
+	slices.SortStableFunc(entities, func(a, b T) int {
+		aName, aID := key(a)
+		bName, bID := key(b)
+		return cmp.Or(
+			strings.Compare(aName, bName),
+			cmp.Compare(owningClusterID(s, a), owningClusterID(s, b)),
+			cmp.Compare(numberValue(aID), numberValue(bID)),
+			strings.Compare(s.DocRefs[a], s.DocRefs[b]),
+		)
+	})
+}
+
+// owningClusterID returns the lowest ID of the clusters that own an entity, or 0 if none do
+func owningClusterID(s *spec.Specification, entity types.Entity) uint64 {
+	var id uint64
+	var found bool
+	for c := range s.ClusterRefs[entity] {
+		if !c.ID.Valid() {
+			continue
+		}
+		if !found || c.ID.Value() < id {
+			id, found = c.ID.Value(), true
+		}
+	}
+	return id
+}
+
+func numberValue(n *matter.Number) uint64 {
+	if !n.Valid() {
+		return 0
+	}
+	return n.Value()
+}
+
+func scopeName(s *spec.Specification, entity types.Entity, name string) string {
+	clusters, ok := s.ClusterRefs[entity]
+	if !ok || len(clusters) != 1 {
+		return name
+	}
+	for c := range clusters {
+		return c.Name + "." + name
+	}
+	return name
+}
+
+func formatID(entityType types.EntityType, id uint64) string {
+	switch entityType {
+	case types.EntityTypeCluster, types.EntityTypeDeviceType, types.EntityTypeAttribute, types.EntityTypeBitmapValue:
+		if id > 0xFFFF {
+			return fmt.Sprintf("0x%08X", id)
+		}
+		return fmt.Sprintf("0x%04X", id)
+	}
+	return fmt.Sprintf("0x%02X", id)
+}
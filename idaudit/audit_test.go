@@ -0,0 +1,43 @@
+package idaudit
+
+import (
+	"testing"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func TestSortEntities(t *testing.T) {
+	s := &spec.Specification{ClusterRefs: make(spec.ClusterRefs), DocRefs: make(map[types.Entity]string)}
+	onOff := &matter.Cluster{Name: "On/Off", ID: matter.NewNumber(0x0006)}
+	levelControl := &matter.Cluster{Name: "Level Control", ID: matter.NewNumber(0x0008)}
+	modeSelect := &matter.Cluster{Name: "Mode Select", ID: matter.NewNumber(0x0050)}
+	levelMode := &matter.Enum{Name: "ModeEnum"}
+	onOffMode := &matter.Enum{Name: "ModeEnum"}
+	sharedMode := &matter.Enum{Name: "ModeEnum"}
+	effect := &matter.Enum{Name: "EffectEnum"}
+	s.ClusterRefs.Add(levelControl, levelMode)
+	s.ClusterRefs.Add(onOff, onOffMode)
+	s.ClusterRefs.Add(modeSelect, sharedMode)
+	s.ClusterRefs.Add(onOff, effect)
+
+	for _, enums := range [][]*matter.Enum{
+		{sharedMode, levelMode, effect, onOffMode},
+		{onOffMode, effect, levelMode, sharedMode},
+	} {
+		sortEntities(s, enums, func(e *matter.Enum) (string, *matter.Number) { return e.Name, nil })
+		expected := []*matter.Enum{effect, onOffMode, levelMode, sharedMode}
+		for i, e := range enums {
+			if e != expected[i] {
+				t.Errorf("unexpected enum at %d; expected %s owned by 0x%04X, got %s owned by 0x%04X", i, expected[i].Name, owningClusterID(s, expected[i]), e.Name, owningClusterID(s, e))
+			}
+		}
+	}
+
+	clusters := []*matter.Cluster{{Name: "Test", ID: matter.NewNumber(0x0FF1)}, {Name: "Test", ID: matter.NewNumber(0x0FF0)}}
+	sortEntities(s, clusters, func(c *matter.Cluster) (string, *matter.Number) { return c.Name, c.ID })
+	if clusters[0].ID.Value() != 0x0FF0 {
+		t.Errorf("unexpected cluster order; expected 0x0FF0 first, got %s", clusters[0].ID.HexString())
+	}
+}
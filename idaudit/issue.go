@@ -0,0 +1,64 @@
+package idaudit
+
+import (
+	"encoding/json"
+
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+type IssueType uint8
+
+const (
+	IssueTypeUnknown IssueType = iota
+	IssueTypeCollision
+	IssueTypeReserved
+	IssueTypeManufacturerSpecific
+	IssueTypeGlobalOverlap
+	IssueTypeOutOfRange
+)
+
+var (
+	issueTypeNames = map[IssueType]string{
+		IssueTypeUnknown:              "unknown",
+		IssueTypeCollision:            "collision",
+		IssueTypeReserved:             "reserved",
+		IssueTypeManufacturerSpecific: "manufacturerSpecific",
+		IssueTypeGlobalOverlap:        "globalOverlap",
+		IssueTypeOutOfRange:           "outOfRange",
+	}
+)
+
+func (it IssueType) String() string {
+	return issueTypeNames[it]
+}
+
+func (it IssueType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(issueTypeNames[it])
+}
+
+type Issue struct {
+	Type   IssueType        `json:"type"`
+	Entity types.EntityType `json:"entity"`
+	Scope  string           `json:"scope,omitempty"`
+	ID     string           `json:"id"`
+	Names  []string         `json:"names"`
+}
+
+type Gap struct {
+	Entity types.EntityType `json:"entity"`
+	Scope  string           `json:"scope,omitempty"`
+	From   string           `json:"from"`
+	To     string           `json:"to"`
+}
+
+type Suggestion struct {
+	Entity types.EntityType `json:"entity"`
+	Scope  string           `json:"scope,omitempty"`
+	ID     string           `json:"id"`
+}
+
+type Report struct {
+	Issues      []*Issue      `json:"issues,omitempty"`
+	Gaps        []*Gap        `json:"gaps,omitempty"`
+	Suggestions []*Suggestion `json:"suggestions,omitempty"`
+}
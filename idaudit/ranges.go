@@ -0,0 +1,88 @@
+package idaudit
+
+import (
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+type idRange struct {
+	from uint64
+	to   uint64
+}
+
+func (r idRange) contains(id uint64) bool {
+	return id >= r.from && id <= r.to
+}
+
+// idSpace describes how the lower 16 bits of an ID are allocated; the upper 16 bits of a cluster, device type, attribute, command or event ID carry a vendor prefix
+type idSpace struct {
+	standard     idRange
+	manufacturer *idRange
+	prefixed     bool
+}
+
+var (
+	clusterIDSpace = idSpace{
+		standard:     idRange{0x0000, 0x7FFF},
+		manufacturer: &idRange{0xFC00, 0xFFFE},
+		prefixed:     true,
+	}
+	deviceTypeIDSpace = idSpace{
+		standard: idRange{0x0000, 0xBFFF},
+		prefixed: true,
+	}
+	attributeIDSpace = idSpace{
+		standard: idRange{0x0000, 0x4FFF},
+		prefixed: true,
+	}
+	commandIDSpace = idSpace{
+		standard: idRange{0x00, 0xFF},
+		prefixed: true,
+	}
+	eventIDSpace = idSpace{
+		standard: idRange{0x00, 0xFF},
+		prefixed: true,
+	}
+	fieldIDSpace = idSpace{
+		standard: idRange{0x00, 0xDF},
+	}
+
+	// globalAttributes are the attributes every cluster carries; a cluster-specific attribute must not reuse their IDs
+	globalAttributes = idRange{0xFFF8, 0xFFFD}
+)
+
+const fabricIndexFieldID = 0xFE
+
+func idSpaceForEntity(entityType types.EntityType) (idSpace, bool) {
+	switch entityType {
+	case types.EntityTypeCluster:
+		return clusterIDSpace, true
+	case types.EntityTypeDeviceType:
+		return deviceTypeIDSpace, true
+	case types.EntityTypeAttribute:
+		return attributeIDSpace, true
+	case types.EntityTypeCommand:
+		return commandIDSpace, true
+	case types.EntityTypeEvent:
+		return eventIDSpace, true
+	case types.EntityTypeField:
+		return fieldIDSpace, true
+	}
+	return idSpace{}, false
+}
+
+func (s idSpace) classify(id uint64) IssueType {
+	suffix := id
+	if s.prefixed {
+		if id>>16 != 0 {
+			return IssueTypeManufacturerSpecific
+		}
+		suffix = id & 0xFFFF
+	}
+	switch {
+	case s.standard.contains(suffix):
+		return IssueTypeUnknown
+	case s.manufacturer != nil && s.manufacturer.contains(suffix):
+		return IssueTypeManufacturerSpecific
+	}
+	return IssueTypeReserved
+}
@@ -0,0 +1,59 @@
+package files
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hexops/gotextdiff"
+	"github.com/project-chip/alchemy/internal/pipeline"
+)
+
+var ErrStale = errors.New("generated files are out of date")
+
+// Checker compares generated output with the files on disk without writing anything, and fails if any would change
+type Checker[T string | []byte] struct {
+	writer
+
+	out  io.Writer
+	diff io.Writer
+}
+
+func NewChecker[T string | []byte](name string, out io.Writer, diff io.Writer) Writer[T] {
+	return &Checker[T]{writer: writer{name: name}, out: out, diff: diff}
+}
+
+func (sp *Checker[T]) Type() pipeline.ProcessorType {
+	return pipeline.ProcessorTypeCollective
+}
+
+func (sp *Checker[T]) Process(cxt context.Context, inputs []*pipeline.Data[T]) (outputs []*pipeline.Data[struct{}], err error) {
+	pipeline.SortData[T](inputs)
+	var stale int
+	for _, i := range inputs {
+		var existing string
+		var exists bool
+		var edits []gotextdiff.TextEdit
+		existing, exists, edits, err = compareFile(i.Path, string(i.Content))
+		if err != nil {
+			return
+		}
+		if exists && len(edits) == 0 {
+			continue
+		}
+		stale++
+		if exists {
+			fmt.Fprintf(sp.out, "Out of date: %s\n", i.Path)
+		} else {
+			fmt.Fprintf(sp.out, "Missing: %s\n", i.Path)
+		}
+		if sp.diff != nil {
+			fmt.Fprintln(sp.diff, gotextdiff.ToUnified(i.Path, i.Path, existing, edits))
+		}
+	}
+	if stale > 0 {
+		err = fmt.Errorf("%d of %d %w", stale, len(inputs), ErrStale)
+	}
+	return
+}
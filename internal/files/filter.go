@@ -3,6 +3,7 @@ package files
 import (
 	"context"
 	"os"
+	"path/filepath"
 
 	"github.com/bmatcuk/doublestar/v4"
 	"github.com/project-chip/alchemy/internal/pipeline"
@@ -47,6 +48,14 @@ func (p PathFilter[T]) Process(cxt context.Context, inputs []*pipeline.Data[T])
 	for _, d := range inputs {
 		var fi os.FileInfo
 		fi, err = os.Stat(d.Path)
+		if os.IsNotExist(err) {
+			// Docs read from a git ref or an archive don't exist on disk, so match their paths directly
+			err = nil
+			if p.matchPath(d.Path) {
+				outputs = append(outputs, d)
+			}
+			continue
+		}
 		if err != nil {
 			return
 		}
@@ -64,3 +73,12 @@ func (p PathFilter[T]) Process(cxt context.Context, inputs []*pipeline.Data[T])
 	}
 	return
 }
+
+func (p PathFilter[T]) matchPath(path string) bool {
+	for _, pattern := range p.paths {
+		if match, _ := doublestar.PathMatch(filepath.Clean(pattern), path); match {
+			return true
+		}
+	}
+	return false
+}
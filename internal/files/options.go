@@ -5,10 +5,12 @@ import "github.com/spf13/cobra"
 type Options struct {
 	DryRun bool
 	Patch  bool
+	Check  bool
 }
 
 func Flags(cmd *cobra.Command) (options Options) {
 	options.Patch, _ = cmd.Flags().GetBool("patch")
 	options.DryRun, _ = cmd.Flags().GetBool("dryrun")
+	options.Check, _ = cmd.Flags().GetBool("check")
 	return
 }
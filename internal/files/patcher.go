@@ -28,24 +28,33 @@ func (sp *Patcher[T]) Type() pipeline.ProcessorType {
 
 func (sp *Patcher[T]) Process(cxt context.Context, inputs []*pipeline.Data[T]) (outputs []*pipeline.Data[struct{}], err error) {
 	for _, i := range inputs {
-		var exists bool
-		exists, err = Exists(i.Path)
+		var existing string
+		var edits []gotextdiff.TextEdit
+		existing, _, edits, err = compareFile(i.Path, string(i.Content))
 		if err != nil {
 			return
 		}
-		var existing string
-		if exists {
-			var eb []byte
-			eb, err = os.ReadFile(i.Path)
-			if err != nil {
-				return
-			}
-			existing = string(eb)
-		}
-		edits := myers.ComputeEdits(span.URIFromPath(i.Path), existing, string(i.Content))
 		if len(edits) > 0 {
 			fmt.Fprintln(sp.out, gotextdiff.ToUnified(i.Path, i.Path, existing, edits))
 		}
 	}
 	return
 }
+
+// compareFile reads the file at path, if there is one, and computes the edits that would turn it into content
+func compareFile(path string, content string) (existing string, exists bool, edits []gotextdiff.TextEdit, err error) {
+	exists, err = Exists(path)
+	if err != nil {
+		return
+	}
+	if exists {
+		var eb []byte
+		eb, err = os.ReadFile(path)
+		if err != nil {
+			return
+		}
+		existing = string(eb)
+	}
+	edits = myers.ComputeEdits(span.URIFromPath(path), existing, content)
+	return
+}
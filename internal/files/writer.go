@@ -25,6 +25,12 @@ func (w *writer) SetName(name string) {
 }
 
 func NewWriter[T string | []byte](name string, options Options) Writer[T] {
+	if options.Check {
+		if options.Patch {
+			return NewChecker[T](name, os.Stderr, os.Stdout)
+		}
+		return NewChecker[T](name, os.Stderr, nil)
+	}
 	if options.DryRun {
 		return &DryRun[T]{writer: writer{name: name}}
 	}
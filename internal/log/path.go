@@ -25,6 +25,9 @@ func Path(name string, source matter.Source) slog.Attr {
 
 func Element(name string, path string, element asciidoc.Element) slog.Attr {
 	var arg strings.Builder
+	if hp, ok := element.(asciidoc.HasPosition); ok && hp.Path() != "" {
+		path = hp.Path()
+	}
 	arg.WriteString(path)
 	if hp, ok := element.(asciidoc.HasPosition); ok {
 		l, _, _ := hp.Position()
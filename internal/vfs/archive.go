@@ -0,0 +1,96 @@
+package vfs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+func openZip(path string) (fs.FS, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	mfs, err := readZip(&r.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	return specRoot(mfs)
+}
+
+// readZip reads a zip file into memory, as tarballs are, so the file needn't stay open while docs are read
+func readZip(r *zip.Reader) (*memFS, error) {
+	mfs := newMemFS()
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			mfs.addDir(f.Name, f.Modified)
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		mfs.addFile(f.Name, b, f.Modified)
+	}
+	return mfs, nil
+}
+
+func openTar(path string) (fs.FS, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var r io.Reader = f
+	lower := strings.ToLower(path)
+	if strings.HasSuffix(lower, ".gz") || strings.HasSuffix(lower, ".tgz") {
+		var gr *gzip.Reader
+		gr, err = gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", path, err)
+		}
+		defer gr.Close()
+		r = gr
+	}
+	mfs, err := readTar(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	return specRoot(mfs)
+}
+
+func readTar(r io.Reader) (*memFS, error) {
+	mfs := newMemFS()
+	tr := tar.NewReader(r)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return mfs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch h.Typeflag {
+		case tar.TypeDir:
+			mfs.addDir(h.Name, h.ModTime)
+		case tar.TypeReg:
+			b, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, err
+			}
+			mfs.addFile(h.Name, b, h.ModTime)
+		}
+	}
+}
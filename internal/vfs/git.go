@@ -0,0 +1,33 @@
+package vfs
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"strings"
+)
+
+// openGit reads the tree at ref out of a local git repository, so no checkout or worktree is needed
+func openGit(repo string, ref string) (fs.FS, error) {
+	cmd := exec.Command("git", "-C", repo, "archive", "--format=tar", ref)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	err = cmd.Start()
+	if err != nil {
+		return nil, fmt.Errorf("error running git: %w", err)
+	}
+	mfs, readErr := readTar(stdout)
+	err = cmd.Wait()
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s at %s: %s", repo, ref, strings.TrimSpace(stderr.String()))
+	}
+	if readErr != nil {
+		return nil, fmt.Errorf("error reading %s at %s: %w", repo, ref, readErr)
+	}
+	return mfs, nil
+}
@@ -0,0 +1,152 @@
+package vfs
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+type memFS struct {
+	entries map[string]*memEntry
+}
+
+type memEntry struct {
+	name     string
+	data     []byte
+	dir      bool
+	modTime  time.Time
+	children []string
+}
+
+func newMemFS() *memFS {
+	return &memFS{entries: map[string]*memEntry{".": {name: ".", dir: true}}}
+}
+
+func cleanPath(name string) string {
+	return path.Clean(strings.TrimPrefix(name, "/"))
+}
+
+func (m *memFS) addDir(name string, modTime time.Time) *memEntry {
+	name = cleanPath(name)
+	if e, ok := m.entries[name]; ok {
+		return e
+	}
+	e := &memEntry{name: path.Base(name), dir: true, modTime: modTime}
+	m.entries[name] = e
+	parent := m.addDir(path.Dir(name), modTime)
+	parent.children = append(parent.children, name)
+	return e
+}
+
+func (m *memFS) addFile(name string, data []byte, modTime time.Time) {
+	name = cleanPath(name)
+	if _, ok := m.entries[name]; !ok {
+		parent := m.addDir(path.Dir(name), modTime)
+		parent.children = append(parent.children, name)
+	}
+	m.entries[name] = &memEntry{name: path.Base(name), data: data, modTime: modTime}
+}
+
+func (m *memFS) lookup(op string, name string) (*memEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	e, ok := m.entries[name]
+	if !ok {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	return e, nil
+}
+
+func (m *memFS) Open(name string) (fs.File, error) {
+	e, err := m.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if e.dir {
+		return &memDir{memEntry: e, fs: m, path: name}, nil
+	}
+	return &memFile{memEntry: e, Reader: bytes.NewReader(e.data)}, nil
+}
+
+func (m *memFS) ReadFile(name string) ([]byte, error) {
+	e, err := m.lookup("read", name)
+	if err != nil {
+		return nil, err
+	}
+	if e.dir {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+	}
+	return slices.Clone(e.data), nil
+}
+
+func (m *memFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	e, err := m.lookup("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	if !e.dir {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	return m.dirEntries(e), nil
+}
+
+func (m *memFS) dirEntries(e *memEntry) []fs.DirEntry {
+	entries := make([]fs.DirEntry, 0, len(e.children))
+	for _, c := range e.children {
+		entries = append(entries, fs.FileInfoToDirEntry(m.entries[c]))
+	}
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int { return strings.Compare(a.Name(), b.Name()) })
+	return entries
+}
+
+func (e *memEntry) Name() string       { return e.name }
+func (e *memEntry) Size() int64        { return int64(len(e.data)) }
+func (e *memEntry) ModTime() time.Time { return e.modTime }
+func (e *memEntry) IsDir() bool        { return e.dir }
+func (e *memEntry) Sys() any           { return nil }
+
+func (e *memEntry) Mode() fs.FileMode {
+	if e.dir {
+		return fs.ModeDir | 0555
+	}
+	return 0444
+}
+
+type memFile struct {
+	*memEntry
+	*bytes.Reader
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.memEntry, nil }
+func (f *memFile) Close() error               { return nil }
+
+type memDir struct {
+	*memEntry
+	fs     *memFS
+	path   string
+	offset int
+}
+
+func (d *memDir) Stat() (fs.FileInfo, error) { return d.memEntry, nil }
+func (d *memDir) Close() error               { return nil }
+
+func (d *memDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.path, Err: fs.ErrInvalid}
+}
+
+func (d *memDir) ReadDir(count int) ([]fs.DirEntry, error) {
+	entries := d.fs.dirEntries(d.memEntry)[d.offset:]
+	if count > 0 {
+		if len(entries) == 0 {
+			return nil, io.EOF
+		}
+		entries = entries[:min(count, len(entries))]
+	}
+	d.offset += len(entries)
+	return entries, nil
+}
@@ -0,0 +1,48 @@
+package vfs
+
+import (
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// Open returns a file system for a spec root, which may be a directory, a tarball, a zip file, or a git repository at a given ref (e.g. "connectedhomeip-spec@v1.3")
+func Open(root string) (fs.FS, error) {
+	lower := strings.ToLower(root)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return openZip(root)
+	case strings.HasSuffix(lower, ".tar"), strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return openTar(root)
+	}
+	if isDir(root) {
+		return os.DirFS(root), nil
+	}
+	if i := strings.LastIndex(root, "@"); i > 0 && i < len(root)-1 {
+		repo, ref := root[:i], root[i+1:]
+		if isDir(repo) {
+			return openGit(repo, ref)
+		}
+	}
+	return os.DirFS(root), nil
+}
+
+func isDir(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.IsDir()
+}
+
+// specRoot strips the single top-level directory release archives are usually wrapped in
+func specRoot(fsys fs.FS) (fs.FS, error) {
+	if _, err := fs.Stat(fsys, "src"); err == nil {
+		return fsys, nil
+	}
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		return fs.Sub(fsys, entries[0].Name())
+	}
+	return fsys, nil
+}
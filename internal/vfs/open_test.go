@@ -0,0 +1,151 @@
+package vfs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// Release archives wrap the spec in a single top-level directory
+var archiveTestFiles = map[string]string{
+	"connectedhomeip-spec-1.3/src/main.adoc":                      "= Main\n",
+	"connectedhomeip-spec-1.3/src/app_clusters/OnOff.adoc":        "= On/Off Cluster\n",
+	"connectedhomeip-spec-1.3/src/app_clusters/LevelControl.adoc": "= Level Control Cluster\n",
+}
+
+var archiveTests = []struct {
+	Name  string
+	Write func(w io.Writer) error
+}{
+	{Name: "spec.tar", Write: writeTestTar},
+	{Name: "spec.tar.gz", Write: writeTestTarGz},
+	{Name: "spec.tgz", Write: writeTestTarGz},
+	{Name: "spec.zip", Write: writeTestZip},
+}
+
+func writeTestTar(w io.Writer) error {
+	tw := tar.NewWriter(w)
+	for name, contents := range archiveTestFiles {
+		err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(contents)), Typeflag: tar.TypeReg})
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write([]byte(contents))
+		if err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func writeTestTarGz(w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	err := writeTestTar(gw)
+	if err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func writeTestZip(w io.Writer) error {
+	zw := zip.NewWriter(w)
+	for name, contents := range archiveTestFiles {
+		f, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = f.Write([]byte(contents))
+		if err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func TestOpenArchive(t *testing.T) {
+	dir := t.TempDir()
+	for _, at := range archiveTests {
+		path := filepath.Join(dir, at.Name)
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatalf("%s: failed creating archive: %v", at.Name, err)
+		}
+		err = at.Write(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: failed writing archive: %v", at.Name, err)
+		}
+		fsys, err := Open(path)
+		if err != nil {
+			t.Errorf("%s: failed opening archive: %v", at.Name, err)
+			continue
+		}
+		err = fstest.TestFS(fsys, "src/main.adoc", "src/app_clusters/OnOff.adoc", "src/app_clusters/LevelControl.adoc")
+		if err != nil {
+			t.Errorf("%s: %v", at.Name, err)
+		}
+		b, err := fs.ReadFile(fsys, "src/app_clusters/OnOff.adoc")
+		if err != nil {
+			t.Errorf("%s: failed reading file: %v", at.Name, err)
+			continue
+		}
+		if string(b) != "= On/Off Cluster\n" {
+			t.Errorf("%s: unexpected contents; expected %q, got %q", at.Name, "= On/Off Cluster\n", string(b))
+		}
+	}
+}
+
+func TestMemFS(t *testing.T) {
+	mfs := newMemFS()
+	modTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mfs.addDir("empty", modTime)
+	mfs.addFile("/src/main.adoc", []byte("= Main\n"), modTime)
+	mfs.addFile("src/app_clusters/OnOff.adoc", []byte("= On/Off Cluster\n"), modTime)
+	err := fstest.TestFS(mfs, "empty", "src/main.adoc", "src/app_clusters/OnOff.adoc")
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = mfs.Open("src/missing.adoc")
+	if !os.IsNotExist(err) {
+		t.Errorf("expected a not exist error opening a missing file, got %v", err)
+	}
+	_, err = mfs.Open("/src/main.adoc")
+	if err == nil {
+		t.Errorf("expected an error opening an invalid path")
+	}
+}
+
+var specRootTests = []struct {
+	Name     string
+	Files    []string
+	Expected string
+}{
+	{Name: "src at the top", Files: []string{"src/main.adoc", "README.md"}, Expected: "src/main.adoc"},
+	{Name: "single top-level directory", Files: []string{"spec-1.3/src/main.adoc", "spec-1.3/README.md"}, Expected: "src/main.adoc"},
+	{Name: "several top-level entries", Files: []string{"spec-1.3/src/main.adoc", "README.md"}, Expected: "spec-1.3/src/main.adoc"},
+}
+
+func TestSpecRoot(t *testing.T) {
+	for _, st := range specRootTests {
+		fsys := fstest.MapFS{}
+		for _, f := range st.Files {
+			fsys[f] = &fstest.MapFile{Data: []byte(f)}
+		}
+		root, err := specRoot(fsys)
+		if err != nil {
+			t.Errorf("%s: failed finding spec root: %v", st.Name, err)
+			continue
+		}
+		_, err = fs.Stat(root, st.Expected)
+		if err != nil {
+			t.Errorf("%s: expected %s in the spec root: %v", st.Name, st.Expected, err)
+		}
+	}
+}
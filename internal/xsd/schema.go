@@ -0,0 +1,148 @@
+package xsd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/beevik/etree"
+)
+
+// Schema is a set of XSD schema documents, loaded along with everything they include or import. It supports the
+// subset of XSD used by the Matter data model schemas; components are matched by local name, ignoring namespaces.
+type Schema struct {
+	elements        map[string]*etree.Element
+	complexTypes    map[string]*etree.Element
+	simpleTypes     map[string]*etree.Element
+	groups          map[string]*etree.Element
+	attributeGroups map[string]*etree.Element
+	attributes      map[string]*etree.Element
+
+	loaded map[string]struct{}
+
+	patterns     map[string]*regexp.Regexp
+	patternsLock sync.Mutex
+}
+
+func Load(paths ...string) (*Schema, error) {
+	s := &Schema{
+		elements:        make(map[string]*etree.Element),
+		complexTypes:    make(map[string]*etree.Element),
+		simpleTypes:     make(map[string]*etree.Element),
+		groups:          make(map[string]*etree.Element),
+		attributeGroups: make(map[string]*etree.Element),
+		attributes:      make(map[string]*etree.Element),
+		loaded:          make(map[string]struct{}),
+		patterns:        make(map[string]*regexp.Regexp),
+	}
+	for _, path := range paths {
+		err := s.load(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *Schema) load(path string) error {
+	path = filepath.Clean(path)
+	if _, ok := s.loaded[path]; ok {
+		return nil
+	}
+	s.loaded[path] = struct{}{}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	doc := etree.NewDocument()
+	err = doc.ReadFromBytes(b)
+	if err != nil {
+		return fmt.Errorf("error reading schema %s: %w", path, err)
+	}
+	root := doc.Root()
+	if root == nil || root.Tag != "schema" {
+		return fmt.Errorf("%s is not an XML schema", path)
+	}
+	for _, el := range root.ChildElements() {
+		name := el.SelectAttrValue("name", "")
+		switch el.Tag {
+		case "include", "import", "redefine":
+			location := el.SelectAttrValue("schemaLocation", "")
+			if location == "" || strings.Contains(location, "://") {
+				continue
+			}
+			err = s.load(filepath.Join(filepath.Dir(path), location))
+			if err != nil {
+				return err
+			}
+		case "element":
+			s.elements[name] = el
+		case "complexType":
+			s.complexTypes[name] = el
+		case "simpleType":
+			s.simpleTypes[name] = el
+		case "group":
+			s.groups[name] = el
+		case "attributeGroup":
+			s.attributeGroups[name] = el
+		case "attribute":
+			s.attributes[name] = el
+		}
+	}
+	return nil
+}
+
+func (s *Schema) pattern(p string) *regexp.Regexp {
+	s.patternsLock.Lock()
+	defer s.patternsLock.Unlock()
+	re, ok := s.patterns[p]
+	if !ok {
+		var err error
+		re, err = regexp.Compile("^(?:" + p + ")$")
+		if err != nil {
+			// Some XSD regular expression features have no RE2 equivalent; such patterns are not checked
+			re = nil
+		}
+		s.patterns[p] = re
+	}
+	return re
+}
+
+// localName strips the namespace prefix from a QName
+func localName(qname string) string {
+	if i := strings.IndexRune(qname, ':'); i >= 0 {
+		return qname[i+1:]
+	}
+	return qname
+}
+
+func prefix(qname string) string {
+	if i := strings.IndexRune(qname, ':'); i >= 0 {
+		return qname[:i]
+	}
+	return ""
+}
+
+// components returns the child elements of a schema element which are not annotations
+func components(el *etree.Element) (children []*etree.Element) {
+	for _, c := range el.ChildElements() {
+		if c.Tag != "annotation" {
+			children = append(children, c)
+		}
+	}
+	return
+}
+
+func firstComponent(el *etree.Element, tags ...string) *etree.Element {
+	for _, c := range el.ChildElements() {
+		for _, t := range tags {
+			if c.Tag == t {
+				return c
+			}
+		}
+	}
+	return nil
+}
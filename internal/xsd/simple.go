@@ -0,0 +1,222 @@
+package xsd
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/beevik/etree"
+)
+
+var decimalPattern = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)$`)
+
+var integerPattern = regexp.MustCompile(`^[+-]?\d+$`)
+
+var hexBinaryPattern = regexp.MustCompile(`^([0-9a-fA-F]{2})*$`)
+
+type integerRange struct {
+	min *big.Int
+	max *big.Int
+}
+
+func bounds(bits uint, signed bool) integerRange {
+	if signed {
+		limit := new(big.Int).Lsh(big.NewInt(1), bits-1)
+		return integerRange{min: new(big.Int).Neg(limit), max: new(big.Int).Sub(limit, big.NewInt(1))}
+	}
+	return integerRange{min: big.NewInt(0), max: new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), bits), big.NewInt(1))}
+}
+
+var integerTypes = map[string]integerRange{
+	"integer":            {},
+	"nonNegativeInteger": {min: big.NewInt(0)},
+	"positiveInteger":    {min: big.NewInt(1)},
+	"nonPositiveInteger": {max: big.NewInt(0)},
+	"negativeInteger":    {max: big.NewInt(-1)},
+	"long":               bounds(64, true),
+	"int":                bounds(32, true),
+	"short":              bounds(16, true),
+	"byte":               bounds(8, true),
+	"unsignedLong":       bounds(64, false),
+	"unsignedInt":        bounds(32, false),
+	"unsignedShort":      bounds(16, false),
+	"unsignedByte":       bounds(8, false),
+}
+
+func isXSDPrefix(p string) bool {
+	return p == "xs" || p == "xsd"
+}
+
+// checkSimple checks a value against a named simple type, or an anonymous one, returning a description of the problem
+// if it doesn't conform
+func (s *Schema) checkSimple(value string, typeName string, st *etree.Element) string {
+	return s.checkSimpleDepth(value, typeName, st, 0)
+}
+
+func (s *Schema) checkSimpleDepth(value string, typeName string, st *etree.Element, depth int) string {
+	if depth > 32 {
+		return ""
+	}
+	if st == nil {
+		if typeName == "" {
+			return ""
+		}
+		if !isXSDPrefix(prefix(typeName)) {
+			if named, ok := s.simpleTypes[localName(typeName)]; ok {
+				st = named
+			}
+		}
+		if st == nil {
+			return checkBuiltin(value, localName(typeName))
+		}
+	}
+	if r := firstComponent(st, "restriction"); r != nil {
+		if msg := s.checkSimpleDepth(value, r.SelectAttrValue("base", ""), firstComponent(r, "simpleType"), depth+1); msg != "" {
+			return msg
+		}
+		return s.checkFacets(value, r)
+	}
+	if l := firstComponent(st, "list"); l != nil {
+		for _, item := range strings.Fields(value) {
+			if msg := s.checkSimpleDepth(item, l.SelectAttrValue("itemType", ""), firstComponent(l, "simpleType"), depth+1); msg != "" {
+				return msg
+			}
+		}
+		return ""
+	}
+	if u := firstComponent(st, "union"); u != nil {
+		var members int
+		for _, member := range strings.Fields(u.SelectAttrValue("memberTypes", "")) {
+			members++
+			if s.checkSimpleDepth(value, member, nil, depth+1) == "" {
+				return ""
+			}
+		}
+		for _, c := range u.ChildElements() {
+			if c.Tag != "simpleType" {
+				continue
+			}
+			members++
+			if s.checkSimpleDepth(value, "", c, depth+1) == "" {
+				return ""
+			}
+		}
+		if members == 0 {
+			return ""
+		}
+		return fmt.Sprintf("value %q does not match any member of its union type", value)
+	}
+	return ""
+}
+
+func checkBuiltin(value string, name string) string {
+	trimmed := strings.TrimSpace(value)
+	if r, ok := integerTypes[name]; ok {
+		if !integerPattern.MatchString(trimmed) {
+			return fmt.Sprintf("value %q is not a valid %s", value, name)
+		}
+		i, _ := new(big.Int).SetString(strings.TrimPrefix(trimmed, "+"), 10)
+		if (r.min != nil && i.Cmp(r.min) < 0) || (r.max != nil && i.Cmp(r.max) > 0) {
+			return fmt.Sprintf("value %q is out of range for %s", value, name)
+		}
+		return ""
+	}
+	switch name {
+	case "boolean":
+		switch trimmed {
+		case "true", "false", "1", "0":
+			return ""
+		}
+	case "decimal":
+		if decimalPattern.MatchString(trimmed) {
+			return ""
+		}
+	case "float", "double":
+		switch trimmed {
+		case "INF", "-INF", "+INF", "NaN":
+			return ""
+		}
+		if _, err := strconv.ParseFloat(trimmed, 64); err == nil {
+			return ""
+		}
+	case "hexBinary":
+		if hexBinaryPattern.MatchString(trimmed) {
+			return ""
+		}
+	default:
+		// Strings, names, URIs and the like are not checked beyond their facets
+		return ""
+	}
+	return fmt.Sprintf("value %q is not a valid %s", value, name)
+}
+
+// checkFacets checks a value against the facets of a restriction
+func (s *Schema) checkFacets(value string, r *etree.Element) string {
+	var enumerations []string
+	var patterns []string
+	for _, f := range r.ChildElements() {
+		facet := f.SelectAttrValue("value", "")
+		switch f.Tag {
+		case "enumeration":
+			enumerations = append(enumerations, facet)
+		case "pattern":
+			patterns = append(patterns, facet)
+		case "length", "minLength", "maxLength":
+			limit, err := strconv.Atoi(facet)
+			if err != nil {
+				continue
+			}
+			length := utf8.RuneCountInString(value)
+			if (f.Tag == "length" && length != limit) || (f.Tag == "minLength" && length < limit) || (f.Tag == "maxLength" && length > limit) {
+				return fmt.Sprintf("value %q violates %s %d", value, f.Tag, limit)
+			}
+		case "minInclusive", "maxInclusive", "minExclusive", "maxExclusive":
+			v, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+			if !ok {
+				continue
+			}
+			limit, ok := new(big.Rat).SetString(facet)
+			if !ok {
+				continue
+			}
+			c := v.Cmp(limit)
+			if (f.Tag == "minInclusive" && c < 0) || (f.Tag == "maxInclusive" && c > 0) || (f.Tag == "minExclusive" && c <= 0) || (f.Tag == "maxExclusive" && c >= 0) {
+				return fmt.Sprintf("value %q violates %s %s", value, f.Tag, facet)
+			}
+		}
+	}
+	if len(enumerations) > 0 {
+		var found bool
+		for _, e := range enumerations {
+			if value == e || strings.TrimSpace(value) == e {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Sprintf("value %q is not one of %s", value, strings.Join(enumerations, ", "))
+		}
+	}
+	if len(patterns) > 0 {
+		// Patterns in the same restriction are alternatives
+		var matched, checked bool
+		for _, p := range patterns {
+			re := s.pattern(p)
+			if re == nil {
+				continue
+			}
+			checked = true
+			if re.MatchString(value) {
+				matched = true
+				break
+			}
+		}
+		if checked && !matched {
+			return fmt.Sprintf("value %q does not match the pattern %s", value, strings.Join(patterns, " | "))
+		}
+	}
+	return ""
+}
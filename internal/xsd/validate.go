@@ -0,0 +1,519 @@
+package xsd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/beevik/etree"
+)
+
+type Violation struct {
+	Element *etree.Element
+	Message string
+}
+
+func (v Violation) Error() string {
+	return v.Message
+}
+
+type validator struct {
+	schema     *Schema
+	violations []Violation
+}
+
+// Validate checks a document against the schema, returning every violation found
+func (s *Schema) Validate(doc *etree.Document) []Violation {
+	v := &validator{schema: s}
+	root := doc.Root()
+	if root == nil {
+		return []Violation{{Element: &doc.Element, Message: "document has no root element"}}
+	}
+	decl, ok := s.elements[root.Tag]
+	if !ok {
+		v.report(root, "no schema declaration for root element <%s>", root.Tag)
+		return v.violations
+	}
+	v.validateElement(root, decl)
+	return v.violations
+}
+
+func (v *validator) report(el *etree.Element, format string, args ...any) {
+	v.violations = append(v.violations, Violation{Element: el, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) validateElement(el *etree.Element, decl *etree.Element) {
+	if ref := decl.SelectAttrValue("ref", ""); ref != "" {
+		global, ok := v.schema.elements[localName(ref)]
+		if !ok {
+			v.report(el, "schema refers to unknown element %s", ref)
+			return
+		}
+		decl = global
+	}
+	if typeName := decl.SelectAttrValue("type", ""); typeName != "" {
+		if ct, ok := v.schema.complexType(typeName); ok {
+			v.validateComplex(el, ct)
+			return
+		}
+		v.validateSimpleElement(el, typeName, nil)
+		return
+	}
+	if ct := firstComponent(decl, "complexType"); ct != nil {
+		v.validateComplex(el, ct)
+		return
+	}
+	if st := firstComponent(decl, "simpleType"); st != nil {
+		v.validateSimpleElement(el, "", st)
+	}
+	// Elements without a type are anyType, so anything goes
+}
+
+func (s *Schema) complexType(qname string) (*etree.Element, bool) {
+	if isXSDPrefix(prefix(qname)) {
+		return nil, false
+	}
+	ct, ok := s.complexTypes[localName(qname)]
+	return ct, ok
+}
+
+func (v *validator) validateSimpleElement(el *etree.Element, typeName string, st *etree.Element) {
+	if localName(typeName) == "anyType" {
+		return
+	}
+	if len(el.ChildElements()) > 0 {
+		v.report(el, "<%s> has simple content, but contains child elements", el.Tag)
+	}
+	for _, a := range el.Attr {
+		if !isSchemaInstanceAttr(a) {
+			v.report(el, "attribute %s is not allowed on <%s>", a.Key, el.Tag)
+		}
+	}
+	if msg := v.schema.checkSimple(text(el), typeName, st); msg != "" {
+		v.report(el, "<%s> %s", el.Tag, msg)
+	}
+}
+
+// content is the effective content model of a complex type
+type content struct {
+	particles      []*etree.Element
+	mixed          bool
+	simple         bool
+	simpleType     string
+	simpleFacets   []*etree.Element
+	attributes     []*etree.Element
+	attributeGroup []*etree.Element
+	anyAttribute   bool
+}
+
+func (s *Schema) content(ct *etree.Element, c *content, depth int) {
+	if depth > 32 {
+		return
+	}
+	if ct.SelectAttrValue("mixed", "false") == "true" {
+		c.mixed = true
+	}
+	for _, child := range components(ct) {
+		switch child.Tag {
+		case "sequence", "choice", "all", "group":
+			c.particles = append(c.particles, child)
+		case "attribute":
+			c.attributes = append(c.attributes, child)
+		case "attributeGroup":
+			c.attributeGroup = append(c.attributeGroup, child)
+		case "anyAttribute":
+			c.anyAttribute = true
+		case "complexContent", "simpleContent":
+			if child.SelectAttrValue("mixed", "false") == "true" {
+				c.mixed = true
+			}
+			derivation := firstComponent(child, "extension", "restriction")
+			if derivation == nil {
+				continue
+			}
+			base := derivation.SelectAttrValue("base", "")
+			if child.Tag == "simpleContent" {
+				c.simple = true
+				if bt, ok := s.complexType(base); ok {
+					s.content(bt, c, depth+1)
+				} else {
+					c.simpleType = base
+				}
+				if derivation.Tag == "restriction" {
+					c.simpleFacets = append(c.simpleFacets, derivation)
+				}
+			} else if derivation.Tag == "extension" {
+				if bt, ok := s.complexType(base); ok {
+					s.content(bt, c, depth+1)
+				}
+			} else {
+				// A restriction restates the content it keeps, but inherits the base type's attributes
+				if bt, ok := s.complexType(base); ok {
+					var inherited content
+					s.content(bt, &inherited, depth+1)
+					c.attributes = append(c.attributes, inherited.attributes...)
+					c.attributeGroup = append(c.attributeGroup, inherited.attributeGroup...)
+					c.anyAttribute = c.anyAttribute || inherited.anyAttribute
+				}
+			}
+			s.content(derivation, c, depth+1)
+		}
+	}
+}
+
+func (v *validator) validateComplex(el *etree.Element, ct *etree.Element) {
+	var c content
+	v.schema.content(ct, &c, 0)
+	v.validateAttributes(el, &c)
+	if c.simple {
+		if len(el.ChildElements()) > 0 {
+			v.report(el, "<%s> has simple content, but contains child elements", el.Tag)
+		}
+		value := text(el)
+		if msg := v.schema.checkSimple(value, c.simpleType, nil); msg != "" {
+			v.report(el, "<%s> %s", el.Tag, msg)
+			return
+		}
+		for _, r := range c.simpleFacets {
+			if msg := v.schema.checkFacets(value, r); msg != "" {
+				v.report(el, "<%s> %s", el.Tag, msg)
+			}
+		}
+		return
+	}
+	if !c.mixed && strings.TrimSpace(text(el)) != "" {
+		v.report(el, "<%s> may not contain text", el.Tag)
+	}
+	children := el.ChildElements()
+	var model *etree.Element
+	switch len(c.particles) {
+	case 0:
+	case 1:
+		model = c.particles[0]
+	default:
+		// Extensions append their particles to their base type's
+		model = etree.NewElement("sequence")
+		for _, p := range c.particles {
+			model.AddChild(p.Copy())
+		}
+	}
+	if model == nil {
+		if len(children) > 0 {
+			v.report(children[0], "<%s> may not contain child elements, but contains <%s>", el.Tag, children[0].Tag)
+		}
+		return
+	}
+	m := &matcher{schema: v.schema, children: children}
+	var complete *match
+	for _, r := range m.occurs(model, 0) {
+		if r.end == len(children) {
+			complete = &r
+			break
+		}
+		if r.end > m.furthest {
+			m.furthest, m.expected = r.end, nil
+		}
+	}
+	if complete == nil {
+		expected := strings.Join(m.expected, ", ")
+		if m.furthest < len(children) {
+			if expected != "" {
+				v.report(children[m.furthest], "unexpected element <%s> in <%s>; expected %s", children[m.furthest].Tag, el.Tag, expected)
+			} else {
+				v.report(children[m.furthest], "unexpected element <%s> in <%s>", children[m.furthest].Tag, el.Tag)
+			}
+		} else {
+			v.report(el, "<%s> is missing required child elements; expected %s", el.Tag, expected)
+		}
+		return
+	}
+	for _, a := range complete.assignments {
+		if a.decl != nil {
+			v.validateElement(children[a.child], a.decl)
+		} else if decl, ok := v.schema.elements[children[a.child].Tag]; ok {
+			// Wildcards are validated laxly, against a global declaration if there is one
+			v.validateElement(children[a.child], decl)
+		}
+	}
+}
+
+func (v *validator) validateAttributes(el *etree.Element, c *content) {
+	uses := make(map[string]*etree.Element)
+	var addUses func(attributes []*etree.Element, groups []*etree.Element, depth int)
+	addUses = func(attributes []*etree.Element, groups []*etree.Element, depth int) {
+		for _, a := range attributes {
+			if a.SelectAttrValue("use", "") == "prohibited" {
+				continue
+			}
+			name := a.SelectAttrValue("name", "")
+			if ref := a.SelectAttrValue("ref", ""); ref != "" {
+				name = localName(ref)
+			}
+			uses[name] = a
+		}
+		if depth > 32 {
+			return
+		}
+		for _, g := range groups {
+			group, ok := v.schema.attributeGroups[localName(g.SelectAttrValue("ref", ""))]
+			if !ok {
+				continue
+			}
+			var nested content
+			for _, child := range components(group) {
+				switch child.Tag {
+				case "attribute":
+					nested.attributes = append(nested.attributes, child)
+				case "attributeGroup":
+					nested.attributeGroup = append(nested.attributeGroup, child)
+				case "anyAttribute":
+					c.anyAttribute = true
+				}
+			}
+			addUses(nested.attributes, nested.attributeGroup, depth+1)
+		}
+	}
+	addUses(c.attributes, c.attributeGroup, 0)
+
+	present := make(map[string]struct{})
+	for _, a := range el.Attr {
+		if isSchemaInstanceAttr(a) {
+			continue
+		}
+		present[a.Key] = struct{}{}
+		use, ok := uses[a.Key]
+		if !ok {
+			if !c.anyAttribute {
+				v.report(el, "attribute %s is not allowed on <%s>", a.Key, el.Tag)
+			}
+			continue
+		}
+		decl := use
+		if ref := use.SelectAttrValue("ref", ""); ref != "" {
+			if global, ok := v.schema.attributes[localName(ref)]; ok {
+				decl = global
+			}
+		}
+		if fixed := use.SelectAttrValue("fixed", decl.SelectAttrValue("fixed", "")); fixed != "" && a.Value != fixed {
+			v.report(el, "attribute %s on <%s> must be %q, not %q", a.Key, el.Tag, fixed, a.Value)
+			continue
+		}
+		if msg := v.schema.checkSimple(a.Value, decl.SelectAttrValue("type", ""), firstComponent(decl, "simpleType")); msg != "" {
+			v.report(el, "attribute %s on <%s> %s", a.Key, el.Tag, msg)
+		}
+	}
+	for name, use := range uses {
+		if use.SelectAttrValue("use", "") != "required" {
+			continue
+		}
+		if _, ok := present[name]; !ok {
+			v.report(el, "<%s> is missing required attribute %s", el.Tag, name)
+		}
+	}
+}
+
+type assignment struct {
+	child int
+	decl  *etree.Element
+}
+
+type match struct {
+	end         int
+	assignments []assignment
+}
+
+// matcher finds the ways a content model can consume a run of child elements
+type matcher struct {
+	schema   *Schema
+	children []*etree.Element
+
+	furthest int
+	expected []string
+}
+
+func occursBounds(p *etree.Element) (minimum int, maximum int) {
+	minimum, maximum = 1, 1
+	if m, err := strconv.Atoi(p.SelectAttrValue("minOccurs", "1")); err == nil {
+		minimum = m
+	}
+	switch m := p.SelectAttrValue("maxOccurs", "1"); m {
+	case "unbounded":
+		maximum = -1
+	default:
+		if n, err := strconv.Atoi(m); err == nil {
+			maximum = n
+		}
+	}
+	return
+}
+
+// occurs matches a particle as many times as its occurrence bounds allow, starting at pos
+func (m *matcher) occurs(p *etree.Element, pos int) (results []match) {
+	minimum, maximum := occursBounds(p)
+	seen := make(map[int]struct{})
+	add := func(r match) {
+		if _, ok := seen[r.end]; !ok {
+			seen[r.end] = struct{}{}
+			results = append(results, r)
+		}
+	}
+	current := []match{{end: pos}}
+	if minimum == 0 {
+		add(current[0])
+	}
+	for count := 1; (maximum < 0 || count <= maximum) && len(current) > 0; count++ {
+		var next []match
+		ends := make(map[int]struct{})
+		for _, c := range current {
+			for _, r := range m.once(p, c.end) {
+				if r.end == c.end && count > minimum {
+					// An empty repetition can't get any further
+					continue
+				}
+				if _, ok := ends[r.end]; ok {
+					continue
+				}
+				ends[r.end] = struct{}{}
+				next = append(next, match{end: r.end, assignments: append(slices.Clip(c.assignments), r.assignments...)})
+			}
+		}
+		if count >= minimum {
+			for _, r := range next {
+				add(r)
+			}
+		}
+		current = next
+		if count > len(m.children)+minimum {
+			break
+		}
+	}
+	return
+}
+
+func (m *matcher) once(p *etree.Element, pos int) []match {
+	switch p.Tag {
+	case "element":
+		name := p.SelectAttrValue("name", "")
+		if ref := p.SelectAttrValue("ref", ""); ref != "" {
+			name = localName(ref)
+		}
+		if pos < len(m.children) && m.children[pos].Tag == name {
+			return []match{{end: pos + 1, assignments: []assignment{{child: pos, decl: p}}}}
+		}
+		m.expect(pos, "<"+name+">")
+		return nil
+	case "any":
+		if pos < len(m.children) {
+			return []match{{end: pos + 1, assignments: []assignment{{child: pos}}}}
+		}
+		m.expect(pos, "any element")
+		return nil
+	case "sequence":
+		states := []match{{end: pos}}
+		for _, c := range components(p) {
+			var next []match
+			seen := make(map[int]struct{})
+			for _, s := range states {
+				for _, r := range m.occurs(c, s.end) {
+					if _, ok := seen[r.end]; ok {
+						continue
+					}
+					seen[r.end] = struct{}{}
+					next = append(next, match{end: r.end, assignments: append(slices.Clip(s.assignments), r.assignments...)})
+				}
+			}
+			states = next
+			if len(states) == 0 {
+				break
+			}
+		}
+		return states
+	case "choice":
+		var results []match
+		seen := make(map[int]struct{})
+		for _, c := range components(p) {
+			for _, r := range m.occurs(c, pos) {
+				if _, ok := seen[r.end]; !ok {
+					seen[r.end] = struct{}{}
+					results = append(results, r)
+				}
+			}
+		}
+		return results
+	case "all":
+		return m.all(p, pos)
+	case "group":
+		group, ok := m.schema.groups[localName(p.SelectAttrValue("ref", ""))]
+		if !ok {
+			return nil
+		}
+		inner := firstComponent(group, "sequence", "choice", "all")
+		if inner == nil {
+			return []match{{end: pos}}
+		}
+		return m.occurs(inner, pos)
+	}
+	return nil
+}
+
+// all matches the members of an all group in any order
+func (m *matcher) all(p *etree.Element, pos int) []match {
+	members := components(p)
+	used := make([]bool, len(members))
+	r := match{end: pos}
+	for r.end < len(m.children) {
+		found := -1
+		for i, member := range members {
+			if used[i] {
+				continue
+			}
+			name := member.SelectAttrValue("name", "")
+			if ref := member.SelectAttrValue("ref", ""); ref != "" {
+				name = localName(ref)
+			}
+			if m.children[r.end].Tag == name {
+				found = i
+				break
+			}
+		}
+		if found < 0 {
+			break
+		}
+		used[found] = true
+		r.assignments = append(r.assignments, assignment{child: r.end, decl: members[found]})
+		r.end++
+	}
+	for i, member := range members {
+		if minimum, _ := occursBounds(member); minimum > 0 && !used[i] {
+			m.expect(r.end, "<"+member.SelectAttrValue("name", localName(member.SelectAttrValue("ref", "")))+">")
+			return nil
+		}
+	}
+	return []match{r}
+}
+
+func (m *matcher) expect(pos int, what string) {
+	if pos > m.furthest {
+		m.furthest = pos
+		m.expected = nil
+	}
+	if pos == m.furthest && !slices.Contains(m.expected, what) {
+		m.expected = append(m.expected, what)
+	}
+}
+
+// text returns the character data directly inside an element
+func text(el *etree.Element) string {
+	var b strings.Builder
+	for _, t := range el.Child {
+		if cd, ok := t.(*etree.CharData); ok {
+			b.WriteString(cd.Data)
+		}
+	}
+	return b.String()
+}
+
+func isSchemaInstanceAttr(a etree.Attr) bool {
+	return a.Space == "xmlns" || (a.Space == "" && a.Key == "xmlns") || a.Space == "xsi" || a.Space == "xml"
+}
@@ -0,0 +1,105 @@
+package xsd
+
+import (
+	"testing"
+
+	"github.com/beevik/etree"
+)
+
+var validateTests = []struct {
+	Name       string
+	XML        string
+	Violations []string
+}{
+	{
+		Name: "minimal",
+		XML:  `<library id="1"><name>Local</name></library>`,
+	},
+	{
+		Name: "complete",
+		XML: `<library id="255"><name>Local</name>` +
+			`<item><book year="1999" isbn="0-000"><title>One</title><author>A</author><author>B</author></book></item>` +
+			`<item><disc>Two</disc></item>` +
+			`</library>`,
+	},
+	{
+		Name:       "missing required attribute",
+		XML:        `<library><name>Local</name></library>`,
+		Violations: []string{"<library> is missing required attribute id"},
+	},
+	{
+		Name:       "invalid attribute value",
+		XML:        `<library id="256"><name>Local</name></library>`,
+		Violations: []string{`attribute id on <library> value "256" is out of range for unsignedByte`},
+	},
+	{
+		Name:       "unknown attribute",
+		XML:        `<library id="1" owner="me"><name>Local</name></library>`,
+		Violations: []string{"attribute owner is not allowed on <library>"},
+	},
+	{
+		Name:       "sequence out of order",
+		XML:        `<library id="1"><item><disc>Two</disc></item><name>Local</name></library>`,
+		Violations: []string{"unexpected element <item> in <library>; expected <name>"},
+	},
+	{
+		Name:       "sequence missing element",
+		XML:        `<library id="1"></library>`,
+		Violations: []string{"<library> is missing required child elements; expected <name>"},
+	},
+	{
+		Name:       "choice of both",
+		XML:        `<library id="1"><name>Local</name><item><disc>Two</disc><book><title>One</title><author>A</author></book></item></library>`,
+		Violations: []string{"unexpected element <book> in <item>"},
+	},
+	{
+		Name:       "choice of neither",
+		XML:        `<library id="1"><name>Local</name><item/></library>`,
+		Violations: []string{"<item> is missing required child elements; expected <book>, <disc>"},
+	},
+	{
+		Name:       "minOccurs",
+		XML:        `<library id="1"><name>Local</name><item><book><title>One</title></book></item></library>`,
+		Violations: []string{"<book> is missing required child elements; expected <author>"},
+	},
+	{
+		Name:       "maxOccurs",
+		XML:        `<library id="1"><name>Local</name><item><book><title>One</title><author>A</author><author>B</author><author>C</author></book></item></library>`,
+		Violations: []string{"unexpected element <author> in <book>"},
+	},
+	{
+		Name:       "extension after base content",
+		XML:        `<library id="1"><name>Local</name><item><book><author>A</author><title>One</title></book></item></library>`,
+		Violations: []string{"unexpected element <author> in <book>; expected <title>"},
+	},
+	{
+		Name:       "extension inherits attributes",
+		XML:        `<library id="1"><name>Local</name><item><book year="soon"><title>One</title><author>A</author></book></item></library>`,
+		Violations: []string{`attribute year on <book> value "soon" is not a valid integer`},
+	},
+}
+
+func TestValidate(t *testing.T) {
+	schema, err := Load("testdata/library.xsd")
+	if err != nil {
+		t.Fatalf("failed loading schema: %v", err)
+	}
+	for _, vt := range validateTests {
+		doc := etree.NewDocument()
+		err = doc.ReadFromString(vt.XML)
+		if err != nil {
+			t.Errorf("%s: failed reading XML: %v", vt.Name, err)
+			continue
+		}
+		violations := schema.Validate(doc)
+		if len(violations) != len(vt.Violations) {
+			t.Errorf("%s: expected %d violations, got %d: %v", vt.Name, len(vt.Violations), len(violations), violations)
+			continue
+		}
+		for i, v := range violations {
+			if v.Message != vt.Violations[i] {
+				t.Errorf("%s: unexpected violation; expected \"%s\", got \"%s\"", vt.Name, vt.Violations[i], v.Message)
+			}
+		}
+	}
+}
@@ -15,6 +15,7 @@ type Command struct {
 	Response    string          `json:"response,omitempty"`
 	Conformance conformance.Set `json:"conformance,omitempty"`
 	Access      Access          `json:"access,omitempty"`
+	Quality     Quality         `json:"quality,omitempty"`
 
 	Fields FieldSet `json:"fields,omitempty"`
 }
@@ -28,7 +29,7 @@ func (c *Command) GetConformance() conformance.Set {
 }
 
 func (c *Command) Clone() *Command {
-	nc := &Command{ID: c.ID.Clone(), Name: c.Name, Description: c.Description, Direction: c.Direction, Response: c.Response, Access: c.Access}
+	nc := &Command{ID: c.ID.Clone(), Name: c.Name, Description: c.Description, Direction: c.Direction, Response: c.Response, Access: c.Access, Quality: c.Quality}
 	if len(c.Conformance) > 0 {
 		nc.Conformance = c.Conformance.CloneSet()
 	}
@@ -52,6 +53,9 @@ func (c *Command) Inherit(parent *Command) {
 	if len(c.Conformance) == 0 {
 		c.Conformance = parent.Conformance.CloneSet()
 	}
+	if c.Quality == QualityNone {
+		c.Quality = parent.Quality
+	}
 	c.Access.Inherit(parent.Access)
 	c.Fields = c.Fields.Inherit(parent.Fields)
 }
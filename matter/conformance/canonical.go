@@ -0,0 +1,297 @@
+package conformance
+
+import (
+	"slices"
+	"strings"
+)
+
+// maxTruthTableIdentifiers bounds the identifiers IsTautology and IsContradiction will enumerate every combination of
+const maxTruthTableIdentifiers = 12
+
+type constant uint8
+
+const (
+	constantNone constant = iota
+	constantTrue
+	constantFalse
+)
+
+// Canonicalize returns an equivalent conformance in canonical form: negations are pushed down to identifiers,
+// nested logical expressions of the same kind are flattened and their terms deduplicated and sorted, and
+// entries which can never apply, such as those after an unconditional mandatory or optional entry, are removed
+func Canonicalize(cs Set) Set {
+	canonical := make(Set, 0, len(cs))
+	var conditions []Expression
+	for _, c := range cs {
+		var exp Expression
+		switch c := c.(type) {
+		case *Mandatory:
+			exp = c.Expression
+		case *Optional:
+			exp = c.Expression
+		default:
+			// Provisional, deprecated and similar entries are kept along with what follows them, since they qualify it
+			canonical = append(canonical, c.Clone())
+			continue
+		}
+		if exp != nil {
+			var value constant
+			exp, value = simplifyExpression(exp)
+			if value == constantNone {
+				if IsContradiction(exp) {
+					value = constantFalse
+				} else if IsTautology(exp) {
+					value = constantTrue
+				}
+			}
+			switch value {
+			case constantFalse:
+				continue
+			case constantTrue:
+				exp = nil
+			}
+		}
+		if exp != nil && slices.ContainsFunc(conditions, exp.Equal) {
+			// An earlier entry with the same condition always applies first
+			continue
+		}
+		switch c := c.(type) {
+		case *Mandatory:
+			canonical = append(canonical, &Mandatory{Expression: exp})
+		case *Optional:
+			o := &Optional{Expression: exp}
+			if c.Choice != nil {
+				o.Choice = c.Choice.Clone()
+			}
+			canonical = append(canonical, o)
+		}
+		if exp == nil {
+			break
+		}
+		conditions = append(conditions, exp)
+	}
+	if len(canonical) == 0 && len(cs) > 0 {
+		// Every entry was a contradiction, so the conformance never applies
+		canonical = append(canonical, &Disallowed{})
+	}
+	return canonical
+}
+
+// CanonicalizeExpression returns an equivalent expression in canonical form, or nil if the expression is always true
+func CanonicalizeExpression(exp Expression) Expression {
+	exp, value := simplifyExpression(exp)
+	if value == constantTrue {
+		return nil
+	}
+	return exp
+}
+
+// Equivalent reports whether two conformances have the same canonical form
+func Equivalent(a Set, b Set) bool {
+	return Canonicalize(a).Equal(Canonicalize(b))
+}
+
+// IsTautology reports whether an expression is true however its identifiers are set; expressions with references
+// or too many identifiers to enumerate are never considered tautologies
+func IsTautology(exp Expression) bool {
+	return truthTable(exp, true)
+}
+
+// IsContradiction reports whether an expression is false however its identifiers are set; expressions with references
+// or too many identifiers to enumerate are never considered contradictions
+func IsContradiction(exp Expression) bool {
+	return truthTable(exp, false)
+}
+
+func truthTable(exp Expression, expected bool) bool {
+	if exp == nil || hasReference(exp) {
+		return false
+	}
+	ids := ReferencedIdentifiers(&Mandatory{Expression: exp})
+	if len(ids) > maxTruthTableIdentifiers {
+		return false
+	}
+	values := make(map[string]any, len(ids))
+	for combination := 0; combination < 1<<len(ids); combination++ {
+		for i, id := range ids {
+			values[id] = combination&(1<<i) != 0
+		}
+		result, err := exp.Eval(Context{Values: values})
+		if err != nil || result != expected {
+			return false
+		}
+	}
+	return true
+}
+
+func hasReference(exp Expression) bool {
+	switch exp := exp.(type) {
+	case *ReferenceExpression:
+		return true
+	case *LogicalExpression:
+		return hasReference(exp.Left) || slices.ContainsFunc(exp.Right, hasReference)
+	case *EqualityExpression:
+		return hasReference(exp.Left) || hasReference(exp.Right)
+	}
+	return false
+}
+
+func simplifyExpression(exp Expression) (Expression, constant) {
+	switch exp := exp.(type) {
+	case *LogicalExpression:
+		return simplifyLogicalExpression(exp)
+	case *EqualityExpression:
+		left, lv := simplifyExpression(exp.Left)
+		right, rv := simplifyExpression(exp.Right)
+		switch {
+		case lv != constantNone && rv != constantNone:
+			return nil, constantOf((lv == rv) != exp.Not)
+		case lv != constantNone:
+			left, rv = right, lv
+			fallthrough
+		case rv != constantNone:
+			// A == true is A, and A == false is !A
+			if (rv == constantFalse) != exp.Not {
+				return simplifyExpression(negateExpression(left))
+			}
+			return left, constantNone
+		}
+		return &EqualityExpression{Not: exp.Not, Left: left, Right: right}, constantNone
+	default:
+		return exp.Clone(), constantNone
+	}
+}
+
+func simplifyLogicalExpression(le *LogicalExpression) (Expression, constant) {
+	operands := append([]Expression{le.Left}, le.Right...)
+	switch le.Operand {
+	case "&", "|":
+		operand := le.Operand
+		if le.Not {
+			// De Morgan: !(A | B) is !A & !B, and !(A & B) is !A | !B
+			for i, o := range operands {
+				operands[i] = negateExpression(o)
+			}
+			if operand == "&" {
+				operand = "|"
+			} else {
+				operand = "&"
+			}
+		}
+		return simplifyJunction(operand, operands)
+	case "^":
+		if le.Not {
+			// !(A ^ B) is !A ^ B
+			operands[0] = negateExpression(operands[0])
+		}
+		var terms []Expression
+		var odd bool
+		for _, o := range operands {
+			o, value := simplifyExpression(o)
+			switch value {
+			case constantTrue:
+				odd = !odd
+			case constantFalse:
+			default:
+				terms = append(terms, o)
+			}
+		}
+		switch len(terms) {
+		case 0:
+			return nil, constantOf(odd)
+		case 1:
+			if odd {
+				return simplifyExpression(negateExpression(terms[0]))
+			}
+			return terms[0], constantNone
+		}
+		if odd {
+			terms[0] = negateExpression(terms[0])
+		}
+		return &LogicalExpression{Operand: "^", Left: terms[0], Right: terms[1:]}, constantNone
+	default:
+		return le.Clone(), constantNone
+	}
+}
+
+func simplifyJunction(operand string, operands []Expression) (Expression, constant) {
+	// A true term decides an or, and a false term decides an and
+	decisive, neutral := constantTrue, constantFalse
+	if operand == "&" {
+		decisive, neutral = constantFalse, constantTrue
+	}
+	var terms []Expression
+	for _, o := range operands {
+		o, value := simplifyExpression(o)
+		switch value {
+		case decisive:
+			return nil, decisive
+		case neutral:
+			continue
+		}
+		if le, ok := o.(*LogicalExpression); ok && le.Operand == operand && !le.Not {
+			terms = append(terms, le.Left)
+			terms = append(terms, le.Right...)
+			continue
+		}
+		terms = append(terms, o)
+	}
+	var unique []Expression
+	for _, t := range terms {
+		if !slices.ContainsFunc(unique, t.Equal) {
+			unique = append(unique, t)
+		}
+	}
+	for _, t := range unique {
+		negated, _ := simplifyExpression(negateExpression(t))
+		if negated != nil && slices.ContainsFunc(unique, negated.Equal) {
+			// A & !A is always false, and A | !A always true
+			return nil, decisive
+		}
+	}
+	switch len(unique) {
+	case 0:
+		return nil, neutral
+	case 1:
+		return unique[0], constantNone
+	}
+	slices.SortStableFunc(unique, compareTerms)
+	return &LogicalExpression{Operand: operand, Left: unique[0], Right: unique[1:]}, constantNone
+}
+
+// compareTerms orders terms by their text, ignoring negation so an identifier and its negation sort together
+func compareTerms(a Expression, b Expression) int {
+	as, bs := a.ASCIIDocString(), b.ASCIIDocString()
+	if c := strings.Compare(strings.TrimPrefix(as, "!"), strings.TrimPrefix(bs, "!")); c != 0 {
+		return c
+	}
+	return strings.Compare(as, bs)
+}
+
+func negateExpression(exp Expression) Expression {
+	switch exp := exp.(type) {
+	case *FeatureExpression:
+		return &FeatureExpression{Feature: exp.Feature, Not: !exp.Not}
+	case *IdentifierExpression:
+		return &IdentifierExpression{ID: exp.ID, Not: !exp.Not}
+	case *ReferenceExpression:
+		ne := exp.Clone().(*ReferenceExpression)
+		ne.Not = !ne.Not
+		return ne
+	case *EqualityExpression:
+		return &EqualityExpression{Not: !exp.Not, Left: exp.Left.Clone(), Right: exp.Right.Clone()}
+	case *LogicalExpression:
+		ne := exp.Clone().(*LogicalExpression)
+		ne.Not = !ne.Not
+		return ne
+	default:
+		return exp.Clone()
+	}
+}
+
+func constantOf(b bool) constant {
+	if b {
+		return constantTrue
+	}
+	return constantFalse
+}
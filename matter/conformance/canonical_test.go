@@ -0,0 +1,73 @@
+package conformance
+
+import "testing"
+
+var canonicalTests = []struct {
+	Conformance string
+	Canonical   string
+}{
+	{Conformance: "!(AB | CD)", Canonical: "!AB & !CD"},
+	{Conformance: "!AB & !CD", Canonical: "!AB & !CD"},
+	{Conformance: "CD | AB", Canonical: "AB \\| CD"},
+	{Conformance: "(AB | (CD | EF))", Canonical: "AB \\| CD \\| EF"},
+	{Conformance: "[AB | AB]", Canonical: "[AB]"},
+	{Conformance: "[AB & !AB], O", Canonical: "O"},
+	{Conformance: "AB | !AB, [CD]", Canonical: "M"},
+	{Conformance: "M, [AB]", Canonical: "M"},
+	{Conformance: "AB, [AB], O", Canonical: "AB, O"},
+	{Conformance: "[(AB & CD) | (AB & !CD)].a", Canonical: "[(AB & !CD) \\| (AB & CD)].a"},
+	{Conformance: "!(AB ^ CD)", Canonical: "!AB ^ CD"},
+	{Conformance: "P, O", Canonical: "P, O"},
+	{Conformance: "[AB & !AB]", Canonical: "X"},
+	{Conformance: "[AB & !AB], [CD & !CD]", Canonical: "X"},
+}
+
+var notEquivalentTests = []struct {
+	Conformance string
+	Other       string
+}{
+	{Conformance: "M", Other: "O"},
+	{Conformance: "AB", Other: "CD"},
+	{Conformance: "[AB]", Other: "[CD]"},
+	{Conformance: "AB, O", Other: "AB"},
+	{Conformance: "O.a", Other: "O.b"},
+	{Conformance: "[AB & !AB]", Other: "O"},
+}
+
+func TestCanonicalize(t *testing.T) {
+	for _, ct := range canonicalTests {
+		cs, err := tryParseConformance(ct.Conformance)
+		if err != nil {
+			t.Errorf("failed parsing conformance %s: %v", ct.Conformance, err)
+			continue
+		}
+		canonical := Canonicalize(cs)
+		if s := canonical.ASCIIDocString(); s != ct.Canonical {
+			t.Errorf("unexpected canonical form for conformance \"%s\"; expected \"%s\", got \"%s\"", ct.Conformance, ct.Canonical, s)
+		}
+		if !Equivalent(cs, canonical) {
+			t.Errorf("canonical form \"%s\" not equivalent to conformance \"%s\"", canonical.ASCIIDocString(), ct.Conformance)
+		}
+	}
+}
+
+func TestNotEquivalent(t *testing.T) {
+	for _, nt := range notEquivalentTests {
+		cs, err := tryParseConformance(nt.Conformance)
+		if err != nil {
+			t.Errorf("failed parsing conformance %s: %v", nt.Conformance, err)
+			continue
+		}
+		other, err := tryParseConformance(nt.Other)
+		if err != nil {
+			t.Errorf("failed parsing conformance %s: %v", nt.Other, err)
+			continue
+		}
+		if cs.Equal(other) || other.Equal(cs) {
+			t.Errorf("conformance \"%s\" unexpectedly equal to \"%s\"", nt.Conformance, nt.Other)
+		}
+		if Equivalent(cs, other) || Equivalent(other, cs) {
+			t.Errorf("conformance \"%s\" unexpectedly equivalent to \"%s\"", nt.Conformance, nt.Other)
+		}
+	}
+}
@@ -34,6 +34,9 @@ func (c *Choice) Equal(oc *Choice) bool {
 	if c.Set != oc.Set {
 		return false
 	}
+	if c.Limit == nil || oc.Limit == nil {
+		return c.Limit == nil && oc.Limit == nil
+	}
 	if !c.Limit.Equal(oc.Limit) {
 		return false
 	}
@@ -41,7 +44,11 @@ func (c *Choice) Equal(oc *Choice) bool {
 }
 
 func (c *Choice) Clone() *Choice {
-	return &Choice{Set: c.Set, Limit: c.Limit.Clone()}
+	nc := &Choice{Set: c.Set}
+	if c.Limit != nil {
+		nc.Limit = c.Limit.Clone()
+	}
+	return nc
 }
 
 type ChoiceLimit interface {
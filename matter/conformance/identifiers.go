@@ -0,0 +1,47 @@
+package conformance
+
+import "slices"
+
+// ReferencedIdentifiers returns the sorted feature and element identifiers referenced by the expressions in a conformance
+func ReferencedIdentifiers(c Conformance) []string {
+	ids := make(map[string]struct{})
+	addConformanceIdentifiers(c, ids)
+	identifiers := make([]string, 0, len(ids))
+	for id := range ids {
+		identifiers = append(identifiers, id)
+	}
+	slices.Sort(identifiers)
+	return identifiers
+}
+
+func addConformanceIdentifiers(c Conformance, ids map[string]struct{}) {
+	switch c := c.(type) {
+	case Set:
+		for _, cs := range c {
+			addConformanceIdentifiers(cs, ids)
+		}
+	case *Mandatory:
+		addExpressionIdentifiers(c.Expression, ids)
+	case *Optional:
+		addExpressionIdentifiers(c.Expression, ids)
+	}
+}
+
+func addExpressionIdentifiers(e Expression, ids map[string]struct{}) {
+	switch e := e.(type) {
+	case *FeatureExpression:
+		ids[e.Feature] = struct{}{}
+	case *IdentifierExpression:
+		ids[e.ID] = struct{}{}
+	case *ReferenceExpression:
+		ids[e.Reference] = struct{}{}
+	case *LogicalExpression:
+		addExpressionIdentifiers(e.Left, ids)
+		for _, r := range e.Right {
+			addExpressionIdentifiers(r, ids)
+		}
+	case *EqualityExpression:
+		addExpressionIdentifiers(e.Left, ids)
+		addExpressionIdentifiers(e.Right, ids)
+	}
+}
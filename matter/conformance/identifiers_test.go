@@ -0,0 +1,31 @@
+package conformance
+
+import (
+	"slices"
+	"testing"
+)
+
+var referencedIdentifiersTests = []struct {
+	Conformance string
+	Identifiers []string
+}{
+	{Conformance: "M", Identifiers: []string{}},
+	{Conformance: "AB, [CD & !EF]", Identifiers: []string{"AB", "CD", "EF"}},
+	{Conformance: "[Enabled]", Identifiers: []string{"Enabled"}},
+	{Conformance: "<<ref_Foo>>, O", Identifiers: []string{"ref_Foo"}},
+	{Conformance: "[AB | <<ref_Foo>>]", Identifiers: []string{"AB", "ref_Foo"}},
+}
+
+func TestReferencedIdentifiers(t *testing.T) {
+	for _, rt := range referencedIdentifiersTests {
+		cs, err := tryParseConformance(rt.Conformance)
+		if err != nil {
+			t.Errorf("failed parsing conformance %s: %v", rt.Conformance, err)
+			continue
+		}
+		ids := ReferencedIdentifiers(cs)
+		if !slices.Equal(ids, rt.Identifiers) {
+			t.Errorf("unexpected identifiers for conformance \"%s\"; expected %v, got %v", rt.Conformance, rt.Identifiers, ids)
+		}
+	}
+}
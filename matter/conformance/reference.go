@@ -39,7 +39,13 @@ func (re *ReferenceExpression) Eval(context Context) (bool, error) {
 }
 
 func evalReference(context Context, id string, not bool) (bool, error) {
-
+	if context.Values != nil {
+		if v, ok := context.Values[id]; ok {
+			if b, ok := v.(bool); ok {
+				return b != not, nil
+			}
+		}
+	}
 	if context.References != nil {
 		if context.VisitedReferences == nil {
 			context.VisitedReferences = make(map[string]struct{})
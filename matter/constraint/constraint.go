@@ -55,6 +55,10 @@ func init() {
 	}
 }
 
+func (ct Type) String() string {
+	return constraintTypeNames[ct]
+}
+
 func (ct Type) MarshalJSON() ([]byte, error) {
 	v, ok := constraintTypeNames[ct]
 	if !ok {
@@ -12,6 +12,7 @@ type Event struct {
 	Priority    string          `json:"priority,omitempty"`
 	Conformance conformance.Set `json:"conformance,omitempty"`
 	Access      Access          `json:"access,omitempty"`
+	Quality     Quality         `json:"quality,omitempty"`
 
 	Fields FieldSet `json:"fields,omitempty"`
 }
@@ -25,7 +26,7 @@ func (e *Event) EntityType() types.EntityType {
 }
 
 func (e *Event) Clone() *Event {
-	ne := &Event{ID: e.ID.Clone(), Name: e.Name, Description: e.Description, Priority: e.Priority, Access: e.Access}
+	ne := &Event{ID: e.ID.Clone(), Name: e.Name, Description: e.Description, Priority: e.Priority, Access: e.Access, Quality: e.Quality}
 	if len(e.Conformance) > 0 {
 		ne.Conformance = e.Conformance.CloneSet()
 	}
@@ -49,6 +50,9 @@ func (e *Event) Inherit(parent *Event) {
 	if len(e.Conformance) == 0 {
 		e.Conformance = parent.Conformance.CloneSet()
 	}
+	if e.Quality == QualityNone {
+		e.Quality = parent.Quality
+	}
 	e.Access.Inherit(parent.Access)
 	e.Fields = e.Fields.Inherit(parent.Fields)
 }
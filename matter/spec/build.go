@@ -53,6 +53,9 @@ func (sp *Builder) buildSpec(docs []*Doc) (spec *Specification, err error) {
 	}
 
 	for _, d := range docs {
+		if includedByExpansion(d) {
+			continue
+		}
 		crossReferences := d.CrossReferences()
 		for id, xrefs := range crossReferences {
 			d.group.crossReferences[id] = append(d.group.crossReferences[id], xrefs...)
@@ -60,6 +63,9 @@ func (sp *Builder) buildSpec(docs []*Doc) (spec *Specification, err error) {
 	}
 
 	for _, d := range docs {
+		if includedByExpansion(d) {
+			continue
+		}
 		var anchors map[string][]*Anchor
 		anchors, err = d.Anchors()
 		if err != nil {
@@ -85,6 +91,10 @@ func (sp *Builder) buildSpec(docs []*Doc) (spec *Specification, err error) {
 			// This file yields some fake entities due to the section titles, so we ignore it.
 			continue
 		}
+		if includedByExpansion(d) && fileName != "BaseDeviceType.adoc" {
+			// The entities of this doc are built from the doc it was expanded into, which saw the attributes set before the include
+			continue
+		}
 		var entities []types.Entity
 		entities, err = d.Entities()
 		if err != nil {
@@ -101,11 +111,18 @@ func (sp *Builder) buildSpec(docs []*Doc) (spec *Specification, err error) {
 			continue
 		}
 
+		entityPaths := d.entityPaths()
 		for _, m := range entities {
+			path := entityPaths[m]
+			switch filepath.Base(path) {
+			case "Data-Model.adoc", "BaseDeviceType.adoc":
+				// Expanded from the files above, which are handled on their own
+				continue
+			}
 			switch m := m.(type) {
 			case *matter.ClusterGroup:
 				for _, c := range m.Clusters {
-					addClusterToSpec(spec, d, c, d.spec)
+					addClusterToSpec(spec, path, c, d.spec)
 				}
 			case *matter.Cluster:
 				switch m.Name {
@@ -114,7 +131,7 @@ func (sp *Builder) buildSpec(docs []*Doc) (spec *Specification, err error) {
 				case "Bridged Device Basic Information":
 					bridgedBasicInformationCluster = m
 				}
-				addClusterToSpec(spec, d, m, d.spec)
+				addClusterToSpec(spec, path, m, d.spec)
 			case *matter.DeviceType:
 				spec.DeviceTypes[m.ID.Value()] = m
 			case *matter.Bitmap:
@@ -148,10 +165,10 @@ func (sp *Builder) buildSpec(docs []*Doc) (spec *Specification, err error) {
 			switch m := m.(type) {
 			case *matter.ClusterGroup:
 				for _, c := range m.Clusters {
-					spec.DocRefs[c] = d.Path
+					spec.DocRefs[c] = path
 				}
 			default:
-				spec.DocRefs[m] = d.Path
+				spec.DocRefs[m] = path
 			}
 		}
 
@@ -201,7 +218,7 @@ func (sp *Builder) buildSpec(docs []*Doc) (spec *Specification, err error) {
 	return
 }
 
-func addClusterToSpec(spec *Specification, d *Doc, m *matter.Cluster, specIndex *Specification) {
+func addClusterToSpec(spec *Specification, path string, m *matter.Cluster, specIndex *Specification) {
 	if m.ID.Valid() {
 		spec.ClustersByID[m.ID.Value()] = m
 	}
@@ -214,7 +231,7 @@ func addClusterToSpec(spec *Specification, d *Doc, m *matter.Cluster, specIndex
 		} else {
 			spec.Bitmaps[en.Name] = en
 		}
-		spec.DocRefs[en] = d.Path
+		spec.DocRefs[en] = path
 		specIndex.addEntity(en.Name, en, m)
 	}
 	for _, en := range m.Enums {
@@ -224,7 +241,7 @@ func addClusterToSpec(spec *Specification, d *Doc, m *matter.Cluster, specIndex
 		} else {
 			spec.Enums[en.Name] = en
 		}
-		spec.DocRefs[en] = d.Path
+		spec.DocRefs[en] = path
 		specIndex.addEntity(en.Name, en, m)
 	}
 	for _, en := range m.Structs {
@@ -234,7 +251,7 @@ func addClusterToSpec(spec *Specification, d *Doc, m *matter.Cluster, specIndex
 		} else {
 			spec.Structs[en.Name] = en
 		}
-		spec.DocRefs[en] = d.Path
+		spec.DocRefs[en] = path
 		specIndex.addEntity(en.Name, en, m)
 	}
 }
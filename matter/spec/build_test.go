@@ -0,0 +1,128 @@
+package spec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const includedClusterDoc = `[[ref_Wobble]]
+= Wobble Cluster
+
+== Classification
+
+|===
+| Hierarchy | Role        | Scope    | PICS Code
+| Base      | Application | Endpoint | WOB
+|===
+
+== Cluster ID
+
+|===
+| ID     | Name
+ifdef::in-spec[]
+| 0x0FF0 | Wobble
+endif::[]
+ifndef::in-spec[]
+| 0x0FF1 | Wobble
+endif::[]
+|===
+
+== Attributes
+
+|===
+| ID     | Name  | Type  | Constraint | Quality | Default | Access | Conformance
+| 0x0000 | Speed | uint8 | 0 to 200   |         | 10      | R V    | M
+|===
+`
+
+// The spec can't be built without these clusters
+const requiredClusterDoc = `= %[1]s Cluster
+
+== Classification
+
+|===
+| Hierarchy | Role    | Scope | PICS Code
+| Base      | Utility | Node  | %[2]s
+|===
+
+== Cluster ID
+
+|===
+| ID   | Name
+| %[3]s | %[1]s
+|===
+
+== Attributes
+
+|===
+| ID     | Name       | Type   | Constraint | Quality | Default | Access | Conformance
+| 0x0001 | VendorName | string | max 32     | F       |         | R V    | M
+|===
+`
+
+const includingDoc = `= Clusters
+:in-spec:
+
+include::BasicInformation.adoc[leveloffset=+1]
+
+include::BridgedDeviceBasicInformation.adoc[leveloffset=+1]
+
+include::Wobble.adoc[leveloffset=+1]
+`
+
+func TestBuildExpandedIncludes(t *testing.T) {
+	dir := t.TempDir()
+	rootPath := filepath.Join(dir, "Clusters.adoc")
+	clusterPath := filepath.Join(dir, "Wobble.adoc")
+	files := map[string]string{
+		rootPath:    includingDoc,
+		clusterPath: includedClusterDoc,
+		filepath.Join(dir, "BasicInformation.adoc"):              fmt.Sprintf(requiredClusterDoc, "Basic Information", "BINFO", "0x0028"),
+		filepath.Join(dir, "BridgedDeviceBasicInformation.adoc"): fmt.Sprintf(requiredClusterDoc, "Bridged Device Basic Information", "BRBINFO", "0x0039"),
+	}
+	for path, contents := range files {
+		err := os.WriteFile(path, []byte(contents), 0o644)
+		if err != nil {
+			t.Fatalf("failed writing %s: %v", path, err)
+		}
+	}
+	var docs []*Doc
+	for _, name := range []string{"Wobble.adoc", "BasicInformation.adoc", "BridgedDeviceBasicInformation.adoc", "Clusters.adoc"} {
+		doc, err := ParseFileWithIncludes(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("failed parsing %s: %v", name, err)
+		}
+		docs = append(docs, doc)
+	}
+	clusterDoc, rootDoc := docs[0], docs[3]
+
+	var b Builder
+	spec, err := b.buildSpec(docs)
+	if err != nil {
+		t.Fatalf("failed building spec: %v", err)
+	}
+
+	parents := clusterDoc.Parents()
+	if len(parents) != 1 || parents[0] != rootDoc {
+		t.Errorf("expected %s to be included by %s, got parents %v", clusterPath, rootPath, parents)
+	}
+	if clusterDoc.Group() == nil || clusterDoc.Group().Root != rootPath {
+		t.Errorf("expected %s to be in the doc group of %s", clusterPath, rootPath)
+	}
+	if len(spec.ClustersByID) != 3 {
+		t.Fatalf("expected 3 clusters, got %d", len(spec.ClustersByID))
+	}
+	// The included doc only sees the attribute set by the doc including it when expanded there
+	cluster, ok := spec.ClustersByID[0x0FF0]
+	if !ok {
+		t.Fatalf("expected the cluster to be built from the expanded include")
+	}
+	if len(cluster.Attributes) != 1 {
+		t.Errorf("expected 1 attribute, got %d", len(cluster.Attributes))
+	}
+	if path := spec.DocRefs[cluster]; path != clusterPath {
+		t.Errorf("unexpected doc for cluster; expected \"%s\", got \"%s\"", clusterPath, path)
+	}
+}
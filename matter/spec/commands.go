@@ -58,6 +58,12 @@ func (s *Section) toCommands(d *Doc, entityMap map[asciidoc.Attributable][]types
 			return
 		}
 		cmd.Access, _ = ParseAccess(a, types.EntityTypeCommand)
+		var q string
+		q, err = readRowASCIIDocString(row, columnMap, matter.TableColumnQuality)
+		if err != nil {
+			return
+		}
+		cmd.Quality = matter.ParseQuality(q)
 		commands = append(commands, cmd)
 		commandMap[strings.ToLower(cmd.Name)] = cmd
 	}
@@ -90,6 +96,7 @@ func (s *Section) toCommands(d *Doc, entityMap map[asciidoc.Attributable][]types
 			if desc != nil {
 				c.Description = strings.ReplaceAll(desc.Value, "\n", " ")
 			}
+			entityMap[s.Base] = append(entityMap[s.Base], c)
 
 			var rows []*asciidoc.TableRow
 			var headerRowIndex int
@@ -108,7 +115,6 @@ func (s *Section) toCommands(d *Doc, entityMap map[asciidoc.Attributable][]types
 			if err != nil {
 				return
 			}
-			entityMap[s.Base] = append(entityMap[s.Base], c)
 			fieldMap := make(map[string]*matter.Field, len(c.Fields))
 			for _, f := range c.Fields {
 				fieldMap[f.Name] = f
@@ -27,6 +27,11 @@ type Doc struct {
 	parents  []*Doc
 	children []*Doc
 
+	// expanded is set when the doc was parsed with its includes expanded in place; includes holds the paths
+	// that were expanded, keyed by the path of the file that included them
+	expanded bool
+	includes map[string][]string
+
 	referenceIndex
 	attributes map[asciidoc.AttributeName]any
 
@@ -122,6 +127,27 @@ func (doc *Doc) Entities() (entities []types.Entity, err error) {
 	return
 }
 
+// entityPaths maps the entities of a doc to the paths of the files their sections came from, which differ from the doc's own path
+// for sections expanded from its includes
+func (doc *Doc) entityPaths() map[types.Entity]string {
+	paths := make(map[types.Entity]string)
+	for _, entity := range doc.entities {
+		paths[entity] = doc.Path
+	}
+	for b, entities := range doc.entitiesBySection {
+		hp, ok := b.(asciidoc.HasPosition)
+		if !ok || hp.Path() == "" {
+			continue
+		}
+		for _, entity := range entities {
+			if _, ok := paths[entity]; ok {
+				paths[entity] = hp.Path()
+			}
+		}
+	}
+	return paths
+}
+
 func (doc *Doc) Reference(ref string) (types.Entity, bool) {
 
 	a := doc.FindAnchor(ref)
@@ -161,3 +187,34 @@ func (doc *Doc) Reference(ref string) (types.Entity, bool) {
 func GithubSettings() []asciidoc.AttributeName {
 	return []asciidoc.AttributeName{asciidoc.AttributeName("env-github")}
 }
+
+func (doc *Doc) EntitySection(entity types.Entity) *Section {
+	_, err := doc.Entities()
+	if err != nil {
+		return nil
+	}
+	var base asciidoc.Attributable
+	for b, entities := range doc.entitiesBySection {
+		for _, e := range entities {
+			if e == entity {
+				base = b
+				break
+			}
+		}
+		if base != nil {
+			break
+		}
+	}
+	if base == nil {
+		return nil
+	}
+	var section *Section
+	parse.Search(doc.Elements(), func(s *Section) parse.SearchShould {
+		if s.Base == base {
+			section = s
+			return parse.SearchShouldStop
+		}
+		return parse.SearchShouldContinue
+	})
+	return section
+}
@@ -52,6 +52,12 @@ func (s *Section) toEvents(d *Doc, entityMap map[asciidoc.Attributable][]types.E
 			// Sometimes the invoke access is omitted; we assume it's view
 			e.Access.Read = matter.PrivilegeView
 		}
+		var q string
+		q, err = readRowASCIIDocString(row, columnMap, matter.TableColumnQuality)
+		if err != nil {
+			return
+		}
+		e.Quality = matter.ParseQuality(q)
 		events = append(events, e)
 
 		eventMap[e.Name] = e
@@ -67,6 +73,7 @@ func (s *Section) toEvents(d *Doc, entityMap map[asciidoc.Attributable][]types.E
 				slog.Debug("unknown event", "event", name)
 				continue
 			}
+			entityMap[s.Base] = append(entityMap[s.Base], e)
 			var rows []*asciidoc.TableRow
 			var headerRowIndex int
 			var columnMap ColumnIndex
@@ -96,7 +103,6 @@ func (s *Section) toEvents(d *Doc, entityMap map[asciidoc.Attributable][]types.E
 			if err != nil {
 				return
 			}
-			entityMap[s.Base] = append(entityMap[s.Base], e)
 			fieldMap := make(map[string]*matter.Field, len(e.Fields))
 			for _, f := range e.Fields {
 				fieldMap[f.Name] = f
@@ -0,0 +1,57 @@
+package spec
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/project-chip/alchemy/internal/vfs"
+)
+
+// sources maps spec roots to the file systems docs under them are read from, so paths handed through the pipeline stay plain strings
+var sources struct {
+	sync.RWMutex
+	roots map[string]fs.FS
+}
+
+func openSource(specRoot string) (fs.FS, error) {
+	specRoot = filepath.Clean(specRoot)
+	sources.Lock()
+	defer sources.Unlock()
+	if fsys, ok := sources.roots[specRoot]; ok {
+		return fsys, nil
+	}
+	fsys, err := vfs.Open(specRoot)
+	if err != nil {
+		return nil, err
+	}
+	if sources.roots == nil {
+		sources.roots = make(map[string]fs.FS)
+	}
+	sources.roots[specRoot] = fsys
+	return fsys, nil
+}
+
+// OpenFile opens a spec document, reading it from the spec root it was found under; if roots are nested, the deepest one
+// holding the document is used
+func OpenFile(path string) (fs.File, error) {
+	sources.RLock()
+	defer sources.RUnlock()
+	var root, rel string
+	var fsys fs.FS
+	for r, f := range sources.roots {
+		rp, err := filepath.Rel(r, path)
+		if err != nil || rp == ".." || strings.HasPrefix(rp, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if fsys == nil || len(r) > len(root) {
+			root, rel, fsys = r, rp, f
+		}
+	}
+	if fsys != nil {
+		return fsys.Open(filepath.ToSlash(rel))
+	}
+	return os.Open(path)
+}
@@ -0,0 +1,41 @@
+package spec
+
+import (
+	"io"
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestOpenFileNestedRoots(t *testing.T) {
+	outer := filepath.Join(t.TempDir(), "spec")
+	inner := filepath.Join(outer, "src")
+	sources.Lock()
+	saved := sources.roots
+	sources.roots = map[string]fs.FS{
+		outer: fstest.MapFS{"src/main.adoc": {Data: []byte("outer")}},
+		inner: fstest.MapFS{"main.adoc": {Data: []byte("inner")}},
+	}
+	sources.Unlock()
+	defer func() {
+		sources.Lock()
+		sources.roots = saved
+		sources.Unlock()
+	}()
+	// Map order varies, so the file is opened more than once
+	for range 10 {
+		f, err := OpenFile(filepath.Join(inner, "main.adoc"))
+		if err != nil {
+			t.Fatalf("failed opening file: %v", err)
+		}
+		b, err := io.ReadAll(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("failed reading file: %v", err)
+		}
+		if string(b) != "inner" {
+			t.Fatalf("unexpected file contents; expected \"inner\", got \"%s\"", string(b))
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package spec
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// DataTypeOwners maps each bitmap, enum and struct defined in a cluster to that cluster; global data types have no owner.
+// Types shared by several clusters, as in cluster groups, belong to the first cluster by name.
+func (s *Specification) DataTypeOwners() map[types.Entity]*matter.Cluster {
+	clusters := make([]*matter.Cluster, 0, len(s.ClustersByName))
+	for _, c := range s.ClustersByName {
+		clusters = append(clusters, c)
+	}
+	slices.SortFunc(clusters, func(a, b *matter.Cluster) int { return strings.Compare(a.Name, b.Name) })
+
+	owners := make(map[types.Entity]*matter.Cluster)
+	own := func(e types.Entity, c *matter.Cluster) {
+		if _, ok := owners[e]; !ok {
+			owners[e] = c
+		}
+	}
+	for _, c := range clusters {
+		for _, bm := range c.Bitmaps {
+			own(bm, c)
+		}
+		for _, e := range c.Enums {
+			own(e, c)
+		}
+		for _, st := range c.Structs {
+			own(st, c)
+		}
+	}
+	return owners
+}
+
+// DataTypeEntityName returns the name of a bitmap, enum or struct, or false if the entity is not a data type
+func DataTypeEntityName(e types.Entity) (string, bool) {
+	switch e := e.(type) {
+	case *matter.Struct:
+		return e.Name, true
+	case *matter.Enum:
+		return e.Name, true
+	case *matter.Bitmap:
+		return e.Name, true
+	}
+	return "", false
+}
+
+// DataTypeName describes a data type by name, e.g. list[ModeStruct]
+func DataTypeName(dt *types.DataType) string {
+	if dt == nil {
+		return ""
+	}
+	if dt.EntryType != nil {
+		return "list[" + DataTypeName(dt.EntryType) + "]"
+	}
+	return dt.Name
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -16,7 +15,7 @@ import (
 
 func ParseFile(path string, attributes ...asciidoc.AttributeName) (*Doc, error) {
 
-	contents, err := os.Open(path)
+	contents, err := OpenFile(path)
 	if err != nil {
 		return nil, err
 	}
@@ -47,35 +46,133 @@ func parseReader(r io.Reader, path string, attributes ...asciidoc.AttributeName)
 }
 
 func ParseDocument(r io.Reader, path string, attributes ...asciidoc.AttributeName) (*asciidoc.Document, error) {
-	ac := &parse.AttributeContext{}
+	parsed, err := parse.PreParseReader(newAttributeContext(attributes), path, r)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, a := range attributes {
-		ac.Set(string(a), nil)
+	if len(parsed) == 0 {
+		return &asciidoc.Document{}, nil
 	}
 
-	parsed, err := parse.PreParseReader(ac, path, r)
+	parsed, _ = applyParseWorkarounds(path, parsed, nil)
+	return parse.String(path, parsed)
+}
+
+// ParseFileWithIncludes parses a doc with the contents of its included files expanded in place, while keeping
+// the positions of their elements in the files they came from
+func ParseFileWithIncludes(path string, attributes ...asciidoc.AttributeName) (*Doc, error) {
+	contents, err := OpenFile(path)
 	if err != nil {
 		return nil, err
 	}
+	defer contents.Close()
+	d, includes, err := parseDocumentWithIncludes(contents, path, attributes...)
+	if err != nil {
+		return nil, fmt.Errorf("parse error in %s: %w", path, err)
+	}
+	doc, err := NewDoc(d, path)
+	if err != nil {
+		return nil, err
+	}
+	doc.expanded = true
+	doc.includes = make(map[string][]string)
+	for _, i := range includes {
+		doc.includes[i.From] = append(doc.includes[i.From], i.To)
+	}
+	return doc, nil
+}
+
+func ParseDocumentWithIncludes(r io.Reader, path string, attributes ...asciidoc.AttributeName) (*asciidoc.Document, error) {
+	d, _, err := parseDocumentWithIncludes(r, path, attributes...)
+	return d, err
+}
+
+func parseDocumentWithIncludes(r io.Reader, path string, attributes ...asciidoc.AttributeName) (*asciidoc.Document, []parse.Include, error) {
+	parsed, lines, includes, err := parse.PreParseIncludes(newAttributeContext(attributes), path, r, openInclude)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	if len(parsed) == 0 {
-		return &asciidoc.Document{}, nil
+		return &asciidoc.Document{}, includes, nil
 	}
 
+	parsed, lines = applyParseWorkarounds(path, parsed, lines)
+	d, err := parse.String(path, parsed)
+	if err != nil {
+		return nil, nil, err
+	}
+	lines.Apply(d.Elements())
+	return d, includes, nil
+}
+
+func openInclude(path string) (io.ReadCloser, error) {
+	return OpenFile(path)
+}
+
+func newAttributeContext(attributes []asciidoc.AttributeName) *parse.AttributeContext {
+	ac := &parse.AttributeContext{}
+	for _, a := range attributes {
+		ac.Set(string(a), nil)
+	}
+	return ac
+}
+
+var doorLockPattern = regexp.MustCompile(`\n+\s*[^&\n]+&#8224;\s+`)
+
+// applyParseWorkarounds patches preparsed text the parser can't handle; if the text has a line map, it's kept in step with the lines removed
+func applyParseWorkarounds(path string, parsed string, lines parse.LineMap) (string, parse.LineMap) {
 	if filepath.Base(path) == "DoorLock.adoc" { // Craptastic workaround for very weird table cell
-		var doorLockPattern = regexp.MustCompile(`\n+\s*[^&\n]+&#8224;\s+`)
-		parsed = doorLockPattern.ReplaceAllString(parsed, "\n")
+		parsed, lines = replaceLines(parsed, lines, doorLockPattern, "\n")
 	}
+	return parsed, lines
+}
 
-	return parse.String(path, parsed)
+func replaceLines(s string, lines parse.LineMap, pattern *regexp.Regexp, replacement string) (string, parse.LineMap) {
+	if lines == nil {
+		return pattern.ReplaceAllString(s, replacement), nil
+	}
+	var sb strings.Builder
+	var kept parse.LineMap
+	var last, line int
+	for _, m := range pattern.FindAllStringIndex(s, -1) {
+		before := s[last:m[0]]
+		sb.WriteString(before)
+		n := strings.Count(before, "\n")
+		kept = append(kept, lines[line:line+n]...)
+		line += n
+		sb.WriteString(replacement)
+		// The lines ended by the newlines of the replacement keep their sources; the rest of the matched lines are gone
+		kept = append(kept, lines[line:line+strings.Count(replacement, "\n")]...)
+		line += strings.Count(s[m[0]:m[1]], "\n")
+		last = m[1]
+	}
+	sb.WriteString(s[last:])
+	kept = append(kept, lines[min(line, len(lines)):]...)
+	return sb.String(), kept
 }
 
 type Parser struct {
-	attributes []asciidoc.AttributeName
+	attributes     []asciidoc.AttributeName
+	expandIncludes bool
 }
 
-func NewParser(attributes []asciidoc.AttributeName) Parser {
-	return Parser{attributes: attributes}
+type ParserOption func(p *Parser)
+
+// ExpandIncludes parses each document with the contents of its included files expanded in place, as ParseFileWithIncludes does
+func ExpandIncludes(expand bool) ParserOption {
+	return func(p *Parser) {
+		p.expandIncludes = expand
+	}
+}
+
+func NewParser(attributes []asciidoc.AttributeName, options ...ParserOption) Parser {
+	p := Parser{attributes: attributes}
+	for _, o := range options {
+		o(&p)
+	}
+	return p
 }
 
 func (p Parser) Name() string {
@@ -88,7 +185,11 @@ func (p Parser) Type() pipeline.ProcessorType {
 
 func (p Parser) Process(cxt context.Context, input *pipeline.Data[struct{}], index int32, total int32) (outputs []*pipeline.Data[*Doc], extras []*pipeline.Data[struct{}], err error) {
 	var doc *Doc
-	doc, err = ParseFile(input.Path, p.attributes...)
+	if p.expandIncludes {
+		doc, err = ParseFileWithIncludes(input.Path, p.attributes...)
+	} else {
+		doc, err = ParseFile(input.Path, p.attributes...)
+	}
 	if err != nil {
 		return
 	}
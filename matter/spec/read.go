@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/project-chip/alchemy/asciidoc/parse"
 	"github.com/project-chip/alchemy/internal/pipeline"
@@ -12,7 +11,7 @@ import (
 
 func ReadFile(path string) (*Doc, error) {
 
-	contents, err := os.Open(path)
+	contents, err := OpenFile(path)
 	if err != nil {
 		return nil, err
 	}
@@ -144,7 +144,13 @@ func AssignSectionTypes(doc *Doc, top *Section) error {
 			return parse.SearchShouldContinue
 		}
 
-		section.SecType = getSectionType(ps, section)
+		section.SecType = matter.SectionUnknown
+		if doc.expanded && section.Base.Path() != ps.Base.Path() {
+			section.SecType = includedSectionType(section)
+		}
+		if section.SecType == matter.SectionUnknown {
+			section.SecType = getSectionType(ps, section)
+		}
 		switch section.SecType {
 		case matter.SectionDataTypeBitmap, matter.SectionDataTypeEnum, matter.SectionDataTypeStruct:
 			if section.Base.Level > 2 {
@@ -157,6 +163,25 @@ func AssignSectionTypes(doc *Doc, top *Section) error {
 	return nil
 }
 
+// includedSectionType returns the type of a section that starts a file expanded into a doc, if that file is a cluster or device type
+func includedSectionType(section *Section) matter.Section {
+	docType, err := docTypeForPath(section.Base.Path(), func() matter.DocType { return matter.DocTypeUnknown })
+	if err != nil {
+		return matter.SectionUnknown
+	}
+	switch docType {
+	case matter.DocTypeCluster:
+		return matter.SectionCluster
+	case matter.DocTypeDeviceType:
+		return matter.SectionDeviceType
+	case matter.DocTypeUnknown:
+		if strings.HasSuffix(section.Name, " Cluster") {
+			return matter.SectionCluster
+		}
+	}
+	return matter.SectionUnknown
+}
+
 func FindSectionByType(top *Section, sectionType matter.Section) *Section {
 	var found *Section
 	parse.Search(top.Elements(), func(s *Section) parse.SearchShould {
@@ -443,6 +468,15 @@ func (s *Section) GetDataType() *types.DataType {
 func findLooseEntities(doc *Doc, section *Section, entityMap map[asciidoc.Attributable][]types.Entity) (entities []types.Entity, err error) {
 	parse.Traverse(doc, section.Elements(), func(section *Section, parent parse.HasElements, index int) parse.SearchShould {
 		switch section.SecType {
+		case matter.SectionCluster, matter.SectionDeviceType:
+			// Clusters and device types expanded from included files
+			var included []types.Entity
+			included, err = section.toEntities(doc, entityMap)
+			if err != nil {
+				return parse.SearchShouldStop
+			}
+			entities = append(entities, included...)
+			return parse.SearchShouldSkip
 		case matter.SectionDataTypeBitmap:
 			var bm *matter.Bitmap
 			bm, err = section.toBitmap(doc, entityMap)
@@ -14,11 +14,15 @@ func newSource(d *Doc, e asciidoc.Element) *source {
 }
 
 func (s *source) Origin() (path string, line int) {
+	path = s.doc.Path
 	if hp, ok := s.element.(asciidoc.HasPosition); ok {
 		line, _, _ = hp.Position()
+		// Elements expanded from an included file keep the path of that file
+		if p := hp.Path(); p != "" {
+			path = p
+		}
 	} else {
 		line = -1
 	}
-	path = s.doc.Path
 	return
 }
@@ -3,6 +3,7 @@ package spec
 import (
 	"context"
 	"io/fs"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -10,10 +11,13 @@ import (
 )
 
 func getSpecPaths(specRoot string) (paths []string, err error) {
-	srcRoot := filepath.Join(specRoot, "/src/")
-	err = filepath.WalkDir(srcRoot, func(path string, d fs.DirEntry, err error) error {
-		if filepath.Ext(path) == ".adoc" && !strings.HasSuffix(path, "-draft.adoc") {
-			paths = append(paths, path)
+	fsys, err := openSource(specRoot)
+	if err != nil {
+		return
+	}
+	err = fs.WalkDir(fsys, "src", func(p string, d fs.DirEntry, err error) error {
+		if path.Ext(p) == ".adoc" && !strings.HasSuffix(p, "-draft.adoc") {
+			paths = append(paths, filepath.Join(specRoot, filepath.FromSlash(p)))
 		}
 		return nil
 	})
@@ -27,20 +27,55 @@ func buildTree(docs []*Doc) {
 		})
 	}
 
+	links := make(map[[2]*Doc]struct{})
+	addLink := func(parent *Doc, child *Doc) {
+		if _, ok := links[[2]*Doc{parent, child}]; ok {
+			return
+		}
+		links[[2]*Doc{parent, child}] = struct{}{}
+		child.addParent(parent)
+		parent.addChild(child)
+	}
+
 	for doc, children := range tree {
 		for _, link := range children {
 			var p strings.Builder
 			doc.buildDataTypeString(link.Set, &p)
 			linkPath := filepath.Join(filepath.Dir(doc.Path), p.String())
 			if cd, ok := docPaths[linkPath]; ok {
-				cd.addParent(doc)
-				doc.addChild(cd)
+				addLink(doc, cd)
 			} else {
 				slog.Warn("unknown child path", log.Element("parent", doc.Path, link), "child", linkPath)
 			}
 		}
 	}
 
+	// Docs parsed with their includes expanded have no include elements left, so they're linked by the includes recorded while parsing
+	for _, doc := range docs {
+		for from, included := range doc.includes {
+			parent, ok := docPaths[from]
+			if !ok {
+				continue
+			}
+			for _, to := range included {
+				if cd, ok := docPaths[to]; ok {
+					addLink(parent, cd)
+				}
+			}
+		}
+	}
+
+}
+
+// includedByExpansion returns true if a doc's contents were expanded into a doc that includes it, so
+// they're built from there rather than from the doc itself
+func includedByExpansion(doc *Doc) bool {
+	for _, p := range doc.Parents() {
+		if p.expanded {
+			return true
+		}
+	}
+	return false
 }
 
 func dumpTree(r *Doc, indent int) {
@@ -15,11 +15,16 @@ func (doc *Doc) DocType() (matter.DocType, error) {
 	if doc.docType != matter.DocTypeUnknown {
 		return doc.docType, nil
 	}
-	if len(doc.Path) == 0 {
+	return docTypeForPath(doc.Path, doc.guessDocType)
+}
+
+// docTypeForPath determines the type of the doc at a path, falling back to guess for directories that hold more than one type
+func docTypeForPath(docPath string, guess func() matter.DocType) (matter.DocType, error) {
+	if len(docPath) == 0 {
 		return matter.DocTypeUnknown, fmt.Errorf("missing path")
 	}
 
-	switch filepath.Base(doc.Path) {
+	switch filepath.Base(docPath) {
 	case "appclusters.adoc":
 		return matter.DocTypeAppClusters, nil
 	case "standard_namespaces.adoc":
@@ -28,7 +33,7 @@ func (doc *Doc) DocType() (matter.DocType, error) {
 		return matter.DocTypeDeviceTypes, nil
 	}
 
-	path, err := filepath.Abs(doc.Path)
+	path, err := filepath.Abs(docPath)
 	if err != nil {
 		return matter.DocTypeUnknown, err
 	}
@@ -50,7 +55,7 @@ func (doc *Doc) DocType() (matter.DocType, error) {
 			if strings.Contains(strings.ToLower(name), "cluster") {
 				return matter.DocTypeCluster, nil
 			}
-			if dt := doc.guessDocType(); dt != matter.DocTypeUnknown {
+			if dt := guess(); dt != matter.DocTypeUnknown {
 				return dt, nil
 			}
 			return matter.DocTypeDataModel, nil
@@ -76,7 +81,7 @@ func (doc *Doc) DocType() (matter.DocType, error) {
 			if strings.Contains(strings.ToLower(name), "cluster") {
 				return matter.DocTypeCluster, nil
 			}
-			if dt := doc.guessDocType(); dt != matter.DocTypeUnknown {
+			if dt := guess(); dt != matter.DocTypeUnknown {
 				return dt, nil
 			}
 			return matter.DocTypeServiceDeviceManagement, nil
@@ -84,7 +89,7 @@ func (doc *Doc) DocType() (matter.DocType, error) {
 			return matter.DocTypeSoftAP, nil
 		}
 	}
-	slog.Debug("could not determine doc type", "path", doc.Path)
+	slog.Debug("could not determine doc type", "path", docPath)
 	return matter.DocTypeUnknown, nil
 }
 
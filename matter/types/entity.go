@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/json"
+	"fmt"
 )
 
 type EntityType uint8
@@ -59,6 +60,20 @@ func (et EntityType) MarshalJSON() ([]byte, error) {
 	return json.Marshal(entityTypeNames[et])
 }
 
+func (et *EntityType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	for t, name := range entityTypeNames {
+		if name == s {
+			*et = t
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown entity type: %s", s)
+}
+
 type EntityStore interface {
 	Entities() ([]Entity, error)
 }
@@ -0,0 +1,287 @@
+package predicate
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// Dialect holds the syntax a language uses for the parts of a compiled expression; the cluster passed to its
+// functions is the one whose conformance is being compiled
+type Dialect struct {
+	And   string
+	Or    string
+	Not   string
+	True  func(cluster *matter.Cluster) string
+	False func(cluster *matter.Cluster) string
+	// Equal checks that two conditions have the same value, or with not set that they differ
+	Equal func(left string, right string, not bool) string
+	// Feature checks that a feature is enabled; an empty result is never true
+	Feature func(cluster *matter.Cluster, feature *matter.Feature, mask uint64) string
+	// Element checks that an element is implemented; entity is nil if the cluster doesn't define the name, and an
+	// empty result is never true
+	Element func(cluster *matter.Cluster, name string, entity types.Entity) string
+}
+
+// Constant returns a dialect function for a condition that doesn't depend on the cluster
+func Constant(s string) func(cluster *matter.Cluster) string {
+	return func(cluster *matter.Cluster) string {
+		return s
+	}
+}
+
+type state uint8
+
+const (
+	stateMandatory state = iota
+	stateOptional
+	stateProvisional
+	stateDeprecated
+	stateDisallowed
+	stateDescribed
+)
+
+// branch is one entry of a conformance set; a branch without a condition always applies
+type branch struct {
+	condition conformance.Expression
+	state     state
+	choice    *conformance.Choice
+}
+
+type element struct {
+	entity      types.Entity
+	entityType  types.EntityType
+	name        string
+	code        string
+	conformance conformance.Set
+	branches    []*branch
+}
+
+type choiceGroup struct {
+	entityType types.EntityType
+	set        string
+	limit      conformance.ChoiceLimit
+	members    []*choiceMember
+}
+
+type choiceMember struct {
+	element *element
+	index   int
+}
+
+type clusterPredicates struct {
+	cluster  *matter.Cluster
+	elements []*element
+	choices  []*choiceGroup
+}
+
+func buildClusterPredicates(cluster *matter.Cluster) *clusterPredicates {
+	cp := &clusterPredicates{cluster: cluster}
+	if cluster.Features != nil {
+		for _, b := range cluster.Features.Bits {
+			f, ok := b.(*matter.Feature)
+			if !ok {
+				continue
+			}
+			cp.addElement(f, types.EntityTypeFeature, f.Name(), f.Code, f.Conformance())
+		}
+	}
+	for _, a := range cluster.Attributes {
+		if conformance.IsZigbee(cluster.Attributes, a.Conformance) {
+			continue
+		}
+		cp.addElement(a, types.EntityTypeAttribute, a.Name, "", a.Conformance)
+	}
+	for _, c := range cluster.Commands {
+		if conformance.IsZigbee(cluster.Commands, c.Conformance) {
+			continue
+		}
+		cp.addElement(c, types.EntityTypeCommand, c.Name, "", c.Conformance)
+	}
+	for _, e := range cluster.Events {
+		if conformance.IsZigbee(cluster.Events, e.Conformance) {
+			continue
+		}
+		cp.addElement(e, types.EntityTypeEvent, e.Name, "", e.Conformance)
+	}
+	return cp
+}
+
+func (cp *clusterPredicates) addElement(entity types.Entity, entityType types.EntityType, name string, code string, cs conformance.Set) {
+	if len(cs) == 0 {
+		return
+	}
+	e := &element{entity: entity, entityType: entityType, name: name, code: code, conformance: cs}
+	for _, c := range cs {
+		var b *branch
+		switch c := c.(type) {
+		case *conformance.Mandatory:
+			b = &branch{condition: c.Expression, state: stateMandatory}
+		case *conformance.Optional:
+			b = &branch{condition: c.Expression, state: stateOptional, choice: c.Choice}
+		case *conformance.Provisional:
+			b = &branch{state: stateProvisional}
+		case *conformance.Deprecated:
+			b = &branch{state: stateDeprecated}
+		case *conformance.Disallowed:
+			b = &branch{state: stateDisallowed}
+		default:
+			b = &branch{state: stateDescribed}
+		}
+		e.branches = append(e.branches, b)
+		if b.choice != nil {
+			cp.addChoiceMember(e, len(e.branches)-1)
+		}
+		if b.condition == nil {
+			// Nothing after an unconditional entry can apply
+			break
+		}
+	}
+	cp.elements = append(cp.elements, e)
+}
+
+func (cp *clusterPredicates) addChoiceMember(e *element, index int) {
+	choice := e.branches[index].choice
+	for _, cg := range cp.choices {
+		if cg.entityType == e.entityType && cg.set == choice.Set {
+			if cg.limit == nil {
+				cg.limit = choice.Limit
+			}
+			cg.members = append(cg.members, &choiceMember{element: e, index: index})
+			return
+		}
+	}
+	cp.choices = append(cp.choices, &choiceGroup{entityType: e.entityType, set: choice.Set, limit: choice.Limit, members: []*choiceMember{{element: e, index: index}}})
+}
+
+// Compile translates a conformance expression of a cluster into a condition in the given dialect; a nil expression is always true
+func Compile(d *Dialect, cluster *matter.Cluster, exp conformance.Expression) string {
+	switch exp := exp.(type) {
+	case nil:
+		return d.True(cluster)
+	case *conformance.FeatureExpression:
+		if _, ok := cluster.Identifier(exp.Feature); !ok {
+			// A feature the cluster doesn't define can never be enabled
+			slog.Debug("unknown feature in conformance expression", slog.String("cluster", cluster.Name), slog.String("feature", exp.Feature))
+			return negate(d, d.False(cluster), exp.Not)
+		}
+		return compileIdentifier(d, cluster, exp.Feature, exp.Not)
+	case *conformance.IdentifierExpression:
+		return compileIdentifier(d, cluster, exp.ID, exp.Not)
+	case *conformance.ReferenceExpression:
+		return negate(d, orFalse(d, cluster, d.Element(cluster, exp.Reference, nil)), exp.Not)
+	case *conformance.EqualityExpression:
+		return d.Equal(Compile(d, cluster, exp.Left), Compile(d, cluster, exp.Right), exp.Not)
+	case *conformance.LogicalExpression:
+		result := Compile(d, cluster, exp.Left)
+		if exp.Operand == "^" {
+			for _, r := range exp.Right {
+				result = d.Equal(result, Compile(d, cluster, r), true)
+			}
+			return negate(d, result, exp.Not)
+		}
+		operator := d.And
+		if exp.Operand == "|" {
+			operator = d.Or
+		}
+		terms := []string{result}
+		for _, r := range exp.Right {
+			terms = append(terms, Compile(d, cluster, r))
+		}
+		return negate(d, "("+strings.Join(terms, operator)+")", exp.Not)
+	default:
+		slog.Warn("unsupported conformance expression", slog.String("cluster", cluster.Name), slog.String("expression", exp.ASCIIDocString()))
+		return d.False(cluster)
+	}
+}
+
+func compileIdentifier(d *Dialect, cluster *matter.Cluster, id string, not bool) string {
+	entity, ok := cluster.Identifier(id)
+	if !ok {
+		return negate(d, orFalse(d, cluster, d.Element(cluster, id, nil)), not)
+	}
+	if f, ok := entity.(*matter.Feature); ok {
+		mask, err := f.Mask()
+		if err != nil {
+			slog.Warn("invalid feature bit in conformance expression", slog.String("cluster", cluster.Name), slog.String("feature", id), slog.Any("error", err))
+			return d.False(cluster)
+		}
+		return negate(d, orFalse(d, cluster, d.Feature(cluster, f, mask)), not)
+	}
+	return negate(d, orFalse(d, cluster, d.Element(cluster, id, entity)), not)
+}
+
+func orFalse(d *Dialect, cluster *matter.Cluster, s string) string {
+	if s == "" {
+		return d.False(cluster)
+	}
+	return s
+}
+
+// elementPresent checks whether an element is implemented; features are present when their bit is set in the feature map
+func (cp *clusterPredicates) elementPresent(d *Dialect, e *element) string {
+	if e.entityType == types.EntityTypeFeature {
+		return compileIdentifier(d, cp.cluster, e.code, false)
+	}
+	return orFalse(d, cp.cluster, d.Element(cp.cluster, e.name, e.entity))
+}
+
+// choiceCondition is true when a choice member's entry is the first in its conformance to apply; it's empty when that's always the case
+func (cp *clusterPredicates) choiceCondition(d *Dialect, m *choiceMember) string {
+	if m.index == 0 && m.element.branches[0].condition == nil {
+		return ""
+	}
+	var conditions []string
+	for _, b := range m.element.branches[:m.index] {
+		conditions = append(conditions, negate(d, Compile(d, cp.cluster, b.condition), true))
+	}
+	if condition := m.element.branches[m.index].condition; condition != nil {
+		conditions = append(conditions, Compile(d, cp.cluster, condition))
+	}
+	return strings.Join(conditions, d.And)
+}
+
+// conformanceComment is the ASCIIDoc form of a conformance without its table cell escaping
+func conformanceComment(cs conformance.Set) string {
+	return strings.ReplaceAll(cs.ASCIIDocString(), `\|`, "|")
+}
+
+func negate(d *Dialect, s string, not bool) string {
+	if !not {
+		return s
+	}
+	return d.Not + s
+}
+
+func choiceLimit(limit conformance.ChoiceLimit) (minimum int, maximum int) {
+	switch limit := limit.(type) {
+	case *conformance.ChoiceExactLimit:
+		return limit.Limit, limit.Limit
+	case *conformance.ChoiceMinLimit:
+		return limit.Min, -1
+	case *conformance.ChoiceMaxLimit:
+		return 0, limit.Max
+	case *conformance.ChoiceRangeLimit:
+		return limit.Min, limit.Max
+	default:
+		return 1, 1
+	}
+}
+
+func entityName(entityType types.EntityType) string {
+	switch entityType {
+	case types.EntityTypeFeature:
+		return "Feature"
+	case types.EntityTypeAttribute:
+		return "Attribute"
+	case types.EntityTypeCommand:
+		return "Command"
+	case types.EntityTypeEvent:
+		return "Event"
+	default:
+		return "Element"
+	}
+}
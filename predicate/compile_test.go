@@ -0,0 +1,109 @@
+package predicate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+)
+
+type predicateTest struct {
+	Name       string
+	Attributes map[string]string
+	CPP        []string
+	Python     []string
+}
+
+var predicateTests = []predicateTest{
+	{
+		Name:       "negated feature",
+		Attributes: map[string]string{"Alpha": "!AB"},
+		CPP: []string{
+			"inline State AttributeAlphaConformance(" + cppParameters + ")\n{\n    if (!((featureMap & 0x1u) != 0))\n    {\n        return State::kMandatory;\n    }\n    return State::kDisallowed;\n}\n",
+		},
+		Python: []string{
+			"def attribute_alpha_conformance(" + pythonParameters + ") -> State:\n    \"\"\"Attribute Alpha: !AB\"\"\"\n    if not ((feature_map & 0x1) != 0):\n        return State.MANDATORY\n    return State.DISALLOWED\n",
+		},
+	},
+	{
+		Name:       "negated feature expression",
+		Attributes: map[string]string{"Alpha": "!(AB | CD), O"},
+		CPP: []string{
+			"    if (!(((featureMap & 0x1u) != 0) || ((featureMap & 0x2u) != 0)))\n    {\n        return State::kMandatory;\n    }\n    return State::kOptional;\n",
+		},
+		Python: []string{
+			"    if not (((feature_map & 0x1) != 0) or ((feature_map & 0x2) != 0)):\n        return State.MANDATORY\n    return State.OPTIONAL\n",
+		},
+	},
+	{
+		Name:       "choice group",
+		Attributes: map[string]string{"Alpha": "O.a", "Beta": "AB, O.a"},
+		CPP: []string{
+			"// Attribute choice a: exactly 1 of Alpha, Beta\ninline bool IsAttributeChoiceASatisfied(" + cppParameters + ")\n{\n" +
+				"    int eligible = 0;\n    int present  = 0;\n" +
+				"    eligible++;\n    if (presentElements.count(\"Alpha\") != 0)\n    {\n        present++;\n    }\n" +
+				"    if (!((featureMap & 0x1u) != 0))\n    {\n        eligible++;\n        if (presentElements.count(\"Beta\") != 0)\n        {\n            present++;\n        }\n    }\n" +
+				"    return eligible == 0 || (present == 1);\n}\n",
+		},
+		Python: []string{
+			"def is_attribute_choice_a_satisfied(" + pythonParameters + ") -> bool:\n    \"\"\"Attribute choice a: exactly 1 of Alpha, Beta\"\"\"\n" +
+				"    eligible = 0\n    present = 0\n" +
+				"    eligible += 1\n    if (\"Alpha\" in present_elements):\n        present += 1\n" +
+				"    if not ((feature_map & 0x1) != 0):\n        eligible += 1\n        if (\"Beta\" in present_elements):\n            present += 1\n" +
+				"    return eligible == 0 or (present == 1)\n",
+		},
+	},
+	{
+		Name:       "choice group with limit",
+		Attributes: map[string]string{"Alpha": "O.b+", "Beta": "CD, O.b+"},
+		CPP: []string{
+			"inline bool IsAttributeChoiceBSatisfied(",
+			"    if (!((featureMap & 0x2u) != 0))\n",
+			"    return eligible == 0 || (present >= 1);\n}\n",
+		},
+		Python: []string{
+			"def is_attribute_choice_b_satisfied(",
+			"    if not ((feature_map & 0x2) != 0):\n",
+			"    return eligible == 0 or (present >= 1)\n",
+		},
+	},
+}
+
+func TestPredicates(t *testing.T) {
+	for _, pt := range predicateTests {
+		cp := buildClusterPredicates(predicateTestCluster(pt.Attributes))
+		cpp := renderCPP(cp)
+		for _, expected := range pt.CPP {
+			if !strings.Contains(cpp, expected) {
+				t.Errorf("%s: C++ predicates missing\n%s\ngot\n%s", pt.Name, expected, cpp)
+			}
+		}
+		python := renderPython(cp)
+		for _, expected := range pt.Python {
+			if !strings.Contains(python, expected) {
+				t.Errorf("%s: Python predicates missing\n%s\ngot\n%s", pt.Name, expected, python)
+			}
+		}
+	}
+}
+
+func predicateTestCluster(attributes map[string]string) *matter.Cluster {
+	c := &matter.Cluster{Name: "Test"}
+	c.Features = &matter.Features{}
+	c.Features.Bits = append(c.Features.Bits,
+		matter.NewFeature("0", "Alpha Feature", "AB", "", conformance.ParseConformance("O")),
+		matter.NewFeature("1", "Charlie Feature", "CD", "", conformance.ParseConformance("O")),
+	)
+	for _, name := range []string{"Alpha", "Beta"} {
+		cs, ok := attributes[name]
+		if !ok {
+			continue
+		}
+		a := matter.NewAttribute()
+		a.Name = name
+		a.Conformance = conformance.ParseConformance(cs)
+		c.Attributes = append(c.Attributes, a)
+	}
+	return c
+}
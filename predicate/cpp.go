@@ -0,0 +1,154 @@
+package predicate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+var cppDialect = &Dialect{
+	And:   " && ",
+	Or:    " || ",
+	Not:   "!",
+	True:  Constant("true"),
+	False: Constant("false"),
+	Equal: func(left string, right string, not bool) string {
+		if not {
+			return fmt.Sprintf("(%s != %s)", left, right)
+		}
+		return fmt.Sprintf("(%s == %s)", left, right)
+	},
+	Feature: func(cluster *matter.Cluster, feature *matter.Feature, mask uint64) string {
+		return fmt.Sprintf("((featureMap & 0x%Xu) != 0)", mask)
+	},
+	Element: func(cluster *matter.Cluster, name string, entity types.Entity) string {
+		return fmt.Sprintf("(presentElements.count(%q) != 0)", name)
+	},
+}
+
+var cppStates = map[state]string{
+	stateMandatory:   "State::kMandatory",
+	stateOptional:    "State::kOptional",
+	stateProvisional: "State::kProvisional",
+	stateDeprecated:  "State::kDeprecated",
+	stateDisallowed:  "State::kDisallowed",
+	stateDescribed:   "State::kDescribed",
+}
+
+const cppParameters = "[[maybe_unused]] uint32_t featureMap, [[maybe_unused]] const PresentElements & presentElements"
+
+func renderCPP(cp *clusterPredicates) string {
+	var b strings.Builder
+	b.WriteString(generatedHeader("//"))
+	b.WriteString("#pragma once\n\n#include <cstdint>\n#include <set>\n#include <string>\n\n")
+	clusterName := matter.Case(cp.cluster.Name)
+	b.WriteString("namespace chip {\nnamespace app {\nnamespace Clusters {\n")
+	fmt.Fprintf(&b, "namespace %s {\nnamespace Conformance {\n\n", clusterName)
+	b.WriteString("enum class State : uint8_t\n{\n    kMandatory,\n    kOptional,\n    kProvisional,\n    kDeprecated,\n    kDisallowed,\n    kDescribed,\n};\n\n")
+	b.WriteString("// The names of the attributes, commands and events the server implements\nusing PresentElements = std::set<std::string>;\n")
+	for _, e := range cp.elements {
+		name := entityName(e.entityType) + matter.Case(e.name)
+		fmt.Fprintf(&b, "\n// %s %s: %s\n", entityName(e.entityType), e.name, conformanceComment(e.conformance))
+		fmt.Fprintf(&b, "inline State %sConformance(%s)\n{\n", name, cppParameters)
+		unconditional := false
+		for _, br := range e.branches {
+			if br.condition == nil {
+				fmt.Fprintf(&b, "    return %s;\n", cppStates[br.state])
+				unconditional = true
+				break
+			}
+			fmt.Fprintf(&b, "    if %s\n    {\n        return %s;\n    }\n", wrap(Compile(cppDialect, cp.cluster, br.condition)), cppStates[br.state])
+		}
+		if !unconditional {
+			b.WriteString("    return State::kDisallowed;\n")
+		}
+		b.WriteString("}\n\n")
+		fmt.Fprintf(&b, "inline bool Is%sMandatory(%s)\n{\n    return %sConformance(featureMap, presentElements) == State::kMandatory;\n}\n\n", name, cppParameters, name)
+		fmt.Fprintf(&b, "inline bool Is%sAllowed(%s)\n{\n    return %sConformance(featureMap, presentElements) != State::kDisallowed;\n}\n", name, cppParameters, name)
+	}
+	for _, cg := range cp.choices {
+		minimum, maximum := choiceLimit(cg.limit)
+		fmt.Fprintf(&b, "\n// %s choice %s: %s\n", entityName(cg.entityType), cg.set, choiceDescription(cg))
+		fmt.Fprintf(&b, "inline bool Is%sChoice%sSatisfied(%s)\n{\n", entityName(cg.entityType), strings.ToUpper(cg.set), cppParameters)
+		b.WriteString("    int eligible = 0;\n    int present  = 0;\n")
+		for _, m := range cg.members {
+			present := wrap(cp.elementPresent(cppDialect, m.element))
+			condition := cp.choiceCondition(cppDialect, m)
+			if condition == "" {
+				fmt.Fprintf(&b, "    eligible++;\n    if %s\n    {\n        present++;\n    }\n", present)
+				continue
+			}
+			fmt.Fprintf(&b, "    if %s\n    {\n        eligible++;\n        if %s\n        {\n            present++;\n        }\n    }\n", wrap(condition), present)
+		}
+		fmt.Fprintf(&b, "    return eligible == 0 || (%s);\n}\n", limitCheck(minimum, maximum, " && "))
+	}
+	fmt.Fprintf(&b, "\n} // namespace Conformance\n} // namespace %s\n} // namespace Clusters\n} // namespace app\n} // namespace chip\n", clusterName)
+	return b.String()
+}
+
+// wrap parenthesizes a condition unless it already is
+func wrap(s string) string {
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") && balanced(s[1:len(s)-1]) {
+		return s
+	}
+	return "(" + s + ")"
+}
+
+func balanced(s string) bool {
+	var depth int
+	for _, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
+
+func limitCheck(minimum int, maximum int, and string) string {
+	var checks []string
+	if minimum == maximum {
+		return fmt.Sprintf("present == %d", minimum)
+	}
+	if minimum > 0 {
+		checks = append(checks, fmt.Sprintf("present >= %d", minimum))
+	}
+	if maximum >= 0 {
+		checks = append(checks, fmt.Sprintf("present <= %d", maximum))
+	}
+	if len(checks) == 0 {
+		return "present >= 0"
+	}
+	return strings.Join(checks, and)
+}
+
+func choiceDescription(cg *choiceGroup) string {
+	names := make([]string, 0, len(cg.members))
+	for _, m := range cg.members {
+		names = append(names, m.element.name)
+	}
+	minimum, maximum := choiceLimit(cg.limit)
+	var count string
+	switch {
+	case minimum == maximum:
+		count = fmt.Sprintf("exactly %d", minimum)
+	case maximum < 0:
+		count = fmt.Sprintf("at least %d", minimum)
+	case minimum <= 0:
+		count = fmt.Sprintf("at most %d", maximum)
+	default:
+		count = fmt.Sprintf("%d to %d", minimum, maximum)
+	}
+	return fmt.Sprintf("%s of %s", count, strings.Join(names, ", "))
+}
+
+func generatedHeader(comment string) string {
+	return fmt.Sprintf("%s Conformance predicates generated by alchemy from the Matter specification; do not edit.\n\n", comment)
+}
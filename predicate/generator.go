@@ -0,0 +1,70 @@
+package predicate
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+type Language string
+
+const (
+	LanguageCPP    Language = "cpp"
+	LanguagePython Language = "python"
+)
+
+type Generator struct {
+	root      string
+	languages []Language
+}
+
+func NewGenerator(root string, languages []Language) *Generator {
+	return &Generator{root: root, languages: languages}
+}
+
+func (g Generator) Name() string {
+	return "Generating conformance predicates"
+}
+
+func (g Generator) Type() pipeline.ProcessorType {
+	return pipeline.ProcessorTypeIndividual
+}
+
+func (g *Generator) Process(cxt context.Context, input *pipeline.Data[*spec.Doc], index int32, total int32) (outputs []*pipeline.Data[string], extras []*pipeline.Data[*spec.Doc], err error) {
+	var entities []types.Entity
+	entities, err = input.Content.Entities()
+	if err != nil {
+		return
+	}
+	var clusters []*matter.Cluster
+	for _, e := range entities {
+		switch e := e.(type) {
+		case *matter.ClusterGroup:
+			clusters = append(clusters, e.Clusters...)
+		case *matter.Cluster:
+			clusters = append(clusters, e)
+		}
+	}
+	for _, cluster := range clusters {
+		cp := buildClusterPredicates(cluster)
+		for _, language := range g.languages {
+			switch language {
+			case LanguageCPP:
+				path := filepath.Join(g.root, matter.Case(cluster.Name)+"Conformance.h")
+				outputs = append(outputs, pipeline.NewData(path, renderCPP(cp)))
+			case LanguagePython:
+				path := filepath.Join(g.root, pythonName(matter.Case(cluster.Name))+"_conformance.py")
+				outputs = append(outputs, pipeline.NewData(path, renderPython(cp)))
+			default:
+				err = fmt.Errorf("unknown predicate language: %s", language)
+				return
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,88 @@
+package predicate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+var pythonDialect = &Dialect{
+	And:   " and ",
+	Or:    " or ",
+	Not:   "not ",
+	True:  Constant("True"),
+	False: Constant("False"),
+	Equal: func(left string, right string, not bool) string {
+		if not {
+			return fmt.Sprintf("(%s != %s)", left, right)
+		}
+		return fmt.Sprintf("(%s == %s)", left, right)
+	},
+	Feature: func(cluster *matter.Cluster, feature *matter.Feature, mask uint64) string {
+		return fmt.Sprintf("((feature_map & 0x%X) != 0)", mask)
+	},
+	Element: func(cluster *matter.Cluster, name string, entity types.Entity) string {
+		return fmt.Sprintf("(%q in present_elements)", name)
+	},
+}
+
+var pythonStates = map[state]string{
+	stateMandatory:   "State.MANDATORY",
+	stateOptional:    "State.OPTIONAL",
+	stateProvisional: "State.PROVISIONAL",
+	stateDeprecated:  "State.DEPRECATED",
+	stateDisallowed:  "State.DISALLOWED",
+	stateDescribed:   "State.DESCRIBED",
+}
+
+const pythonParameters = "feature_map: int, present_elements: set[str]"
+
+func renderPython(cp *clusterPredicates) string {
+	var b strings.Builder
+	b.WriteString(generatedHeader("#"))
+	b.WriteString("import enum\n\n\n")
+	b.WriteString("class State(enum.Enum):\n    MANDATORY = enum.auto()\n    OPTIONAL = enum.auto()\n    PROVISIONAL = enum.auto()\n    DEPRECATED = enum.auto()\n    DISALLOWED = enum.auto()\n    DESCRIBED = enum.auto()\n")
+	for _, e := range cp.elements {
+		name := pythonName(entityName(e.entityType) + matter.Case(e.name))
+		fmt.Fprintf(&b, "\n\ndef %s_conformance(%s) -> State:\n", name, pythonParameters)
+		fmt.Fprintf(&b, "    \"\"\"%s %s: %s\"\"\"\n", entityName(e.entityType), e.name, strings.ReplaceAll(conformanceComment(e.conformance), `\`, `\\`))
+		unconditional := false
+		for _, br := range e.branches {
+			if br.condition == nil {
+				fmt.Fprintf(&b, "    return %s\n", pythonStates[br.state])
+				unconditional = true
+				break
+			}
+			fmt.Fprintf(&b, "    if %s:\n        return %s\n", Compile(pythonDialect, cp.cluster, br.condition), pythonStates[br.state])
+		}
+		if !unconditional {
+			b.WriteString("    return State.DISALLOWED\n")
+		}
+		fmt.Fprintf(&b, "\n\ndef is_%s_mandatory(%s) -> bool:\n    return %s_conformance(feature_map, present_elements) == State.MANDATORY\n", name, pythonParameters, name)
+		fmt.Fprintf(&b, "\n\ndef is_%s_allowed(%s) -> bool:\n    return %s_conformance(feature_map, present_elements) != State.DISALLOWED\n", name, pythonParameters, name)
+	}
+	for _, cg := range cp.choices {
+		minimum, maximum := choiceLimit(cg.limit)
+		fmt.Fprintf(&b, "\n\ndef is_%s_choice_%s_satisfied(%s) -> bool:\n", pythonName(entityName(cg.entityType)), strings.ToLower(cg.set), pythonParameters)
+		fmt.Fprintf(&b, "    \"\"\"%s choice %s: %s\"\"\"\n", entityName(cg.entityType), cg.set, choiceDescription(cg))
+		b.WriteString("    eligible = 0\n    present = 0\n")
+		for _, m := range cg.members {
+			present := cp.elementPresent(pythonDialect, m.element)
+			condition := cp.choiceCondition(pythonDialect, m)
+			if condition == "" {
+				fmt.Fprintf(&b, "    eligible += 1\n    if %s:\n        present += 1\n", present)
+				continue
+			}
+			fmt.Fprintf(&b, "    if %s:\n        eligible += 1\n        if %s:\n            present += 1\n", condition, present)
+		}
+		fmt.Fprintf(&b, "    return eligible == 0 or (%s)\n", limitCheck(minimum, maximum, " and "))
+	}
+	return b.String()
+}
+
+func pythonName(name string) string {
+	return strings.ToLower(strcase.ToSnake(name))
+}
@@ -0,0 +1,293 @@
+package refgraph
+
+import (
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func DocumentGraph(docs []*spec.Doc) *Graph {
+	g := newGraph()
+	for _, doc := range docs {
+		g.addNode(documentNode(doc))
+	}
+	for _, doc := range docs {
+		for _, parent := range doc.Parents() {
+			g.addNode(documentNode(parent))
+			g.addEdge(parent.Path, doc.Path, "include")
+		}
+		for id := range doc.CrossReferences() {
+			anchor := doc.FindAnchor(id)
+			if anchor == nil || anchor.Document == doc {
+				continue
+			}
+			g.addNode(documentNode(anchor.Document))
+			g.addEdge(doc.Path, anchor.Document.Path, "reference")
+		}
+	}
+	g.Cycles = findCycles(g, "include")
+	g.sort()
+	return g
+}
+
+func documentNode(doc *spec.Doc) *Node {
+	return &Node{ID: doc.Path, Label: filepath.Base(doc.Path), Kind: "document", Path: doc.Path}
+}
+
+type entityGraph struct {
+	*Graph
+
+	spec   *spec.Specification
+	owners map[types.Entity]*matter.Cluster
+	ids    map[types.Entity]string
+	taken  map[string]struct{}
+}
+
+func newEntityGraph(s *spec.Specification) *entityGraph {
+	eg := &entityGraph{Graph: newGraph(), spec: s, owners: s.DataTypeOwners(), ids: make(map[types.Entity]string), taken: make(map[string]struct{})}
+	// Data types are the entities most likely to share a name, so their IDs are assigned up front in a stable order,
+	// keeping the suffixes given to duplicates the same from run to run
+	dataTypes := make(map[types.Entity]struct{})
+	for e := range s.DocRefs {
+		if _, ok := spec.DataTypeEntityName(e); ok {
+			dataTypes[e] = struct{}{}
+		}
+	}
+	for e := range eg.owners {
+		dataTypes[e] = struct{}{}
+	}
+	for _, e := range eg.sortEntities(dataTypes) {
+		name, _ := spec.DataTypeEntityName(e)
+		eg.assign(e, name)
+	}
+	return eg
+}
+
+// sortEntities orders entities by the document they're defined in, then by name
+func (eg *entityGraph) sortEntities(entities map[types.Entity]struct{}) []types.Entity {
+	sorted := make([]types.Entity, 0, len(entities))
+	for e := range entities {
+		sorted = append(sorted, e)
+	}
+	slices.SortFunc(sorted, func(a, b types.Entity) int {
+		if c := strings.Compare(eg.spec.DocRefs[a], eg.spec.DocRefs[b]); c != 0 {
+			return c
+		}
+		return strings.Compare(eg.label(a, entityName(a)), eg.label(b, entityName(b)))
+	})
+	return sorted
+}
+
+func (eg *entityGraph) label(e types.Entity, name string) string {
+	if owner, ok := eg.owners[e]; ok {
+		return owner.Name + "." + name
+	}
+	return name
+}
+
+func (eg *entityGraph) assign(e types.Entity, name string) string {
+	if id, ok := eg.ids[e]; ok {
+		return id
+	}
+	kind := e.EntityType().String()
+	name = eg.label(e, name)
+	id := kind + ":" + name
+	// Different entities can share a name, e.g. identically named global types in different documents
+	for i := 2; ; i++ {
+		if _, ok := eg.taken[id]; !ok {
+			break
+		}
+		id = kind + ":" + name + "#" + strconv.Itoa(i)
+	}
+	eg.taken[id] = struct{}{}
+	eg.ids[e] = id
+	return id
+}
+
+func (eg *entityGraph) node(e types.Entity, name string) string {
+	id := eg.assign(e, name)
+	eg.addNode(&Node{ID: id, Label: eg.label(e, name), Kind: e.EntityType().String(), Path: eg.spec.DocRefs[e]})
+	return id
+}
+
+func (eg *entityGraph) clusters() []*matter.Cluster {
+	clusters := make([]*matter.Cluster, 0, len(eg.spec.ClustersByName))
+	for _, c := range eg.spec.ClustersByName {
+		clusters = append(clusters, c)
+	}
+	slices.SortFunc(clusters, func(a, b *matter.Cluster) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return clusters
+}
+
+func (eg *entityGraph) clusterNode(c *matter.Cluster) string {
+	return eg.node(c, c.Name)
+}
+
+func (eg *entityGraph) deviceTypeNode(dt *matter.DeviceType) string {
+	return eg.node(dt, dt.Name)
+}
+
+func (eg *entityGraph) addDeviceTypes() {
+	deviceTypes := make(map[types.Entity]struct{})
+	for e := range eg.spec.DocRefs {
+		if _, ok := e.(*matter.DeviceType); ok {
+			deviceTypes[e] = struct{}{}
+		}
+	}
+	sorted := eg.sortEntities(deviceTypes)
+	deviceTypesByName := make(map[string]*matter.DeviceType)
+	for _, e := range sorted {
+		dt := e.(*matter.DeviceType)
+		if _, ok := deviceTypesByName[dt.Name]; !ok {
+			deviceTypesByName[dt.Name] = dt
+		}
+	}
+	for _, e := range sorted {
+		dt := e.(*matter.DeviceType)
+		id := eg.deviceTypeNode(dt)
+		for _, cr := range dt.ClusterRequirements {
+			if cr.Cluster != nil {
+				eg.addEdge(id, eg.clusterNode(cr.Cluster), "requires")
+			}
+		}
+		if dt.Superset != "" {
+			if superset, ok := deviceTypesByName[dt.Superset]; ok {
+				eg.addEdge(id, eg.deviceTypeNode(superset), "superset")
+			}
+		}
+	}
+}
+
+func (eg *entityGraph) addHierarchy(c *matter.Cluster) {
+	if c.Hierarchy == "" || c.Hierarchy == "Base" {
+		return
+	}
+	if base, ok := eg.spec.ClustersByName[c.Hierarchy]; ok {
+		eg.addEdge(eg.clusterNode(c), eg.clusterNode(base), "derives")
+	}
+}
+
+func ClusterGraph(s *spec.Specification) *Graph {
+	eg := newEntityGraph(s)
+	for _, c := range eg.clusters() {
+		eg.clusterNode(c)
+		eg.addHierarchy(c)
+	}
+	for e, clusters := range s.ClusterRefs {
+		name, ok := spec.DataTypeEntityName(e)
+		if !ok {
+			continue
+		}
+		owner := eg.owners[e]
+		for c := range clusters {
+			switch owner {
+			case nil:
+				eg.addEdge(eg.clusterNode(c), eg.node(e, name), "uses")
+			case c:
+			default:
+				eg.addEdge(eg.clusterNode(c), eg.clusterNode(owner), "uses")
+			}
+		}
+	}
+	eg.addDeviceTypes()
+	eg.sort()
+	return eg.Graph
+}
+
+func EntityGraph(s *spec.Specification) *Graph {
+	eg := newEntityGraph(s)
+	for _, c := range eg.clusters() {
+		cid := eg.clusterNode(c)
+		eg.addHierarchy(c)
+		for _, bm := range c.Bitmaps {
+			eg.addEdge(cid, eg.node(bm, bm.Name), "defines")
+		}
+		for _, e := range c.Enums {
+			eg.addEdge(cid, eg.node(e, e.Name), "defines")
+		}
+		for _, st := range c.Structs {
+			eg.addEdge(cid, eg.node(st, st.Name), "defines")
+		}
+		for _, a := range c.Attributes {
+			aid := eg.node(a, c.Name+"."+a.Name)
+			eg.addEdge(cid, aid, "contains")
+			eg.addTypeEdges(aid, a.Type)
+		}
+		for _, cmd := range c.Commands {
+			cmdID := eg.node(cmd, c.Name+"."+cmd.Name)
+			eg.addEdge(cid, cmdID, "contains")
+			eg.addFieldEdges(cmdID, cmd.Fields)
+		}
+		for _, cmd := range c.Commands {
+			if cmd.Response == "" {
+				continue
+			}
+			for _, response := range c.Commands {
+				if response.Name == cmd.Response {
+					eg.addEdge(eg.ids[cmd], eg.ids[response], "response")
+				}
+			}
+		}
+		for _, ev := range c.Events {
+			evID := eg.node(ev, c.Name+"."+ev.Name)
+			eg.addEdge(cid, evID, "contains")
+			eg.addFieldEdges(evID, ev.Fields)
+		}
+	}
+	for e := range s.DocRefs {
+		if name, ok := spec.DataTypeEntityName(e); ok {
+			eg.node(e, name)
+		}
+	}
+	var structs []*matter.Struct
+	for e := range eg.ids {
+		if st, ok := e.(*matter.Struct); ok {
+			structs = append(structs, st)
+		}
+	}
+	for _, st := range structs {
+		eg.addFieldEdges(eg.ids[st], st.Fields)
+	}
+	eg.addDeviceTypes()
+	eg.sort()
+	return eg.Graph
+}
+
+func (eg *entityGraph) addFieldEdges(from string, fields matter.FieldSet) {
+	for _, f := range fields {
+		eg.addTypeEdges(from, f.Type)
+	}
+}
+
+func (eg *entityGraph) addTypeEdges(from string, dt *types.DataType) {
+	if dt == nil {
+		return
+	}
+	if dt.EntryType != nil {
+		eg.addTypeEdges(from, dt.EntryType)
+	}
+	if dt.Entity == nil {
+		return
+	}
+	name, ok := spec.DataTypeEntityName(dt.Entity)
+	if !ok {
+		return
+	}
+	eg.addEdge(from, eg.node(dt.Entity, name), "uses")
+}
+
+func entityName(e types.Entity) string {
+	switch e := e.(type) {
+	case *matter.DeviceType:
+		return e.Name
+	}
+	name, _ := spec.DataTypeEntityName(e)
+	return name
+}
@@ -0,0 +1,56 @@
+package refgraph
+
+import (
+	"slices"
+)
+
+// findCycles returns each cycle formed by edges of the given kind, starting and ending at the same node
+func findCycles(g *Graph, kind string) (cycles [][]string) {
+	outgoing := make(map[string][]string)
+	for _, e := range g.Edges {
+		if e.Kind == kind {
+			outgoing[e.From] = append(outgoing[e.From], e.To)
+		}
+	}
+	for _, targets := range outgoing {
+		slices.Sort(targets)
+	}
+	var ids []string
+	for id := range outgoing {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var stack []string
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = visiting
+		stack = append(stack, id)
+		for _, to := range outgoing[id] {
+			switch state[to] {
+			case unvisited:
+				visit(to)
+			case visiting:
+				start := slices.Index(stack, to)
+				cycle := make([]string, 0, len(stack)-start+1)
+				cycle = append(cycle, stack[start:]...)
+				cycle = append(cycle, to)
+				cycles = append(cycles, cycle)
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = visited
+	}
+	for _, id := range ids {
+		if state[id] == unvisited {
+			visit(id)
+		}
+	}
+	return
+}
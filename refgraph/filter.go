@@ -0,0 +1,105 @@
+package refgraph
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+type Direction uint8
+
+const (
+	DirectionBoth Direction = iota
+	DirectionDependents
+	DirectionDependencies
+)
+
+func ParseDirection(s string) (Direction, error) {
+	switch s {
+	case "", "both":
+		return DirectionBoth, nil
+	case "dependents", "in":
+		return DirectionDependents, nil
+	case "dependencies", "out":
+		return DirectionDependencies, nil
+	}
+	return DirectionBoth, fmt.Errorf("unknown direction: %s", s)
+}
+
+type Filter struct {
+	// Focus matches the IDs or labels of the nodes to start from; if nil, the whole graph is kept
+	Focus *regexp.Regexp
+	// Depth limits how many edges away from a focused node are kept; zero or less is unlimited
+	Depth     int
+	Direction Direction
+	Kinds     []string
+}
+
+func (g *Graph) Filter(f Filter) *Graph {
+	keep := make(map[string]struct{})
+	if f.Focus == nil {
+		for _, n := range g.Nodes {
+			keep[n.ID] = struct{}{}
+		}
+	} else {
+		outgoing := make(map[string][]string)
+		incoming := make(map[string][]string)
+		for _, e := range g.Edges {
+			outgoing[e.From] = append(outgoing[e.From], e.To)
+			incoming[e.To] = append(incoming[e.To], e.From)
+		}
+		var queue []string
+		for _, n := range g.Nodes {
+			if f.Focus.MatchString(n.ID) || f.Focus.MatchString(n.Label) {
+				keep[n.ID] = struct{}{}
+				queue = append(queue, n.ID)
+			}
+		}
+		for depth := 1; len(queue) > 0 && (f.Depth <= 0 || depth <= f.Depth); depth++ {
+			var next []string
+			for _, id := range queue {
+				var neighbors []string
+				if f.Direction != DirectionDependencies {
+					neighbors = append(neighbors, incoming[id]...)
+				}
+				if f.Direction != DirectionDependents {
+					neighbors = append(neighbors, outgoing[id]...)
+				}
+				for _, n := range neighbors {
+					if _, ok := keep[n]; !ok {
+						keep[n] = struct{}{}
+						next = append(next, n)
+					}
+				}
+			}
+			queue = next
+		}
+	}
+	if len(f.Kinds) > 0 {
+		for _, n := range g.Nodes {
+			if !slices.Contains(f.Kinds, n.Kind) {
+				delete(keep, n.ID)
+			}
+		}
+	}
+
+	fg := newGraph()
+	for _, n := range g.Nodes {
+		if _, ok := keep[n.ID]; ok {
+			fg.addNode(n)
+		}
+	}
+	for _, e := range g.Edges {
+		_, from := keep[e.From]
+		_, to := keep[e.To]
+		if from && to {
+			fg.addEdge(e.From, e.To, e.Kind)
+		}
+	}
+	for _, cycle := range g.Cycles {
+		if slices.ContainsFunc(cycle, func(id string) bool { _, ok := keep[id]; return ok }) {
+			fg.Cycles = append(fg.Cycles, cycle)
+		}
+	}
+	return fg
+}
@@ -0,0 +1,133 @@
+package refgraph
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type Format uint8
+
+const (
+	FormatJSON Format = iota
+	FormatDOT
+	FormatGraphML
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "json":
+		return FormatJSON, nil
+	case "dot", "graphviz":
+		return FormatDOT, nil
+	case "graphml":
+		return FormatGraphML, nil
+	}
+	return FormatJSON, fmt.Errorf("unknown graph format: %s", s)
+}
+
+func (g *Graph) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatDOT:
+		return g.writeDOT(w)
+	case FormatGraphML:
+		return g.writeGraphML(w)
+	default:
+		jm := json.NewEncoder(w)
+		jm.SetIndent("", "\t")
+		return jm.Encode(g)
+	}
+}
+
+func (g *Graph) writeDOT(w io.Writer) (err error) {
+	var b strings.Builder
+	b.WriteString("digraph spec {\n")
+	b.WriteString("\trankdir=LR;\n")
+	for _, cycle := range g.Cycles {
+		b.WriteString(fmt.Sprintf("\t// cycle: %s\n", strings.Join(cycle, " -> ")))
+	}
+	for _, n := range g.Nodes {
+		b.WriteString(fmt.Sprintf("\t%s [label=%s, class=%s];\n", strconv.Quote(n.ID), strconv.Quote(n.Label), strconv.Quote(n.Kind)))
+	}
+	for _, e := range g.Edges {
+		b.WriteString(fmt.Sprintf("\t%s -> %s [label=%s];\n", strconv.Quote(e.From), strconv.Quote(e.To), strconv.Quote(e.Kind)))
+	}
+	b.WriteString("}\n")
+	_, err = io.WriteString(w, b.String())
+	return
+}
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+func (g *Graph) writeGraphML(w io.Writer) (err error) {
+	gml := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "label", For: "node", Name: "label", Type: "string"},
+			{ID: "kind", For: "node", Name: "kind", Type: "string"},
+			{ID: "path", For: "node", Name: "path", Type: "string"},
+			{ID: "edgeKind", For: "edge", Name: "kind", Type: "string"},
+		},
+		Graph: graphMLGraph{ID: "spec", EdgeDefault: "directed"},
+	}
+	for _, n := range g.Nodes {
+		node := graphMLNode{ID: n.ID, Data: []graphMLData{{Key: "label", Value: n.Label}, {Key: "kind", Value: n.Kind}}}
+		if n.Path != "" {
+			node.Data = append(node.Data, graphMLData{Key: "path", Value: n.Path})
+		}
+		gml.Graph.Nodes = append(gml.Graph.Nodes, node)
+	}
+	for _, e := range g.Edges {
+		gml.Graph.Edges = append(gml.Graph.Edges, graphMLEdge{Source: e.From, Target: e.To, Data: []graphMLData{{Key: "edgeKind", Value: e.Kind}}})
+	}
+	_, err = io.WriteString(w, xml.Header)
+	if err != nil {
+		return
+	}
+	x := xml.NewEncoder(w)
+	x.Indent("", "  ")
+	err = x.Encode(gml)
+	if err != nil {
+		return
+	}
+	_, err = io.WriteString(w, "\n")
+	return
+}
@@ -0,0 +1,93 @@
+package refgraph
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type Granularity uint8
+
+const (
+	GranularityUnknown Granularity = iota
+	GranularityDocument
+	GranularityCluster
+	GranularityEntity
+)
+
+var granularityNames = map[string]Granularity{
+	"document": GranularityDocument,
+	"doc":      GranularityDocument,
+	"cluster":  GranularityCluster,
+	"entity":   GranularityEntity,
+}
+
+func ParseGranularity(s string) (Granularity, error) {
+	g, ok := granularityNames[strings.ToLower(s)]
+	if !ok {
+		return GranularityUnknown, fmt.Errorf("unknown granularity: %s", s)
+	}
+	return g, nil
+}
+
+type Node struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+	Kind  string `json:"kind"`
+	Path  string `json:"path,omitempty"`
+}
+
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"`
+}
+
+type Graph struct {
+	Nodes  []*Node    `json:"nodes"`
+	Edges  []*Edge    `json:"edges"`
+	Cycles [][]string `json:"cycles,omitempty"`
+
+	nodes map[string]*Node
+	edges map[Edge]struct{}
+}
+
+func newGraph() *Graph {
+	return &Graph{nodes: make(map[string]*Node), edges: make(map[Edge]struct{})}
+}
+
+func (g *Graph) addNode(n *Node) *Node {
+	if existing, ok := g.nodes[n.ID]; ok {
+		return existing
+	}
+	g.nodes[n.ID] = n
+	g.Nodes = append(g.Nodes, n)
+	return n
+}
+
+func (g *Graph) addEdge(from string, to string, kind string) {
+	if from == to {
+		return
+	}
+	e := Edge{From: from, To: to, Kind: kind}
+	if _, ok := g.edges[e]; ok {
+		return
+	}
+	g.edges[e] = struct{}{}
+	g.Edges = append(g.Edges, &e)
+}
+
+func (g *Graph) sort() {
+	slices.SortFunc(g.Nodes, func(a, b *Node) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	slices.SortFunc(g.Edges, func(a, b *Edge) int {
+		if c := strings.Compare(a.From, b.From); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.To, b.To); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Kind, b.Kind)
+	})
+}
@@ -0,0 +1,100 @@
+package sizing
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// DefaultMessageLimit is the largest application payload that fits in a single, unfragmented Matter message
+const DefaultMessageLimit = 1200
+
+type Element struct {
+	Cluster      string           `json:"cluster"`
+	ClusterID    string           `json:"clusterId,omitempty"`
+	Type         types.EntityType `json:"type"`
+	Direction    string           `json:"direction,omitempty"`
+	ID           string           `json:"id,omitempty"`
+	Name         string           `json:"name"`
+	Size         Size             `json:"size"`
+	LargeMessage bool             `json:"largeMessage,omitempty"`
+	ExceedsLimit bool             `json:"exceedsLimit,omitempty"`
+}
+
+func (e *Element) Unbounded() bool {
+	return !e.Size.IsBounded()
+}
+
+type Report struct {
+	Limit    int        `json:"limit"`
+	Elements []*Element `json:"elements"`
+}
+
+func Analyze(clusters []*matter.Cluster, limit int) *Report {
+	if limit <= 0 {
+		limit = DefaultMessageLimit
+	}
+	r := &Report{Limit: limit}
+	for _, cluster := range clusters {
+		r.Elements = append(r.Elements, analyzeCluster(cluster, limit)...)
+	}
+	slices.SortStableFunc(r.Elements, func(a, b *Element) int {
+		return strings.Compare(a.Cluster, b.Cluster)
+	})
+	return r
+}
+
+func analyzeCluster(cluster *matter.Cluster, limit int) (elements []*Element) {
+	c := newCalculator()
+	for _, a := range cluster.Attributes {
+		if conformance.IsZigbee(cluster.Attributes, a.Conformance) || conformance.IsDisallowed(a.Conformance) {
+			continue
+		}
+		e := newElement(cluster, types.EntityTypeAttribute, a.ID, a.Name)
+		e.Size = c.fieldSize(a.Name, a, cluster.Attributes)
+		e.LargeMessage = a.Quality.Has(matter.QualityLargeMessage)
+		elements = append(elements, e)
+	}
+	for _, cmd := range cluster.Commands {
+		if conformance.IsZigbee(cluster.Commands, cmd.Conformance) || conformance.IsDisallowed(cmd.Conformance) {
+			continue
+		}
+		e := newElement(cluster, types.EntityTypeCommand, cmd.ID, cmd.Name)
+		switch cmd.Direction {
+		case matter.InterfaceServer:
+			e.Direction = "request"
+		case matter.InterfaceClient:
+			e.Direction = "response"
+		}
+		e.Size = c.fieldsSize(cmd.Name, cmd.Fields)
+		e.LargeMessage = cmd.Quality.Has(matter.QualityLargeMessage)
+		elements = append(elements, e)
+	}
+	for _, ev := range cluster.Events {
+		if conformance.IsZigbee(cluster.Events, ev.Conformance) || conformance.IsDisallowed(ev.Conformance) {
+			continue
+		}
+		e := newElement(cluster, types.EntityTypeEvent, ev.ID, ev.Name)
+		e.Size = c.fieldsSize(ev.Name, ev.Fields)
+		e.LargeMessage = ev.Quality.Has(matter.QualityLargeMessage)
+		elements = append(elements, e)
+	}
+	for _, e := range elements {
+		e.ExceedsLimit = e.Size.Bytes > limit
+	}
+	return
+}
+
+func newElement(cluster *matter.Cluster, entityType types.EntityType, id *matter.Number, name string) *Element {
+	e := &Element{Cluster: cluster.Name, Type: entityType, Name: name}
+	if cluster.ID.Valid() {
+		e.ClusterID = cluster.ID.HexString()
+	}
+	if id.Valid() {
+		e.ID = id.HexString()
+	}
+	return e
+}
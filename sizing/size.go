@@ -0,0 +1,179 @@
+package sizing
+
+import (
+	"fmt"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+const (
+	controlOctetSize    = 1
+	contextTagSize      = 1
+	endOfContainerSize  = 1
+	defaultLengthPrefix = 1
+)
+
+type Size struct {
+	Bytes     int      `json:"bytes"`
+	Unbounded []string `json:"unbounded,omitempty"`
+}
+
+func (s *Size) add(o Size) {
+	s.Bytes += o.Bytes
+	s.Unbounded = append(s.Unbounded, o.Unbounded...)
+}
+
+func (s *Size) IsBounded() bool {
+	return len(s.Unbounded) == 0
+}
+
+type calculator struct {
+	visiting map[*matter.Struct]struct{}
+}
+
+func newCalculator() *calculator {
+	return &calculator{visiting: make(map[*matter.Struct]struct{})}
+}
+
+// fieldsSize returns the size of a TLV structure containing the given fields, including the control octet, the context tag and the end of container marker
+func (c *calculator) fieldsSize(path string, fields matter.FieldSet) (size Size) {
+	size.Bytes = controlOctetSize + contextTagSize + endOfContainerSize
+	for _, f := range fields {
+		if f.Type == nil {
+			continue
+		}
+		size.add(c.fieldSize(path+"."+f.Name, f, fields))
+	}
+	return
+}
+
+// Optional fields are assumed to be present, and null values are encoded entirely in the control octet, so neither adds to the worst case
+func (c *calculator) fieldSize(path string, field *matter.Field, fields matter.FieldSet) (size Size) {
+	cc := &matter.ConstraintContext{Field: field, Fields: fields}
+	size = c.valueSize(path, field.Type, field.Constraint, cc)
+	size.Bytes += controlOctetSize + contextTagSize
+	return
+}
+
+// valueSize returns the size of the value portion of a TLV element; control octets and tags are accounted for by the caller
+func (c *calculator) valueSize(path string, dt *types.DataType, cons constraint.Constraint, cc constraint.Context) (size Size) {
+	if dt == nil {
+		size.Unbounded = append(size.Unbounded, fmt.Sprintf("%s: missing data type", path))
+		return
+	}
+	switch dt.BaseType {
+	case types.BaseDataTypeBoolean:
+		// Booleans are encoded entirely in the control octet
+		return
+	case types.BaseDataTypeSingle:
+		size.Bytes = 4
+		return
+	case types.BaseDataTypeDouble:
+		size.Bytes = 8
+		return
+	case types.BaseDataTypeString, types.BaseDataTypeOctStr:
+		var entryConstraint constraint.Constraint = cons
+		if lc, ok := cons.(*constraint.ListConstraint); ok {
+			entryConstraint = lc.EntryConstraint
+		}
+		length, ok := maxLength(entryConstraint, cc)
+		if !ok {
+			size.Bytes = defaultLengthPrefix
+			size.Unbounded = append(size.Unbounded, fmt.Sprintf("%s: %s has no maximum length", path, dt.Name))
+			return
+		}
+		size.Bytes = lengthPrefixSize(length) + length
+		return
+	case types.BaseDataTypeList:
+		return c.listSize(path, dt, cons, cc)
+	case types.BaseDataTypeCustom:
+		switch entity := dt.Entity.(type) {
+		case *matter.Struct:
+			if _, ok := c.visiting[entity]; ok {
+				size.Unbounded = append(size.Unbounded, fmt.Sprintf("%s: %s is recursive", path, entity.Name))
+				return
+			}
+			c.visiting[entity] = struct{}{}
+			size = c.fieldsSize(path, entity.Fields)
+			delete(c.visiting, entity)
+			// The control octet and tag are added by the caller
+			size.Bytes -= controlOctetSize + contextTagSize
+			return
+		case nil:
+			size.Unbounded = append(size.Unbounded, fmt.Sprintf("%s: unresolved data type %s", path, dt.Name))
+			return
+		}
+	}
+	fixed := dt.Size()
+	if fixed == 0 {
+		size.Unbounded = append(size.Unbounded, fmt.Sprintf("%s: unknown size for data type %s", path, dt.Name))
+		return
+	}
+	size.Bytes = integerSize(fixed)
+	return
+}
+
+func (c *calculator) listSize(path string, dt *types.DataType, cons constraint.Constraint, cc constraint.Context) (size Size) {
+	size.Bytes = endOfContainerSize
+	var countConstraint constraint.Constraint = cons
+	var entryConstraint constraint.Constraint
+	if lc, ok := cons.(*constraint.ListConstraint); ok {
+		countConstraint = lc.Constraint
+		entryConstraint = lc.EntryConstraint
+	}
+	entry := c.valueSize(path+"[]", dt.EntryType, entryConstraint, cc)
+	// List entries are anonymous, so they carry a control octet but no tag
+	entry.Bytes += controlOctetSize
+	count, ok := maxLength(countConstraint, cc)
+	if !ok {
+		size.Unbounded = append(size.Unbounded, fmt.Sprintf("%s: list has no maximum count", path))
+		count = 1
+	}
+	size.Bytes += entry.Bytes * count
+	size.Unbounded = append(size.Unbounded, entry.Unbounded...)
+	return
+}
+
+func maxLength(cons constraint.Constraint, cc constraint.Context) (int, bool) {
+	if cons == nil || constraint.IsBlank(cons) {
+		return 0, false
+	}
+	max := cons.Max(cc)
+	switch max.Type {
+	case types.DataTypeExtremeTypeInt64:
+		if max.Int64 < 0 {
+			return 0, false
+		}
+		return int(max.Int64), true
+	case types.DataTypeExtremeTypeUInt64:
+		return int(max.UInt64), true
+	}
+	return 0, false
+}
+
+// integerSize rounds a fixed size up to the nearest width TLV can encode an integer in; anything wider is encoded as an octet string
+func integerSize(size int) int {
+	switch {
+	case size <= 1:
+		return 1
+	case size <= 2:
+		return 2
+	case size <= 4:
+		return 4
+	case size <= 8:
+		return 8
+	}
+	return lengthPrefixSize(size) + size
+}
+
+func lengthPrefixSize(length int) int {
+	switch {
+	case length <= 0xFF:
+		return 1
+	case length <= 0xFFFF:
+		return 2
+	}
+	return 4
+}
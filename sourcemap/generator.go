@@ -0,0 +1,29 @@
+package sourcemap
+
+import (
+	"github.com/beevik/etree"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// Generator locates the spec entities which renderers record as the sources of the ZAP templates and data model XML they generate
+type Generator struct {
+	spec *spec.Specification
+	docs map[string]*spec.Doc
+}
+
+func NewGenerator(s *spec.Specification, docs []*spec.Doc) *Generator {
+	g := &Generator{spec: s, docs: make(map[string]*spec.Doc, len(docs))}
+	for _, d := range docs {
+		g.docs[d.Path] = d
+	}
+	return g
+}
+
+// Recorder starts recording the sources of a generated document; a nil generator returns a nil recorder, which records nothing
+func (g *Generator) Recorder(x *etree.Document) *Recorder {
+	if g == nil {
+		return nil
+	}
+	return &Recorder{generator: g, document: x, entities: make(map[*etree.Element]types.Entity)}
+}
@@ -0,0 +1,179 @@
+package sourcemap
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/beevik/etree"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// Recorder collects the spec entity each element of a generated document is built from, as the renderer builds it
+type Recorder struct {
+	generator *Generator
+	document  *etree.Document
+	entities  map[*etree.Element]types.Entity
+}
+
+// Record notes that an element was built from an entity
+func (r *Recorder) Record(el *etree.Element, entity types.Entity) {
+	if r == nil || el == nil || entity == nil {
+		return
+	}
+	r.entities[el] = entity
+}
+
+// SourceMap builds the source map of the recorded elements still in the finished document
+func (r *Recorder) SourceMap(path string) *SourceMap {
+	m := &mapper{recorder: r}
+	m.walk(&r.document.Element, "", nil)
+	return &SourceMap{File: filepath.Base(path), Entries: m.entries}
+}
+
+// Data returns the source map to write alongside the generated file at path, or nil if nothing is being recorded
+func (r *Recorder) Data(path string) (*pipeline.Data[string], error) {
+	if r == nil {
+		return nil, nil
+	}
+	b, err := json.MarshalIndent(r.SourceMap(path), "", "\t")
+	if err != nil {
+		return nil, fmt.Errorf("error writing source map for %s: %w", path, err)
+	}
+	return pipeline.NewData[string](Path(path), string(b)), nil
+}
+
+type mapper struct {
+	recorder *Recorder
+	entries  []*Entry
+}
+
+type scope struct {
+	entity types.Entity
+	name   string
+	doc    *spec.Doc
+	source string
+	line   int
+}
+
+func (m *mapper) walk(el *etree.Element, path string, parent *scope) {
+	counts := make(map[string]int)
+	for _, child := range el.ChildElements() {
+		counts[child.Tag]++
+	}
+	indexes := make(map[string]int)
+	for _, child := range el.ChildElements() {
+		indexes[child.Tag]++
+		childPath := path + "/" + step(child, indexes[child.Tag], counts[child.Tag])
+		entity, ok := m.recorder.entities[child]
+		if !ok {
+			m.walk(child, childPath, parent)
+			continue
+		}
+		s := m.newScope(entity, parent)
+		m.entries = append(m.entries, &Entry{Element: childPath, Entity: entity.EntityType(), Name: s.name, Source: s.source, Line: s.line})
+		m.walk(child, childPath, s)
+	}
+}
+
+// step describes an element by its tag and the first identifying attribute it has, falling back to its position among siblings with the same tag
+func step(el *etree.Element, index int, count int) string {
+	for _, attr := range []string{"id", "code", "fieldId", "name"} {
+		if v := el.SelectAttrValue(attr, ""); v != "" {
+			return fmt.Sprintf("%s[@%s='%s']", el.Tag, attr, v)
+		}
+	}
+	if count > 1 {
+		return fmt.Sprintf("%s[%d]", el.Tag, index)
+	}
+	return el.Tag
+}
+
+// ElementPath returns the path of an element in the same form as the paths in a source map
+func ElementPath(el *etree.Element) string {
+	var steps []string
+	for el != nil && el.Parent() != nil {
+		parent := el.Parent()
+		var index, count int
+		for _, sibling := range parent.ChildElements() {
+			if sibling.Tag != el.Tag {
+				continue
+			}
+			count++
+			if sibling == el {
+				index = count
+			}
+		}
+		steps = append(steps, step(el, index, count))
+		el = parent
+	}
+	slices.Reverse(steps)
+	if len(steps) == 0 {
+		return ""
+	}
+	return "/" + strings.Join(steps, "/")
+}
+
+func (m *mapper) newScope(entity types.Entity, parent *scope) *scope {
+	s := &scope{entity: entity, name: entityName(entity)}
+	if parent != nil {
+		s.name = parent.name + "." + s.name
+		s.doc = parent.doc
+		s.source, s.line = parent.source, parent.line
+	}
+	g := m.recorder.generator
+	if path, ok := g.spec.DocRefs[entity]; ok {
+		s.doc = g.docs[path]
+		s.source, s.line = path, 0
+	}
+	switch e := entity.(type) {
+	case *matter.Field:
+		if e.Source != nil {
+			s.source, s.line = e.Source.Origin()
+			return s
+		}
+	case *matter.DeviceType:
+		if e.Source != nil {
+			s.source, s.line = e.Source.Origin()
+			return s
+		}
+	}
+	if s.doc != nil {
+		if section := s.doc.EntitySection(entity); section != nil {
+			s.source = s.doc.Path
+			s.line, _, _ = section.Base.Position()
+		}
+	}
+	return s
+}
+
+func entityName(entity types.Entity) string {
+	switch e := entity.(type) {
+	case *matter.Cluster:
+		return e.Name
+	case *matter.DeviceType:
+		return e.Name
+	case *matter.Field:
+		return e.Name
+	case *matter.Command:
+		return e.Name
+	case *matter.Event:
+		return e.Name
+	case *matter.Struct:
+		return e.Name
+	case *matter.Enum:
+		return e.Name
+	case *matter.EnumValue:
+		return e.Name
+	case *matter.Bitmap:
+		return e.Name
+	case matter.Bit:
+		return e.Name()
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+package sourcemap
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+type Entry struct {
+	// Element is the path of the generated element, e.g. /cluster[@id='0x0006']/attributes/attribute[@id='0x0000']
+	Element string           `json:"element"`
+	Entity  types.EntityType `json:"entity"`
+	Name    string           `json:"name"`
+	Source  string           `json:"source"`
+	Line    int              `json:"line,omitempty"`
+}
+
+func (e *Entry) Location() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d", e.Source, e.Line)
+	}
+	return e.Source
+}
+
+type SourceMap struct {
+	File    string   `json:"file"`
+	Entries []*Entry `json:"entries"`
+}
+
+// Path returns the path of the source map written alongside a generated XML file
+func Path(xmlPath string) string {
+	return xmlPath + ".map.json"
+}
+
+func Read(xmlPath string) (*SourceMap, error) {
+	b, err := os.ReadFile(Path(xmlPath))
+	if err != nil {
+		return nil, err
+	}
+	return Parse(xmlPath, b)
+}
+
+// Parse reads the source map of a generated XML file from its JSON
+func Parse(xmlPath string, b []byte) (*SourceMap, error) {
+	var sm SourceMap
+	err := json.Unmarshal(b, &sm)
+	if err != nil {
+		return nil, fmt.Errorf("error reading source map for %s: %w", xmlPath, err)
+	}
+	return &sm, nil
+}
+
+// Find returns the entries whose element path matches the query exactly, or whose name is the query or ends with it (e.g. "OnTime" or "On/Off.OnTime")
+func (sm *SourceMap) Find(query string) (entries []*Entry) {
+	for _, e := range sm.Entries {
+		if e.Element == query {
+			return []*Entry{e}
+		}
+	}
+	q := normalizeName(query)
+	for _, e := range sm.Entries {
+		name := normalizeName(e.Name)
+		if name == q || strings.HasSuffix(name, "."+q) {
+			entries = append(entries, e)
+		}
+	}
+	return
+}
+
+// Locate returns the entry for the element at the given path, or for its nearest mapped ancestor
+func (sm *SourceMap) Locate(element string) (entry *Entry) {
+	for _, e := range sm.Entries {
+		if e.Element != element && !strings.HasPrefix(element, e.Element+"/") {
+			continue
+		}
+		if entry == nil || len(e.Element) > len(entry.Element) {
+			entry = e
+		}
+	}
+	return
+}
+
+func normalizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		}
+		return -1
+	}, name)
+}
@@ -18,7 +18,9 @@ func renderAttributes(doc *spec.Doc, cluster *matter.Cluster, b *strings.Builder
 	if len(cluster.Attributes) == 0 {
 		return
 	}
-	b.WriteString("==== Attributes\n\n\n")
+	b.WriteString("==== Attributes\n")
+	startRegion(b, regionAttributesPICS)
+	b.WriteString("\n\n")
 	names := make([]string, 0, len(cluster.Attributes))
 	var longest int
 	for _, a := range cluster.Attributes {
@@ -50,7 +52,9 @@ func renderAttributes(doc *spec.Doc, cluster *matter.Cluster, b *strings.Builder
 		}
 		b.WriteString(" |\n")
 	}
-	b.WriteString("|===\n\n")
+	b.WriteString("|===\n")
+	endRegion(b, regionAttributesPICS)
+	b.WriteString("\n")
 
 }
 
@@ -85,6 +89,7 @@ func renderAttributesTest(cluster *matter.Cluster, b *strings.Builder) {
 	b.WriteString(attributesTestHeader)
 
 	b.WriteString("===== Test Procedure\n")
+	startRegion(b, regionAttributesProcedure)
 	b.WriteString("[cols=\"5%,5%,10%,40%,40%\"]\n")
 	b.WriteString("|===\n")
 	b.WriteString("| **#** | *Ref* | *PICS* | *Test Step* | *Expected Outcome* \n")
@@ -149,7 +154,9 @@ func renderAttributesTest(cluster *matter.Cluster, b *strings.Builder) {
 		}
 		b.WriteString("\n")
 	}
-	b.WriteString("|===\n\n")
+	b.WriteString("|===\n")
+	endRegion(b, regionAttributesProcedure)
+	b.WriteString("\n")
 	b.WriteString("===== Notes/Testing Considerations\n\n\n")
 	b.WriteString("// ################# TEST CASE TEMPLATE: END #################\n")
 }
@@ -28,6 +28,7 @@ func renderClusterTestPlan(doc *spec.Doc, cluster *matter.Cluster) (output strin
 }
 
 var testCases = `== Test Case List
+` + regionStart(regionTestCaseList) + `
 
 |===
 | *TC UUID*         | *Test Case Name*
@@ -35,6 +36,7 @@ var testCases = `== Test Case List
 | TC-{picsCode}-2.1 | Attributes with Server as DUT
 | TC-{picsCode}-2.2 | Primary Functionality with Server as DUT
 |===
+` + regionEnd(regionTestCaseList) + `
 
 
 `
@@ -12,7 +12,9 @@ func renderEvents(doc *spec.Doc, cluster *matter.Cluster, b *strings.Builder) {
 	if len(cluster.Events) == 0 {
 		return
 	}
-	b.WriteString("==== Events\n\n")
+	b.WriteString("==== Events\n")
+	startRegion(b, regionEventsPICS)
+	b.WriteString("\n")
 	names := make([]string, 0, len(cluster.Events))
 	var longest int
 	for _, event := range cluster.Events {
@@ -44,6 +46,8 @@ func renderEvents(doc *spec.Doc, cluster *matter.Cluster, b *strings.Builder) {
 		}
 		b.WriteString(" |\n")
 	}
-	b.WriteString("|===\n\n")
+	b.WriteString("|===\n")
+	endRegion(b, regionEventsPICS)
+	b.WriteString("\n")
 
 }
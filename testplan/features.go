@@ -10,7 +10,9 @@ import (
 
 func renderFeatures(doc *spec.Doc, cluster *matter.Cluster, b *strings.Builder) {
 	if cluster.Features != nil && len(cluster.Features.Bits) > 0 {
-		b.WriteString("==== Features\n\n// FeatureMap defined macros\n")
+		b.WriteString("==== Features\n")
+		startRegion(b, regionFeaturesPICS)
+		b.WriteString("\n// FeatureMap defined macros\n")
 		for _, bit := range cluster.Features.Bits {
 			f := bit.(*matter.Feature)
 			b.WriteString(fmt.Sprintf(":F_%s: %s\n", f.Code, f.Code))
@@ -33,6 +35,8 @@ func renderFeatures(doc *spec.Doc, cluster *matter.Cluster, b *strings.Builder)
 			renderFeatureConformance(b, doc, cluster, f.Conformance())
 			b.WriteString(" | \n")
 		}
-		b.WriteString("|===\n\n\n")
+		b.WriteString("|===\n")
+		endRegion(b, regionFeaturesPICS)
+		b.WriteString("\n\n")
 	}
 }
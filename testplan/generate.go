@@ -19,6 +19,7 @@ import (
 type Generator struct {
 	testPlanRoot string
 	overwrite    bool
+	merge        bool
 }
 
 func (sp Generator) Name() string {
@@ -44,7 +45,7 @@ func (sp *Generator) Process(cxt context.Context, input *pipeline.Data[*spec.Doc
 	for newPath, cluster := range destinations {
 
 		_, err = os.ReadFile(newPath)
-		if (err == nil || !errors.Is(err, os.ErrNotExist)) && !sp.overwrite {
+		if (err == nil || !errors.Is(err, os.ErrNotExist)) && !sp.overwrite && !sp.merge {
 			slog.InfoContext(cxt, "Skipping existing test plan", slog.String("path", newPath))
 			continue
 		}
@@ -61,8 +62,8 @@ func (sp *Generator) Process(cxt context.Context, input *pipeline.Data[*spec.Doc
 	return
 }
 
-func NewGenerator(testPlanRoot string, overwrite bool) *Generator {
-	return &Generator{testPlanRoot: testPlanRoot, overwrite: overwrite}
+func NewGenerator(testPlanRoot string, overwrite bool, merge bool) *Generator {
+	return &Generator{testPlanRoot: testPlanRoot, overwrite: overwrite, merge: merge}
 }
 
 func getTestPlanPath(testplanRoot string, name string) string {
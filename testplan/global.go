@@ -39,6 +39,7 @@ func renderGlobalAttributesTestCase(doc *spec.Doc, cluster *matter.Cluster, b *s
 	b.WriteString(globalHeader)
 
 	b.WriteString("===== Test Procedure\n")
+	startRegion(b, regionGlobalAttributesProcedure)
 	b.WriteString("[cols=\"5%,5%,10%,40%,40%\"]\n")
 	b.WriteString("|===\n")
 	b.WriteString("| **#** | *Ref* | *PICS* | *Test Step* | *Expected Outcome* \n")
@@ -71,6 +72,7 @@ func renderGlobalAttributesTestCase(doc *spec.Doc, cluster *matter.Cluster, b *s
 | 7     | {REF_GENERATEDCOMMANDLIST} |       | {THread} _GeneratedCommandList_ attribute. | {DUTreply} the _GeneratedCommandList_ attribute and have the list of Generated Command:
 {noEntryStdRgn} +
 |===
+` + regionEnd(regionGlobalAttributesProcedure) + `
 
 ===== Notes/Testing Considerations
 ^*^ Step 5 is currently not supported and SHALL be skipped.
@@ -0,0 +1,281 @@
+package testplan
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+)
+
+type region struct {
+	name string
+	// anchors are the headings, in order, that lead to the region in plans written before regions were marked
+	anchors []string
+	// keepRows is set for regions whose table may have rows added by hand, which are kept when the region is replaced
+	keepRows bool
+}
+
+var (
+	regionFeaturesPICS              = region{name: "FEATURES PICS", anchors: []string{"==== Features"}}
+	regionAttributesPICS            = region{name: "ATTRIBUTES PICS", anchors: []string{"==== Attributes"}}
+	regionEventsPICS                = region{name: "EVENTS PICS", anchors: []string{"==== Events"}}
+	regionTestCaseList              = region{name: "TEST CASE LIST", anchors: []string{"== Test Case List"}}
+	regionGlobalAttributesProcedure = region{name: "GLOBAL ATTRIBUTES PROCEDURE", anchors: []string{"==== [TC-{picsCode}-1.1]", "===== Test Procedure"}}
+	regionAttributesProcedure       = region{name: "ATTRIBUTES PROCEDURE", anchors: []string{"==== [TC-{picsCode}-2.1]", "===== Test Procedure"}, keepRows: true}
+)
+
+var generatedRegions = []region{
+	regionFeaturesPICS,
+	regionAttributesPICS,
+	regionEventsPICS,
+	regionTestCaseList,
+	regionGlobalAttributesProcedure,
+	regionAttributesProcedure,
+}
+
+var regionMarkerPattern = regexp.MustCompile(`^// #+ GENERATED (.+): (START|END) #+$`)
+
+var headingPattern = regexp.MustCompile(`^=+ `)
+
+func regionStart(r region) string {
+	return fmt.Sprintf("// ################# GENERATED %s: START #################", r.name)
+}
+
+func regionEnd(r region) string {
+	return fmt.Sprintf("// ################# GENERATED %s: END #################", r.name)
+}
+
+func startRegion(b *strings.Builder, r region) {
+	b.WriteString(regionStart(r))
+	b.WriteRune('\n')
+}
+
+func endRegion(b *strings.Builder, r region) {
+	b.WriteString(regionEnd(r))
+	b.WriteRune('\n')
+}
+
+// Merger replaces the generated regions of existing test plans with freshly generated ones, leaving everything else as it was
+type Merger struct {
+}
+
+func NewMerger() *Merger {
+	return &Merger{}
+}
+
+func (m Merger) Name() string {
+	return "Merging test plans"
+}
+
+func (m Merger) Type() pipeline.ProcessorType {
+	return pipeline.ProcessorTypeIndividual
+}
+
+func (m *Merger) Process(cxt context.Context, input *pipeline.Data[string], index int32, total int32) (outputs []*pipeline.Data[string], extras []*pipeline.Data[string], err error) {
+	var exists bool
+	exists, err = files.Exists(input.Path)
+	if err != nil {
+		return
+	}
+	if !exists {
+		outputs = append(outputs, input)
+		return
+	}
+	var existing []byte
+	existing, err = os.ReadFile(input.Path)
+	if err != nil {
+		return
+	}
+	merged := mergeTestPlan(input.Path, string(existing), input.Content)
+	outputs = append(outputs, pipeline.NewData[string](input.Path, merged))
+	return
+}
+
+func mergeTestPlan(path string, existing string, generated string) string {
+	regions := extractRegions(strings.Split(generated, "\n"))
+	lines := strings.Split(existing, "\n")
+	for _, r := range generatedRegions {
+		content, ok := regions[r.name]
+		if !ok {
+			continue
+		}
+		start, end, ok := findMarkedRegion(lines, r)
+		if !ok {
+			start, end, ok = findAnchoredRegion(lines, r)
+			if !ok {
+				slog.Warn("Unable to find generated region in existing test plan", slog.String("path", path), slog.String("region", r.name))
+				continue
+			}
+		}
+		if r.keepRows {
+			content = keepHandWrittenRows(lines[start:end], content)
+		}
+		merged := make([]string, 0, len(lines)-(end-start)+len(content))
+		merged = append(merged, lines[:start]...)
+		merged = append(merged, content...)
+		merged = append(merged, lines[end:]...)
+		lines = merged
+	}
+	return strings.Join(lines, "\n")
+}
+
+var attributeReferencePattern = regexp.MustCompile(`\{[A-Za-z0-9_]+\}`)
+
+// tableRows splits the lines of the first table among them into rows, each of which starts with a cell on a new line
+// and runs until the next one; it returns the indexes of the lines that open and close the table
+func tableRows(lines []string) (rows [][]string, open int, closing int, ok bool) {
+	open = -1
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "|==="):
+			if open >= 0 {
+				return rows, open, i, true
+			}
+			open = i
+		case open < 0:
+		case strings.HasPrefix(line, "|") || len(rows) == 0:
+			rows = append(rows, []string{lines[i]})
+		default:
+			rows[len(rows)-1] = append(rows[len(rows)-1], lines[i])
+		}
+	}
+	return nil, 0, 0, false
+}
+
+// rowKey identifies a row by the first attribute reference in it, which names the element a generated row is for; rows
+// without one are identified by their text
+func rowKey(row []string) string {
+	text := strings.TrimSpace(strings.Join(row, "\n"))
+	if ref := attributeReferencePattern.FindString(text); ref != "" {
+		return ref
+	}
+	return text
+}
+
+// keepHandWrittenRows adds the rows of an existing table that weren't generated to the generated table, each after the
+// generated row it followed; the generated table has one row per key, so an existing row is only taken to be generated
+// if it's the first with its key. Rows for elements that are no longer generated are kept too, since they can't be told
+// apart from hand-written ones
+func keepHandWrittenRows(existing []string, generated []string) []string {
+	existingRows, _, _, ok := tableRows(existing)
+	if !ok {
+		return generated
+	}
+	generatedRows, open, closing, ok := tableRows(generated)
+	if !ok {
+		return generated
+	}
+	generatedIndexes := make(map[string]int, len(generatedRows))
+	for i, row := range generatedRows {
+		generatedIndexes[rowKey(row)] = i
+	}
+	type keptRow struct {
+		after int
+		row   []string
+	}
+	var kept []keptRow
+	seen := make(map[string]struct{}, len(existingRows))
+	after := -1
+	for _, row := range existingRows {
+		key := rowKey(row)
+		if index, ok := generatedIndexes[key]; ok {
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				after = index
+				continue
+			}
+		}
+		kept = append(kept, keptRow{after: after, row: row})
+	}
+	if len(kept) == 0 {
+		return generated
+	}
+	merged := make([]string, 0, len(generated)+len(existing))
+	merged = append(merged, generated[:open+1]...)
+	for i := -1; i < len(generatedRows); i++ {
+		if i >= 0 {
+			merged = append(merged, generatedRows[i]...)
+		}
+		for _, k := range kept {
+			if k.after == i {
+				merged = append(merged, k.row...)
+			}
+		}
+	}
+	return append(merged, generated[closing:]...)
+}
+
+// extractRegions returns the lines of each marked region, including its markers
+func extractRegions(lines []string) map[string][]string {
+	regions := make(map[string][]string)
+	var name string
+	var start int
+	for i, line := range lines {
+		matches := regionMarkerPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+		switch matches[2] {
+		case "START":
+			name, start = matches[1], i
+		case "END":
+			if matches[1] == name {
+				regions[name] = lines[start : i+1]
+			}
+			name = ""
+		}
+	}
+	return regions
+}
+
+// findMarkedRegion returns the lines from a region's start marker through its end marker
+func findMarkedRegion(lines []string, r region) (start int, end int, ok bool) {
+	start = -1
+	for i, line := range lines {
+		switch strings.TrimSpace(line) {
+		case regionStart(r):
+			start = i
+		case regionEnd(r):
+			if start >= 0 {
+				return start, i + 1, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// findAnchoredRegion returns the lines from just after a region's last anchor through the end of the first table
+// that follows it, so long as no other heading comes first
+func findAnchoredRegion(lines []string, r region) (start int, end int, ok bool) {
+	var anchor int
+	i := 0
+	for ; i < len(lines) && anchor < len(r.anchors); i++ {
+		if strings.HasPrefix(lines[i], r.anchors[anchor]) {
+			anchor++
+		}
+	}
+	if anchor < len(r.anchors) {
+		return 0, 0, false
+	}
+	start = i
+	var inTable bool
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if !inTable && headingPattern.MatchString(line) {
+			return 0, 0, false
+		}
+		if strings.HasPrefix(line, "|===") {
+			if inTable {
+				return start, i + 1, true
+			}
+			inTable = true
+		}
+	}
+	return 0, 0, false
+}
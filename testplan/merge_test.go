@@ -0,0 +1,210 @@
+package testplan
+
+import (
+	"strings"
+	"testing"
+)
+
+var mergeTests = []struct {
+	Name      string
+	Existing  []string
+	Generated []string
+	Merged    []string
+}{
+	{
+		Name: "marked region",
+		Existing: []string{
+			"==== Features",
+			"A hand-written note",
+			regionStart(regionFeaturesPICS),
+			"|===",
+			"| {PICS_SF_OLD} | old",
+			"|===",
+			regionEnd(regionFeaturesPICS),
+			"Another note",
+		},
+		Generated: []string{
+			"==== Features",
+			regionStart(regionFeaturesPICS),
+			"|===",
+			"| {PICS_SF_NEW} | new",
+			"|===",
+			regionEnd(regionFeaturesPICS),
+		},
+		Merged: []string{
+			"==== Features",
+			"A hand-written note",
+			regionStart(regionFeaturesPICS),
+			"|===",
+			"| {PICS_SF_NEW} | new",
+			"|===",
+			regionEnd(regionFeaturesPICS),
+			"Another note",
+		},
+	},
+	{
+		Name: "anchored region",
+		Existing: []string{
+			"==== Features",
+			"",
+			"|===",
+			"| {PICS_SF_OLD} | old",
+			"|===",
+			"Another note",
+		},
+		Generated: []string{
+			"==== Features",
+			regionStart(regionFeaturesPICS),
+			"|===",
+			"| {PICS_SF_NEW} | new",
+			"|===",
+			regionEnd(regionFeaturesPICS),
+		},
+		Merged: []string{
+			"==== Features",
+			regionStart(regionFeaturesPICS),
+			"|===",
+			"| {PICS_SF_NEW} | new",
+			"|===",
+			regionEnd(regionFeaturesPICS),
+			"Another note",
+		},
+	},
+	{
+		Name: "anchored region under nested headings",
+		Existing: []string{
+			"==== [TC-{picsCode}-1.1] Global Attributes with {DUT_Server}",
+			"===== Test Procedure",
+			"|===",
+			"| 1 | old step",
+			"|===",
+			"==== [TC-{picsCode}-2.1] Attributes with {DUT_Server}",
+			"===== Test Procedure",
+			"|===",
+			"| 1 | hand-written step",
+			"|===",
+		},
+		Generated: []string{
+			regionStart(regionGlobalAttributesProcedure),
+			"|===",
+			"| 1 | new step",
+			"|===",
+			regionEnd(regionGlobalAttributesProcedure),
+		},
+		Merged: []string{
+			"==== [TC-{picsCode}-1.1] Global Attributes with {DUT_Server}",
+			"===== Test Procedure",
+			regionStart(regionGlobalAttributesProcedure),
+			"|===",
+			"| 1 | new step",
+			"|===",
+			regionEnd(regionGlobalAttributesProcedure),
+			"==== [TC-{picsCode}-2.1] Attributes with {DUT_Server}",
+			"===== Test Procedure",
+			"|===",
+			"| 1 | hand-written step",
+			"|===",
+		},
+	},
+	{
+		Name: "anchor without a table before the next heading",
+		Existing: []string{
+			"==== Features",
+			"This cluster has no features.",
+			"==== Attributes",
+			"|===",
+			"| {PICS_SA_OLD} | old",
+			"|===",
+		},
+		Generated: []string{
+			regionStart(regionFeaturesPICS),
+			"|===",
+			"| {PICS_SF_NEW} | new",
+			"|===",
+			regionEnd(regionFeaturesPICS),
+		},
+		Merged: []string{
+			"==== Features",
+			"This cluster has no features.",
+			"==== Attributes",
+			"|===",
+			"| {PICS_SA_OLD} | old",
+			"|===",
+		},
+	},
+	{
+		Name: "hand-written procedure rows",
+		Existing: []string{
+			regionStart(regionAttributesProcedure),
+			"|===",
+			"| **#** | *Ref* | *PICS* | *Test Step* | *Expected Outcome*",
+			"| 1 | | | {comDutTH}. |",
+			"| 2 | {REF_WOB_SA} | {PICS_SA} | {THread} _{A}_ attribute. | old outcome",
+			"| 2a | | {PICS_SA} | TH writes _{A}_. | hand-written",
+			"  - continued",
+			"| 3 | {REF_WOB_SB} | {PICS_SB} | {THread} _{B}_ attribute. |",
+			"| 3a | {REF_WOB_SB} | {PICS_SB} | TH reads _{B}_ again. |",
+			"| 4 | {REF_WOB_SC} | {PICS_SC} | {THread} _{C}_ attribute. |",
+			"| 4a | | | TH does something after C. |",
+			"|===",
+			regionEnd(regionAttributesProcedure),
+		},
+		Generated: []string{
+			regionStart(regionAttributesProcedure),
+			"|===",
+			"| **#** | *Ref* | *PICS* | *Test Step* | *Expected Outcome*",
+			"| 1 | | | {comDutTH}. |",
+			"| 2 | {REF_WOB_SA} | {PICS_SA} | {THread} _{A}_ attribute. | new outcome",
+			"| 3 | {REF_WOB_SB} | {PICS_SB} | {THread} _{B}_ attribute. |",
+			"|===",
+			regionEnd(regionAttributesProcedure),
+		},
+		Merged: []string{
+			regionStart(regionAttributesProcedure),
+			"|===",
+			"| **#** | *Ref* | *PICS* | *Test Step* | *Expected Outcome*",
+			"| 1 | | | {comDutTH}. |",
+			"| 2 | {REF_WOB_SA} | {PICS_SA} | {THread} _{A}_ attribute. | new outcome",
+			"| 2a | | {PICS_SA} | TH writes _{A}_. | hand-written",
+			"  - continued",
+			"| 3 | {REF_WOB_SB} | {PICS_SB} | {THread} _{B}_ attribute. |",
+			"| 3a | {REF_WOB_SB} | {PICS_SB} | TH reads _{B}_ again. |",
+			"| 4 | {REF_WOB_SC} | {PICS_SC} | {THread} _{C}_ attribute. |",
+			"| 4a | | | TH does something after C. |",
+			"|===",
+			regionEnd(regionAttributesProcedure),
+		},
+	},
+	{
+		Name: "region missing from the generated plan",
+		Existing: []string{
+			"==== Events",
+			regionStart(regionEventsPICS),
+			"|===",
+			"| {PICS_SE_OLD} | old",
+			"|===",
+			regionEnd(regionEventsPICS),
+		},
+		Generated: []string{
+			"==== Events",
+		},
+		Merged: []string{
+			"==== Events",
+			regionStart(regionEventsPICS),
+			"|===",
+			"| {PICS_SE_OLD} | old",
+			"|===",
+			regionEnd(regionEventsPICS),
+		},
+	},
+}
+
+func TestMergeTestPlan(t *testing.T) {
+	for _, mt := range mergeTests {
+		merged := mergeTestPlan(mt.Name, strings.Join(mt.Existing, "\n"), strings.Join(mt.Generated, "\n"))
+		expected := strings.Join(mt.Merged, "\n")
+		if merged != expected {
+			t.Errorf("%s: unexpected merge result; expected\n%s\ngot\n%s", mt.Name, expected, merged)
+		}
+	}
+}
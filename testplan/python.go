@@ -0,0 +1,387 @@
+package testplan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+	"github.com/project-chip/alchemy/predicate"
+)
+
+// Features are checked against the feature map the DUT reported, and other elements against their PICS
+var pythonDialect = &predicate.Dialect{
+	And:   " and ",
+	Or:    " or ",
+	Not:   "not ",
+	True:  predicate.Constant("True"),
+	False: predicate.Constant("False"),
+	Equal: func(left string, right string, not bool) string {
+		if not {
+			return fmt.Sprintf("(%s != %s)", left, right)
+		}
+		return fmt.Sprintf("(%s == %s)", left, right)
+	},
+	Feature: func(cluster *matter.Cluster, feature *matter.Feature, mask uint64) string {
+		return fmt.Sprintf("((feature_map & 0x%X) != 0)", mask)
+	},
+	Element: func(cluster *matter.Cluster, name string, entity types.Entity) string {
+		pics := elementPICS(cluster, entity)
+		if pics == "" {
+			return ""
+		}
+		return fmt.Sprintf("self.check_pics(%q)", pics)
+	},
+}
+
+type PythonGenerator struct {
+	sdkRoot   string
+	overwrite bool
+	year      int
+}
+
+// NewPythonGenerator creates a generator for Python tests; year is the one given in their copyright notice, or 0 to keep the year of existing tests
+func NewPythonGenerator(sdkRoot string, overwrite bool, year int) *PythonGenerator {
+	return &PythonGenerator{sdkRoot: sdkRoot, overwrite: overwrite, year: year}
+}
+
+func (sp PythonGenerator) Name() string {
+	return "Generating Python tests"
+}
+
+func (sp PythonGenerator) Type() pipeline.ProcessorType {
+	return pipeline.ProcessorTypeIndividual
+}
+
+func (sp *PythonGenerator) Process(cxt context.Context, input *pipeline.Data[*spec.Doc], index int32, total int32) (outputs []*pipeline.Data[string], extras []*pipeline.Data[*spec.Doc], err error) {
+	var entities []types.Entity
+	entities, err = input.Content.Entities()
+	if err != nil {
+		return
+	}
+
+	var clusters []*matter.Cluster
+	for _, e := range entities {
+		switch e := e.(type) {
+		case *matter.ClusterGroup:
+			clusters = append(clusters, e.Clusters...)
+		case *matter.Cluster:
+			clusters = append(clusters, e)
+		}
+	}
+
+	for _, cluster := range clusters {
+		if cluster.PICS == "" {
+			slog.WarnContext(cxt, "Skipping Python test for cluster without PICS code", slog.String("cluster", cluster.Name))
+			continue
+		}
+		newPath := getPythonTestPath(sp.sdkRoot, cluster.PICS)
+		var existing []byte
+		existing, err = os.ReadFile(newPath)
+		if (err == nil || !errors.Is(err, os.ErrNotExist)) && !sp.overwrite {
+			slog.InfoContext(cxt, "Skipping existing Python test", slog.String("path", newPath))
+			err = nil
+			continue
+		}
+		err = nil
+		outputs = append(outputs, pipeline.NewData[string](newPath, renderClusterPythonTest(cluster, copyrightYear(sp.year, existing))))
+	}
+	return
+}
+
+func getPythonTestPath(sdkRoot string, pics string) string {
+	return filepath.Join(sdkRoot, "src/python_testing", "TC_"+picsName(pics)+".py")
+}
+
+var pythonImports = `from matter_testing_support import MatterBaseTest, TestStep, async_test_body, default_matter_test_main
+from mobly import asserts
+`
+
+func renderClusterPythonTest(cluster *matter.Cluster, year int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, scriptHeader, year, cluster.Name)
+	b.WriteString("\nimport chip.clusters as Clusters\n")
+	if hasNullableAttribute(cluster) {
+		b.WriteString("from chip.clusters.Types import NullValue\n")
+	}
+	b.WriteString(pythonImports)
+	fmt.Fprintf(&b, "\n\nclass TC_%s(MatterBaseTest):\n", picsName(cluster.PICS))
+	renderPythonGlobalAttributesTest(cluster, &b)
+	renderPythonAttributesTest(cluster, &b)
+	renderPythonCommandsTest(cluster, &b)
+	b.WriteString("\n\nif __name__ == \"__main__\":\n    default_matter_test_main()\n")
+	return b.String()
+}
+
+// renderPythonTestMethods writes a test's description, PICS and steps, and starts the test itself
+func renderPythonTestMethods(b *strings.Builder, cluster *matter.Cluster, number string, description string, steps []string) {
+	name := picsName(cluster.PICS) + "_" + strings.ReplaceAll(number, ".", "_")
+	fmt.Fprintf(b, "\n    def desc_TC_%s(self) -> str:\n        return \"[TC-%s-%s] %s\"\n", name, cluster.PICS, number, description)
+	fmt.Fprintf(b, "\n    def pics_TC_%s(self) -> list[str]:\n        return [\"%s.S\"]\n", name, cluster.PICS)
+	fmt.Fprintf(b, "\n    def steps_TC_%s(self) -> list[TestStep]:\n        return [\n", name)
+	b.WriteString("            TestStep(1, \"Commission DUT to TH\", is_commissioning=True),\n")
+	for i, s := range steps {
+		fmt.Fprintf(b, "            TestStep(%d, %q),\n", i+2, s)
+	}
+	b.WriteString("        ]\n")
+	fmt.Fprintf(b, "\n    @async_test_body\n    async def test_TC_%s(self):\n", name)
+	if len(steps) > 0 {
+		b.WriteString("        endpoint = self.get_endpoint(default=1)\n")
+		fmt.Fprintf(b, "        cluster = Clusters.%s\n", matter.Case(cluster.Name))
+	}
+}
+
+func renderPythonGlobalAttributesTest(cluster *matter.Cluster, b *strings.Builder) {
+	renderPythonTestMethods(b, cluster, "1.1", "Global Attributes with DUT as Server", []string{
+		"TH reads the ClusterRevision attribute",
+		"TH reads the FeatureMap attribute",
+		"TH reads the AttributeList attribute",
+		"TH reads the EventList attribute",
+		"TH reads the AcceptedCommandList attribute",
+		"TH reads the GeneratedCommandList attribute",
+	})
+	b.WriteString("        attributes = cluster.Attributes\n")
+	if len(cluster.Commands) > 0 {
+		b.WriteString("        commands = cluster.Commands\n")
+	}
+	b.WriteString("\n        self.step(1)\n")
+
+	b.WriteString("\n        self.step(2)\n")
+	b.WriteString("        revision = await self.read_single_attribute_check_success(cluster=cluster, attribute=attributes.ClusterRevision, endpoint=endpoint)\n")
+	if len(cluster.Revisions) > 0 {
+		fmt.Fprintf(b, "        asserts.assert_equal(revision, %s, \"Unexpected ClusterRevision\")\n", cluster.Revisions[len(cluster.Revisions)-1].Number)
+	}
+
+	b.WriteString("\n        self.step(3)\n")
+	b.WriteString("        feature_map = await self.read_single_attribute_check_success(cluster=cluster, attribute=attributes.FeatureMap, endpoint=endpoint)\n")
+	var known uint64
+	if cluster.Features != nil {
+		for _, bit := range cluster.Features.Bits {
+			f, ok := bit.(*matter.Feature)
+			if !ok {
+				continue
+			}
+			mask, err := f.Mask()
+			if err != nil {
+				slog.Warn("invalid feature bit in Python test", slog.String("cluster", cluster.Name), slog.String("feature", f.Code), slog.Any("error", err))
+				continue
+			}
+			known |= mask
+			fmt.Fprintf(b, "        asserts.assert_equal((feature_map & 0x%X) != 0, self.check_pics(%q), \"%s feature bit does not match PICS\")\n", mask, featurePICS(cluster, f), f.Name())
+		}
+	}
+	fmt.Fprintf(b, "        asserts.assert_equal(feature_map & ~0x%X, 0, \"Unknown feature bits are set\")\n", known)
+
+	b.WriteString("\n        self.step(4)\n")
+	b.WriteString("        attribute_list = await self.read_single_attribute_check_success(cluster=cluster, attribute=attributes.AttributeList, endpoint=endpoint)\n")
+	for _, name := range []string{"ClusterRevision", "FeatureMap", "AttributeList", "AcceptedCommandList", "GeneratedCommandList"} {
+		fmt.Fprintf(b, "        asserts.assert_in(attributes.%s.attribute_id, attribute_list, \"%s attribute is missing\")\n", name, name)
+	}
+	for _, a := range cluster.Attributes {
+		if conformance.IsZigbee(cluster.Attributes, a.Conformance) {
+			continue
+		}
+		writePythonListCheck(b, cluster, a, a.Name, a.Conformance, "attribute_list", fmt.Sprintf("attributes.%s.attribute_id", matter.Case(a.Name)), "attribute")
+	}
+
+	b.WriteString("\n        # The EventList attribute is not yet supported\n        self.skip_step(5)\n")
+
+	b.WriteString("\n        self.step(6)\n")
+	b.WriteString("        accepted_command_list = await self.read_single_attribute_check_success(cluster=cluster, attribute=attributes.AcceptedCommandList, endpoint=endpoint)\n")
+	renderPythonCommandListChecks(b, cluster, matter.InterfaceServer, "accepted_command_list")
+
+	b.WriteString("\n        self.step(7)\n")
+	b.WriteString("        generated_command_list = await self.read_single_attribute_check_success(cluster=cluster, attribute=attributes.GeneratedCommandList, endpoint=endpoint)\n")
+	renderPythonCommandListChecks(b, cluster, matter.InterfaceClient, "generated_command_list")
+}
+
+func renderPythonCommandListChecks(b *strings.Builder, cluster *matter.Cluster, direction matter.Interface, list string) {
+	for _, c := range cluster.Commands {
+		if c.Direction != direction || conformance.IsZigbee(cluster.Commands, c.Conformance) {
+			continue
+		}
+		writePythonListCheck(b, cluster, c, c.Name, c.Conformance, list, fmt.Sprintf("commands.%s.command_id", matter.Case(c.Name)), "command")
+	}
+}
+
+// writePythonListCheck checks that an element is in a list when its conformance makes it mandatory or its PICS is set, and otherwise isn't
+func writePythonListCheck(b *strings.Builder, cluster *matter.Cluster, entity types.Entity, name string, cs conformance.Set, list string, id string, kind string) {
+	if conformance.IsDisallowed(cs) {
+		return
+	}
+	condition, always := mandatoryCondition(pythonDialect, cluster, cs)
+	if always {
+		fmt.Fprintf(b, "        asserts.assert_in(%s, %s, \"%s %s is missing\")\n", id, list, name, kind)
+		return
+	}
+	if condition != "" {
+		condition += " or "
+	}
+	condition += fmt.Sprintf("self.check_pics(%q)", elementPICS(cluster, entity))
+	fmt.Fprintf(b, "        # %s: %s\n", name, strings.ReplaceAll(cs.ASCIIDocString(), `\|`, "|"))
+	fmt.Fprintf(b, "        if %s:\n", condition)
+	fmt.Fprintf(b, "            asserts.assert_in(%s, %s, \"%s %s is missing\")\n", id, list, name, kind)
+	b.WriteString("        else:\n")
+	fmt.Fprintf(b, "            asserts.assert_not_in(%s, %s, \"%s %s is not expected\")\n", id, list, name, kind)
+}
+
+func renderPythonAttributesTest(cluster *matter.Cluster, b *strings.Builder) {
+	steps := make([]string, 0, len(cluster.Attributes))
+	for _, a := range cluster.Attributes {
+		steps = append(steps, fmt.Sprintf("TH reads the %s attribute", a.Name))
+	}
+	renderPythonTestMethods(b, cluster, "2.1", "Attributes with DUT as Server", steps)
+	if len(cluster.Attributes) > 0 {
+		b.WriteString("        attributes = cluster.Attributes\n")
+	}
+	b.WriteString("\n        self.step(1)\n")
+	for i, a := range cluster.Attributes {
+		fmt.Fprintf(b, "\n        self.step(%d)\n", i+2)
+		fmt.Fprintf(b, "        if self.check_pics(%q):\n", elementPICS(cluster, a))
+		checks := pythonValueChecks(a, cluster.Attributes)
+		if len(checks) == 0 {
+			fmt.Fprintf(b, "            await self.read_single_attribute_check_success(cluster=cluster, attribute=attributes.%s, endpoint=endpoint)\n", matter.Case(a.Name))
+			continue
+		}
+		fmt.Fprintf(b, "            value = await self.read_single_attribute_check_success(cluster=cluster, attribute=attributes.%s, endpoint=endpoint)\n", matter.Case(a.Name))
+		indent := "            "
+		if a.Quality.Has(matter.QualityNullable) {
+			b.WriteString("            if value is not NullValue:\n")
+			indent += "    "
+		}
+		for _, c := range checks {
+			b.WriteString(indent)
+			b.WriteString(c)
+			b.WriteRune('\n')
+		}
+	}
+}
+
+func renderPythonCommandsTest(cluster *matter.Cluster, b *strings.Builder) {
+	var accepted []*matter.Command
+	for _, c := range cluster.Commands {
+		if c.Direction != matter.InterfaceServer || conformance.IsZigbee(cluster.Commands, c.Conformance) || conformance.IsDisallowed(c.Conformance) {
+			continue
+		}
+		accepted = append(accepted, c)
+	}
+	steps := make([]string, 0, len(accepted))
+	for _, c := range accepted {
+		steps = append(steps, fmt.Sprintf("TH sends the %s command", c.Name))
+	}
+	renderPythonTestMethods(b, cluster, "2.2", "Primary Functionality with DUT as Server", steps)
+	if len(accepted) > 0 {
+		b.WriteString("        commands = cluster.Commands\n")
+	}
+	b.WriteString("\n        self.step(1)\n")
+	for i, c := range accepted {
+		fmt.Fprintf(b, "\n        self.step(%d)\n", i+2)
+		fmt.Fprintf(b, "        if self.check_pics(%q):\n", elementPICS(cluster, c))
+		fmt.Fprintf(b, "            # TODO: set the %s command's fields and verify the DUT's response\n", c.Name)
+		fmt.Fprintf(b, "            await self.send_single_cmd(cmd=commands.%s(), endpoint=endpoint)\n", matter.Case(c.Name))
+	}
+}
+
+// pythonValueChecks asserts the type of an attribute's value and that it's within the attribute's constraint
+func pythonValueChecks(f *matter.Field, fields matter.FieldSet) (checks []string) {
+	if f.Type == nil {
+		return
+	}
+	pythonType := pythonTypeName(f.Type)
+	if pythonType == "" {
+		return
+	}
+	checks = append(checks, fmt.Sprintf("asserts.assert_true(isinstance(value, %s), \"%s is not of type %s\")", pythonType, f.Name, pythonType))
+	if f.Constraint == nil {
+		return
+	}
+	cc := &matter.ConstraintContext{Field: f, Fields: fields}
+	value := "value"
+	if f.Type.IsArray() || f.Type.HasLength() {
+		value = "len(value)"
+	} else if pythonType != "int" && pythonType != "float" {
+		return
+	}
+	if m := f.Constraint.Min(cc); m.IsNumeric() {
+		checks = append(checks, fmt.Sprintf("asserts.assert_greater_equal(%s, %v, \"%s is below its minimum\")", value, m.Value(), f.Name))
+	}
+	if m := f.Constraint.Max(cc); m.IsNumeric() {
+		checks = append(checks, fmt.Sprintf("asserts.assert_less_equal(%s, %v, \"%s is above its maximum\")", value, m.Value(), f.Name))
+	}
+	return
+}
+
+func pythonTypeName(dt *types.DataType) string {
+	switch {
+	case dt.IsArray():
+		return "list"
+	case dt.BaseType == types.BaseDataTypeBoolean:
+		return "bool"
+	case dt.BaseType == types.BaseDataTypeString:
+		return "str"
+	case dt.HasLength():
+		return "bytes"
+	case dt.BaseType == types.BaseDataTypeSingle, dt.BaseType == types.BaseDataTypeDouble:
+		return "float"
+	}
+	switch dt.Entity.(type) {
+	case *matter.Enum, *matter.Bitmap:
+		return "int"
+	case *matter.Struct:
+		return ""
+	}
+	if maximum := dt.Max(false); maximum.IsNumeric() {
+		return "int"
+	}
+	return ""
+}
+
+func elementPICS(cluster *matter.Cluster, entity types.Entity) string {
+	switch entity := entity.(type) {
+	case *matter.Feature:
+		return featurePICS(cluster, entity)
+	case *matter.Field:
+		if entity.EntityType() == types.EntityTypeAttribute && entity.ID.Valid() {
+			return fmt.Sprintf("%s.S.A%04X", cluster.PICS, entity.ID.Value())
+		}
+	case *matter.Command:
+		if !entity.ID.Valid() {
+			return ""
+		}
+		if entity.Direction == matter.InterfaceClient {
+			return fmt.Sprintf("%s.S.C%02X.Tx", cluster.PICS, entity.ID.Value())
+		}
+		return fmt.Sprintf("%s.S.C%02X.Rsp", cluster.PICS, entity.ID.Value())
+	}
+	return ""
+}
+
+func featurePICS(cluster *matter.Cluster, f *matter.Feature) string {
+	from, _, err := f.Bits()
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%s.S.F%02X", cluster.PICS, from)
+}
+
+func hasNullableAttribute(cluster *matter.Cluster) bool {
+	for _, a := range cluster.Attributes {
+		if a.Quality.Has(matter.QualityNullable) && len(pythonValueChecks(a, cluster.Attributes)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// picsName is a PICS code usable in file and class names
+func picsName(pics string) string {
+	return strings.ReplaceAll(pics, "-", "_")
+}
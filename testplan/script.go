@@ -0,0 +1,78 @@
+package testplan
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/predicate"
+)
+
+var scriptHeader = `#
+#    Copyright (c) %d Project CHIP Authors
+#    All rights reserved.
+#
+#    Licensed under the Apache License, Version 2.0 (the "License");
+#    you may not use this file except in compliance with the License.
+#    You may obtain a copy of the License at
+#
+#        http://www.apache.org/licenses/LICENSE-2.0
+#
+#    Unless required by applicable law or agreed to in writing, software
+#    distributed under the License is distributed on an "AS IS" BASIS,
+#    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+#    See the License for the specific language governing permissions and
+#    limitations under the License.
+#
+
+# This test was generated by alchemy from the %s cluster specification.
+`
+
+var copyrightPattern = regexp.MustCompile(`Copyright \(c\) (\d{4}) Project CHIP Authors`)
+
+// copyrightYear returns the year for the copyright notice of a generated test; unless one was given, an existing test keeps
+// the year in its notice, so regenerating it doesn't change it, and a new test gets the current year
+func copyrightYear(year int, existing []byte) int {
+	if year != 0 {
+		return year
+	}
+	if match := copyrightPattern.FindSubmatch(existing); match != nil {
+		if y, err := strconv.Atoi(string(match[1])); err == nil {
+			return y
+		}
+	}
+	return time.Now().Year()
+}
+
+// mandatoryCondition returns the condition under which the first applicable entry of a conformance is mandatory,
+// or always if that's unconditionally the case
+func mandatoryCondition(d *predicate.Dialect, cluster *matter.Cluster, cs conformance.Set) (condition string, always bool) {
+	var previous []string
+	var mandatory []string
+	for _, c := range cs {
+		var exp conformance.Expression
+		switch c := c.(type) {
+		case *conformance.Mandatory:
+			exp = c.Expression
+			if exp == nil {
+				if len(previous) == 0 {
+					return "", true
+				}
+				mandatory = append(mandatory, strings.Join(previous, d.And))
+				return strings.Join(mandatory, d.Or), false
+			}
+			mandatory = append(mandatory, strings.Join(append(append([]string{}, previous...), predicate.Compile(d, cluster, exp)), d.And))
+		case *conformance.Optional:
+			exp = c.Expression
+		}
+		if exp == nil {
+			// Nothing after an unconditional entry can apply
+			break
+		}
+		previous = append(previous, d.Not+predicate.Compile(d, cluster, exp))
+	}
+	return strings.Join(mandatory, d.Or), false
+}
@@ -0,0 +1,32 @@
+package testplan
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+var copyrightYearTests = []struct {
+	Name     string
+	Year     int
+	Existing string
+	Expected int
+}{
+	{Name: "existing test", Existing: fmt.Sprintf(scriptHeader, 2021, "Test"), Expected: 2021},
+	{Name: "given year", Year: 2024, Existing: fmt.Sprintf(scriptHeader, 2021, "Test"), Expected: 2024},
+	{Name: "new test", Expected: time.Now().Year()},
+	{Name: "existing test without notice", Existing: "# A hand-written test\n", Expected: time.Now().Year()},
+}
+
+func TestCopyrightYear(t *testing.T) {
+	for _, ct := range copyrightYearTests {
+		var existing []byte
+		if ct.Existing != "" {
+			existing = []byte(ct.Existing)
+		}
+		year := copyrightYear(ct.Year, existing)
+		if year != ct.Expected {
+			t.Errorf("%s: unexpected copyright year; expected %d, got %d", ct.Name, ct.Expected, year)
+		}
+	}
+}
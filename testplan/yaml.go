@@ -0,0 +1,339 @@
+package testplan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/project-chip/alchemy/boundary"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+	"github.com/project-chip/alchemy/predicate"
+	"github.com/project-chip/alchemy/zap"
+)
+
+// The test only runs when the cluster's server PICS is set, so it stands in for true and its negation for false
+var yamlDialect = &predicate.Dialect{
+	And: " && ",
+	Or:  " || ",
+	Not: "!",
+	True: func(cluster *matter.Cluster) string {
+		return fmt.Sprintf("%s.S", cluster.PICS)
+	},
+	False: func(cluster *matter.Cluster) string {
+		return fmt.Sprintf("!%s.S", cluster.PICS)
+	},
+	Equal: func(left string, right string, not bool) string {
+		if not {
+			return fmt.Sprintf("((%s && !%s) || (!%s && %s))", left, right, left, right)
+		}
+		return fmt.Sprintf("((%s && %s) || (!%s && !%s))", left, right, left, right)
+	},
+	Feature: func(cluster *matter.Cluster, feature *matter.Feature, mask uint64) string {
+		return featurePICS(cluster, feature)
+	},
+	Element: func(cluster *matter.Cluster, name string, entity types.Entity) string {
+		return elementPICS(cluster, entity)
+	},
+}
+
+var yamlVectorLabels = map[boundary.Kind]string{
+	boundary.KindMin:        "the minimum value",
+	boundary.KindMax:        "the maximum value",
+	boundary.KindDefault:    "the default value",
+	boundary.KindTypical:    "a typical value",
+	boundary.KindNull:       "null",
+	boundary.KindBelowMin:   "a value below the minimum",
+	boundary.KindAboveMax:   "a value above the maximum",
+	boundary.KindUndefined:  "an undefined value",
+	boundary.KindMinLength:  "a value of the minimum length",
+	boundary.KindMaxLength:  "a value of the maximum length",
+	boundary.KindOverLength: "a value longer than the maximum length",
+}
+
+type YAMLGenerator struct {
+	sdkRoot   string
+	overwrite bool
+	year      int
+}
+
+// NewYAMLGenerator creates a generator for YAML tests; year is the one given in their copyright notice, or 0 to keep the year of existing tests
+func NewYAMLGenerator(sdkRoot string, overwrite bool, year int) *YAMLGenerator {
+	return &YAMLGenerator{sdkRoot: sdkRoot, overwrite: overwrite, year: year}
+}
+
+func (sp YAMLGenerator) Name() string {
+	return "Generating YAML tests"
+}
+
+func (sp YAMLGenerator) Type() pipeline.ProcessorType {
+	return pipeline.ProcessorTypeIndividual
+}
+
+func (sp *YAMLGenerator) Process(cxt context.Context, input *pipeline.Data[*spec.Doc], index int32, total int32) (outputs []*pipeline.Data[string], extras []*pipeline.Data[*spec.Doc], err error) {
+	var entities []types.Entity
+	entities, err = input.Content.Entities()
+	if err != nil {
+		return
+	}
+
+	var clusters []*matter.Cluster
+	for _, e := range entities {
+		switch e := e.(type) {
+		case *matter.ClusterGroup:
+			clusters = append(clusters, e.Clusters...)
+		case *matter.Cluster:
+			clusters = append(clusters, e)
+		}
+	}
+
+	for _, cluster := range clusters {
+		if cluster.PICS == "" {
+			slog.WarnContext(cxt, "Skipping YAML test for cluster without PICS code", slog.String("cluster", cluster.Name))
+			continue
+		}
+		newPath := getYAMLTestPath(sp.sdkRoot, cluster.PICS)
+		var existing []byte
+		existing, err = os.ReadFile(newPath)
+		if (err == nil || !errors.Is(err, os.ErrNotExist)) && !sp.overwrite {
+			slog.InfoContext(cxt, "Skipping existing YAML test", slog.String("path", newPath))
+			err = nil
+			continue
+		}
+		err = nil
+		outputs = append(outputs, pipeline.NewData[string](newPath, renderClusterYAMLTest(cluster, copyrightYear(sp.year, existing))))
+	}
+	return
+}
+
+func getYAMLTestPath(sdkRoot string, pics string) string {
+	return filepath.Join(sdkRoot, "src/app/tests/suites/certification", "Test_TC_"+picsName(pics)+".yaml")
+}
+
+var yamlConfig = `
+name: "[TC-%s] %s Attributes and Commands with DUT as Server"
+
+PICS:
+    - %s.S
+
+config:
+    nodeId: 0x12344321
+    cluster: %q
+    endpoint: 1
+
+tests:
+    - label: "Wait for the commissioned device to be retrieved"
+      cluster: "DelayCommands"
+      command: "WaitForCommissionee"
+      arguments:
+          values:
+              - name: "nodeId"
+                value: nodeId
+`
+
+func renderClusterYAMLTest(cluster *matter.Cluster, year int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, scriptHeader, year, cluster.Name)
+	fmt.Fprintf(&b, yamlConfig, cluster.PICS, cluster.Name, cluster.PICS, cluster.Name)
+	for _, a := range cluster.Attributes {
+		if conformance.IsZigbee(cluster.Attributes, a.Conformance) || conformance.IsDisallowed(a.Conformance) {
+			continue
+		}
+		renderYAMLReadAttribute(&b, cluster, a)
+	}
+	for _, a := range cluster.Attributes {
+		if a.Access.Write == matter.PrivilegeUnknown || conformance.IsZigbee(cluster.Attributes, a.Conformance) || conformance.IsDisallowed(a.Conformance) {
+			continue
+		}
+		renderYAMLWriteAttribute(&b, cluster, a)
+	}
+	for _, c := range cluster.Commands {
+		if c.Direction != matter.InterfaceServer || conformance.IsZigbee(cluster.Commands, c.Conformance) || conformance.IsDisallowed(c.Conformance) {
+			continue
+		}
+		renderYAMLInvokeCommand(&b, cluster, c)
+	}
+	return b.String()
+}
+
+// yamlGuard is the PICS expression under which an element is expected to be present: when its conformance makes it
+// mandatory, or when its own PICS is set
+func yamlGuard(cluster *matter.Cluster, entity types.Entity, cs conformance.Set) string {
+	pics := elementPICS(cluster, entity)
+	condition, always := mandatoryCondition(yamlDialect, cluster, cs)
+	if always || condition == "" {
+		return pics
+	}
+	return condition + yamlDialect.Or + pics
+}
+
+func renderYAMLStep(b *strings.Builder, label string, guard string, command string) {
+	fmt.Fprintf(b, "\n    - label: %q\n", label)
+	switch {
+	case guard == "":
+	case strings.ContainsAny(guard, "!(|&"):
+		// Expressions are quoted, since YAML treats some of their operators as syntax
+		fmt.Fprintf(b, "      PICS: %q\n", guard)
+	default:
+		fmt.Fprintf(b, "      PICS: %s\n", guard)
+	}
+	fmt.Fprintf(b, "      command: %q\n", command)
+}
+
+func renderYAMLReadAttribute(b *strings.Builder, cluster *matter.Cluster, a *matter.Field) {
+	renderYAMLStep(b, fmt.Sprintf("TH reads the %s attribute", a.Name), yamlGuard(cluster, a, a.Conformance), "readAttribute")
+	fmt.Fprintf(b, "      attribute: %q\n", a.Name)
+	constraints := yamlConstraints(a, cluster.Attributes)
+	if len(constraints) == 0 {
+		return
+	}
+	b.WriteString("      response:\n          constraints:\n")
+	for _, c := range constraints {
+		fmt.Fprintf(b, "              %s\n", c)
+	}
+}
+
+func renderYAMLWriteAttribute(b *strings.Builder, cluster *matter.Cluster, a *matter.Field) {
+	vectors := boundary.FieldVectors(cluster, a, cluster.Attributes)
+	if vectors == nil {
+		return
+	}
+	guard := yamlGuard(cluster, a, a.Conformance)
+	for _, v := range vectors.Valid {
+		value, ok := yamlValue(v)
+		if !ok {
+			continue
+		}
+		renderYAMLStep(b, fmt.Sprintf("TH writes %s to the %s attribute", yamlVectorLabels[v.Kind], a.Name), guard, "writeAttribute")
+		fmt.Fprintf(b, "      attribute: %q\n      arguments:\n          value: %s\n", a.Name, value)
+		renderYAMLStep(b, fmt.Sprintf("TH reads back the %s attribute", a.Name), guard, "readAttribute")
+		fmt.Fprintf(b, "      attribute: %q\n      response:\n          value: %s\n", a.Name, value)
+	}
+	for _, v := range vectors.Invalid {
+		if v.Null {
+			// A null can't be encoded for a non-nullable attribute, so it never reaches the DUT
+			continue
+		}
+		value, ok := yamlValue(v)
+		if !ok {
+			continue
+		}
+		renderYAMLStep(b, fmt.Sprintf("TH writes %s to the %s attribute", yamlVectorLabels[v.Kind], a.Name), guard, "writeAttribute")
+		fmt.Fprintf(b, "      attribute: %q\n      arguments:\n          value: %s\n      response:\n          error: CONSTRAINT_ERROR\n", a.Name, value)
+	}
+}
+
+func renderYAMLInvokeCommand(b *strings.Builder, cluster *matter.Cluster, c *matter.Command) {
+	type argument struct {
+		name  string
+		value string
+	}
+	var arguments []argument
+	var missing []string
+	for _, f := range c.Fields {
+		if conformance.IsZigbee(c.Fields, f.Conformance) {
+			continue
+		}
+		if _, always := mandatoryCondition(yamlDialect, cluster, f.Conformance); !always {
+			continue
+		}
+		value, ok := yamlFieldValue(cluster, f, c.Fields)
+		if !ok {
+			missing = append(missing, f.Name)
+			continue
+		}
+		arguments = append(arguments, argument{name: f.Name, value: value})
+	}
+	renderYAMLStep(b, fmt.Sprintf("TH sends the %s command", c.Name), yamlGuard(cluster, c, c.Conformance), c.Name)
+	if len(missing) > 0 {
+		fmt.Fprintf(b, "      # TODO: provide values for %s\n      disabled: true\n", strings.Join(missing, ", "))
+	}
+	if len(arguments) == 0 {
+		return
+	}
+	b.WriteString("      arguments:\n          values:\n")
+	for _, a := range arguments {
+		fmt.Fprintf(b, "              - name: %q\n                value: %s\n", a.name, a.value)
+	}
+}
+
+// yamlFieldValue picks a valid value for a command field, preferring its default
+func yamlFieldValue(cluster *matter.Cluster, f *matter.Field, fields matter.FieldSet) (string, bool) {
+	vectors := boundary.FieldVectors(cluster, f, fields)
+	if vectors == nil {
+		return "", false
+	}
+	var value string
+	var ok bool
+	for _, v := range vectors.Valid {
+		if s, valid := yamlValue(v); valid && (!ok || v.Kind == boundary.KindDefault) {
+			value, ok = s, true
+		}
+	}
+	return value, ok
+}
+
+func yamlValue(v *boundary.Vector) (string, bool) {
+	if v.Null {
+		return "null", true
+	}
+	switch value := v.Value.(type) {
+	case nil:
+		// Octet strings and lists are only described by their length
+		return "", false
+	case string:
+		return fmt.Sprintf("%q", value), true
+	default:
+		return fmt.Sprintf("%v", value), true
+	}
+}
+
+func yamlConstraints(f *matter.Field, fields matter.FieldSet) (constraints []string) {
+	if f.Type == nil {
+		return
+	}
+	if t := yamlTypeName(f, fields); t != "" {
+		constraints = append(constraints, "type: "+t)
+	}
+	if f.Constraint == nil {
+		return
+	}
+	cc := &matter.ConstraintContext{Field: f, Fields: fields}
+	minimum, maximum := "minValue", "maxValue"
+	if f.Type.IsArray() || f.Type.HasLength() {
+		minimum, maximum = "minLength", "maxLength"
+	} else if pythonTypeName(f.Type) != "int" {
+		return
+	}
+	if m := f.Constraint.Min(cc); m.IsNumeric() {
+		constraints = append(constraints, fmt.Sprintf("%s: %v", minimum, m.Value()))
+	}
+	if m := f.Constraint.Max(cc); m.IsNumeric() {
+		constraints = append(constraints, fmt.Sprintf("%s: %v", maximum, m.Value()))
+	}
+	return
+}
+
+func yamlTypeName(f *matter.Field, fields matter.FieldSet) string {
+	if f.Type.IsArray() {
+		return "list"
+	}
+	switch entity := f.Type.Entity.(type) {
+	case *matter.Enum:
+		if entity.Type != nil {
+			return zap.DataTypeName(entity.Type)
+		}
+	case *matter.Bitmap:
+		if entity.Type != nil {
+			return zap.DataTypeName(entity.Type)
+		}
+	}
+	return zap.FieldToZapDataType(fields, f)
+}
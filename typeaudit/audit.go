@@ -0,0 +1,217 @@
+package typeaudit
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+type Type struct {
+	Entity  types.EntityType `json:"entity"`
+	Name    string           `json:"name"`
+	Cluster string           `json:"cluster,omitempty"`
+	Path    string           `json:"path,omitempty"`
+}
+
+type SingleUse struct {
+	Type
+	UsedBy string `json:"usedBy"`
+}
+
+type Duplicate struct {
+	Entity types.EntityType `json:"entity"`
+	Types  []*Type          `json:"types"`
+}
+
+type Unresolved struct {
+	Cluster  string `json:"cluster,omitempty"`
+	Parent   string `json:"parent,omitempty"`
+	Field    string `json:"field"`
+	TypeName string `json:"typeName"`
+	Source   string `json:"source,omitempty"`
+}
+
+type Report struct {
+	Unreferenced []*Type       `json:"unreferenced,omitempty"`
+	SingleUse    []*SingleUse  `json:"singleUse,omitempty"`
+	Duplicates   []*Duplicate  `json:"duplicates,omitempty"`
+	Unresolved   []*Unresolved `json:"unresolved,omitempty"`
+}
+
+type auditor struct {
+	spec       *spec.Specification
+	owners     map[types.Entity]*matter.Cluster
+	referenced map[types.Entity]struct{}
+	report     Report
+}
+
+func Audit(s *spec.Specification) *Report {
+	a := &auditor{spec: s, owners: s.DataTypeOwners(), referenced: make(map[types.Entity]struct{})}
+
+	clusters := make([]*matter.Cluster, 0, len(s.ClustersByName))
+	for _, c := range s.ClustersByName {
+		clusters = append(clusters, c)
+	}
+	slices.SortFunc(clusters, func(a, b *matter.Cluster) int { return strings.Compare(a.Name, b.Name) })
+
+	var dataTypes []types.Entity
+	for e := range s.DocRefs {
+		if _, ok := spec.DataTypeEntityName(e); ok {
+			dataTypes = append(dataTypes, e)
+		}
+	}
+	slices.SortFunc(dataTypes, func(x, y types.Entity) int {
+		if c := strings.Compare(a.qualifiedName(x), a.qualifiedName(y)); c != 0 {
+			return c
+		}
+		return strings.Compare(s.DocRefs[x], s.DocRefs[y])
+	})
+
+	for _, c := range clusters {
+		a.visitFields(c, "", c.Attributes)
+		for _, cmd := range c.Commands {
+			a.visitFields(c, cmd.Name, cmd.Fields)
+		}
+		for _, ev := range c.Events {
+			a.visitFields(c, ev.Name, ev.Fields)
+		}
+	}
+	for _, e := range dataTypes {
+		if st, ok := e.(*matter.Struct); ok {
+			a.visitFields(a.owners[e], st.Name, st.Fields)
+		}
+	}
+
+	for _, e := range dataTypes {
+		if _, ok := a.referenced[e]; !ok {
+			a.report.Unreferenced = append(a.report.Unreferenced, a.describe(e))
+		}
+	}
+
+	for _, e := range dataTypes {
+		if _, owned := a.owners[e]; owned {
+			continue
+		}
+		users := s.ClusterRefs[e]
+		if len(users) != 1 {
+			continue
+		}
+		for c := range users {
+			a.report.SingleUse = append(a.report.SingleUse, &SingleUse{Type: *a.describe(e), UsedBy: c.Name})
+		}
+	}
+
+	a.findDuplicates(dataTypes)
+	return &a.report
+}
+
+func (a *auditor) visitFields(cluster *matter.Cluster, parent string, fields matter.FieldSet) {
+	for _, f := range fields {
+		a.visitType(cluster, parent, f, f.Type)
+	}
+}
+
+func (a *auditor) visitType(cluster *matter.Cluster, parent string, field *matter.Field, dt *types.DataType) {
+	if dt == nil {
+		return
+	}
+	if dt.EntryType != nil {
+		a.visitType(cluster, parent, field, dt.EntryType)
+		return
+	}
+	if dt.BaseType != types.BaseDataTypeCustom {
+		return
+	}
+	if dt.Entity == nil {
+		u := &Unresolved{Parent: parent, Field: field.Name, TypeName: dt.Name}
+		if cluster != nil {
+			u.Cluster = cluster.Name
+		}
+		if field.Source != nil {
+			path, line := field.Source.Origin()
+			u.Source = fmt.Sprintf("%s:%d", path, line)
+		}
+		a.report.Unresolved = append(a.report.Unresolved, u)
+		return
+	}
+	a.referenced[dt.Entity] = struct{}{}
+}
+
+func (a *auditor) findDuplicates(dataTypes []types.Entity) {
+	bySignature := make(map[string][]types.Entity)
+	var signatures []string
+	for _, e := range dataTypes {
+		owner, owned := a.owners[e]
+		if !owned {
+			continue
+		}
+		// Derived clusters inherit their data types from their base cluster, so they're expected to match
+		if owner.Hierarchy != "" && owner.Hierarchy != "Base" {
+			continue
+		}
+		sig := signature(e)
+		if _, ok := bySignature[sig]; !ok {
+			signatures = append(signatures, sig)
+		}
+		bySignature[sig] = append(bySignature[sig], e)
+	}
+	for _, sig := range signatures {
+		entities := bySignature[sig]
+		if len(entities) < 2 {
+			continue
+		}
+		d := &Duplicate{Entity: entities[0].EntityType()}
+		for _, e := range entities {
+			d.Types = append(d.Types, a.describe(e))
+		}
+		a.report.Duplicates = append(a.report.Duplicates, d)
+	}
+}
+
+func (a *auditor) describe(e types.Entity) *Type {
+	name, _ := spec.DataTypeEntityName(e)
+	t := &Type{Entity: e.EntityType(), Name: name, Path: a.spec.DocRefs[e]}
+	if owner, ok := a.owners[e]; ok {
+		t.Cluster = owner.Name
+	}
+	return t
+}
+
+func (a *auditor) qualifiedName(e types.Entity) string {
+	name, _ := spec.DataTypeEntityName(e)
+	if owner, ok := a.owners[e]; ok {
+		return owner.Name + "." + name
+	}
+	return name
+}
+
+// signature describes the definition of a data type, ignoring its name and description
+func signature(e types.Entity) string {
+	var s strings.Builder
+	s.WriteString(e.EntityType().String())
+	switch e := e.(type) {
+	case *matter.Enum:
+		s.WriteString(spec.DataTypeName(e.Type))
+		for _, v := range e.Values {
+			fmt.Fprintf(&s, "|%s=%s", v.Value.HexString(), v.Name)
+		}
+	case *matter.Bitmap:
+		s.WriteString(spec.DataTypeName(e.Type))
+		for _, b := range e.Bits {
+			fmt.Fprintf(&s, "|%s=%s", b.Bit(), b.Name())
+		}
+	case *matter.Struct:
+		for _, f := range e.Fields {
+			fmt.Fprintf(&s, "|%s=%s:%s", f.ID.HexString(), f.Name, spec.DataTypeName(f.Type))
+			if f.Constraint != nil {
+				s.WriteString(" " + f.Constraint.ASCIIDocString(f.Type))
+			}
+			fmt.Fprintf(&s, " %s %s %s", f.Quality.String(), f.Default, f.Conformance.ASCIIDocString())
+		}
+	}
+	return s.String()
+}
@@ -0,0 +1,114 @@
+package variant
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// PropertyPresent is the property reported when an element only exists under some of the variants
+const PropertyPresent = "present"
+
+type Variant struct {
+	Name string
+	Spec *spec.Specification
+}
+
+type Difference struct {
+	Cluster string `json:"cluster,omitempty"`
+	// Document is set for global data types, which are told apart by the document defining them
+	Document string           `json:"document,omitempty"`
+	Entity   types.EntityType `json:"entity"`
+	Name     string           `json:"name"`
+	Property string           `json:"property"`
+	// Values holds the property's value under each variant, in the same order as the report's variants
+	Values []string `json:"values"`
+}
+
+type Report struct {
+	Variants     []string                 `json:"variants"`
+	Differences  []*Difference            `json:"differences,omitempty"`
+	Conditionals []*UnbalancedConditional `json:"conditionals,omitempty"`
+}
+
+func Compare(variants []*Variant) *Report {
+	r := &Report{}
+	models := make([]model, 0, len(variants))
+	keys := make(map[elementKey]struct{})
+	for _, v := range variants {
+		r.Variants = append(r.Variants, v.Name)
+		m := flatten(v.Spec)
+		for k := range m {
+			keys[k] = struct{}{}
+		}
+		models = append(models, m)
+	}
+
+	for key := range keys {
+		presence := make([]string, len(models))
+		var present []properties
+		for i, m := range models {
+			props, ok := m[key]
+			presence[i] = boolString(ok)
+			if ok {
+				present = append(present, props)
+			}
+		}
+		if len(present) != len(models) {
+			r.Differences = append(r.Differences, newDifference(key, PropertyPresent, presence))
+			continue
+		}
+		for _, name := range propertyNames(present) {
+			values := make([]string, len(present))
+			for i, props := range present {
+				values[i] = props[name]
+			}
+			if !allEqual(values) {
+				r.Differences = append(r.Differences, newDifference(key, name, values))
+			}
+		}
+	}
+	slices.SortFunc(r.Differences, func(a, b *Difference) int {
+		return cmp.Or(strings.Compare(a.Cluster, b.Cluster),
+			strings.Compare(a.Document, b.Document),
+			cmp.Compare(a.Entity, b.Entity),
+			strings.Compare(a.Name, b.Name),
+			strings.Compare(a.Property, b.Property))
+	})
+	return r
+}
+
+func newDifference(key elementKey, property string, values []string) *Difference {
+	return &Difference{Cluster: key.cluster, Document: key.doc, Entity: key.entity, Name: key.name, Property: property, Values: values}
+}
+
+func propertyNames(props []properties) (names []string) {
+	for _, p := range props {
+		for name := range p {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+	return
+}
+
+func allEqual(values []string) bool {
+	for _, v := range values[1:] {
+		if v != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func boolString(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
@@ -0,0 +1,72 @@
+package variant
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+)
+
+type UnbalancedConditional struct {
+	Path      string `json:"path"`
+	Line      int    `json:"line"`
+	Directive string `json:"directive"`
+	Problem   string `json:"problem"`
+}
+
+var conditionalStartPattern = regexp.MustCompile(`^(ifdef|ifndef|ifeval)::([^\[]*)\[(.*)\]\s*$`)
+var conditionalEndPattern = regexp.MustCompile(`^endif::([^\[]*)\[\]\s*$`)
+
+type openConditional struct {
+	line      int
+	directive string
+	target    string
+}
+
+// CheckConditionals reports ifdef, ifndef and ifeval blocks which are never closed, endif directives with nothing to close, and endif directives which name a different attribute than the block they close
+func CheckConditionals(path string, r io.Reader) (issues []*UnbalancedConditional, err error) {
+	var stack []openConditional
+	var inComment bool
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+	var line int
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "////" {
+			inComment = !inComment
+			continue
+		}
+		if inComment || strings.HasPrefix(text, "//") {
+			continue
+		}
+		if m := conditionalStartPattern.FindStringSubmatch(text); m != nil {
+			// ifdef and ifndef with inline content are complete on a single line
+			if m[1] != "ifeval" && m[3] != "" {
+				continue
+			}
+			stack = append(stack, openConditional{line: line, directive: text, target: m[2]})
+			continue
+		}
+		m := conditionalEndPattern.FindStringSubmatch(text)
+		if m == nil {
+			continue
+		}
+		if len(stack) == 0 {
+			issues = append(issues, &UnbalancedConditional{Path: path, Line: line, Directive: text, Problem: "endif without matching conditional"})
+			continue
+		}
+		open := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if m[1] != "" && open.target != "" && m[1] != open.target {
+			issues = append(issues, &UnbalancedConditional{Path: path, Line: line, Directive: text, Problem: "endif closes " + open.directive})
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return
+	}
+	for _, open := range stack {
+		issues = append(issues, &UnbalancedConditional{Path: path, Line: open.line, Directive: open.directive, Problem: "conditional is never closed"})
+	}
+	return
+}
@@ -0,0 +1,166 @@
+package variant
+
+import (
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// scope is where an element is defined: its cluster, or for global data types, which can share a name, its document
+type scope struct {
+	cluster string
+	doc     string
+}
+
+type elementKey struct {
+	scope
+	entity types.EntityType
+	name   string
+}
+
+type properties map[string]string
+
+// model is a flattened view of a spec's data model, so specs built with different attributes can be compared element by element
+type model map[elementKey]properties
+
+func flatten(s *spec.Specification) model {
+	m := make(model)
+	for _, c := range s.ClustersByName {
+		m.addCluster(c)
+	}
+	owned := s.DataTypeOwners()
+	for e := range s.DocRefs {
+		if _, ok := owned[e]; ok {
+			continue
+		}
+		sc := scope{doc: s.DocRefs[e]}
+		switch e := e.(type) {
+		case *matter.Bitmap:
+			m.addBitmap(sc, e)
+		case *matter.Enum:
+			m.addEnum(sc, e)
+		case *matter.Struct:
+			m.addStruct(sc, e)
+		}
+	}
+	return m
+}
+
+func (m model) add(sc scope, entity types.EntityType, name string, props properties) {
+	m[elementKey{scope: sc, entity: entity, name: name}] = props
+}
+
+func (m model) addCluster(c *matter.Cluster) {
+	sc := scope{cluster: c.Name}
+	props := properties{
+		"id":          numberString(c.ID),
+		"conformance": c.Conformance.ASCIIDocString(),
+		"hierarchy":   c.Hierarchy,
+		"role":        c.Role,
+		"scope":       c.Scope,
+		"pics":        c.PICS,
+	}
+	if len(c.Revisions) > 0 {
+		props["revision"] = c.Revisions[len(c.Revisions)-1].Number
+	}
+	m.add(sc, types.EntityTypeCluster, c.Name, props)
+
+	if c.Features != nil {
+		for _, b := range c.Features.Bits {
+			f, ok := b.(*matter.Feature)
+			if !ok {
+				continue
+			}
+			m.add(sc, types.EntityTypeFeature, f.Name(), properties{
+				"bit":         f.Bit(),
+				"code":        f.Code,
+				"conformance": f.Conformance().ASCIIDocString(),
+			})
+		}
+	}
+	for _, bm := range c.Bitmaps {
+		m.addBitmap(sc, bm)
+	}
+	for _, e := range c.Enums {
+		m.addEnum(sc, e)
+	}
+	for _, st := range c.Structs {
+		m.addStruct(sc, st)
+	}
+	for _, a := range c.Attributes {
+		m.add(sc, types.EntityTypeAttribute, a.Name, fieldProperties(a))
+	}
+	for _, cmd := range c.Commands {
+		m.add(sc, types.EntityTypeCommand, cmd.Name, properties{
+			"id":          numberString(cmd.ID),
+			"direction":   cmd.Direction.String(),
+			"response":    cmd.Response,
+			"access":      cmd.Access.String(),
+			"quality":     cmd.Quality.String(),
+			"conformance": cmd.Conformance.ASCIIDocString(),
+		})
+		m.addFields(sc, cmd.Name, cmd.Fields)
+	}
+	for _, ev := range c.Events {
+		m.add(sc, types.EntityTypeEvent, ev.Name, properties{
+			"id":          numberString(ev.ID),
+			"priority":    ev.Priority,
+			"access":      ev.Access.String(),
+			"conformance": ev.Conformance.ASCIIDocString(),
+		})
+		m.addFields(sc, ev.Name, ev.Fields)
+	}
+}
+
+func (m model) addBitmap(sc scope, bm *matter.Bitmap) {
+	m.add(sc, types.EntityTypeBitmap, bm.Name, properties{"type": spec.DataTypeName(bm.Type)})
+	for _, b := range bm.Bits {
+		m.add(sc, types.EntityTypeBitmapValue, bm.Name+"."+b.Name(), properties{
+			"bit":         b.Bit(),
+			"conformance": b.Conformance().ASCIIDocString(),
+		})
+	}
+}
+
+func (m model) addEnum(sc scope, e *matter.Enum) {
+	m.add(sc, types.EntityTypeEnum, e.Name, properties{"type": spec.DataTypeName(e.Type)})
+	for _, v := range e.Values {
+		m.add(sc, types.EntityTypeEnumValue, e.Name+"."+v.Name, properties{
+			"value":       numberString(v.Value),
+			"conformance": v.Conformance.ASCIIDocString(),
+		})
+	}
+}
+
+func (m model) addStruct(sc scope, s *matter.Struct) {
+	m.add(sc, types.EntityTypeStruct, s.Name, properties{})
+	m.addFields(sc, s.Name, s.Fields)
+}
+
+func (m model) addFields(sc scope, parent string, fields matter.FieldSet) {
+	for _, f := range fields {
+		m.add(sc, types.EntityTypeField, parent+"."+f.Name, fieldProperties(f))
+	}
+}
+
+func fieldProperties(f *matter.Field) properties {
+	props := properties{
+		"id":          numberString(f.ID),
+		"type":        spec.DataTypeName(f.Type),
+		"quality":     f.Quality.String(),
+		"access":      f.Access.String(),
+		"default":     f.Default,
+		"conformance": f.Conformance.ASCIIDocString(),
+	}
+	if f.Constraint != nil {
+		props["constraint"] = f.Constraint.ASCIIDocString(f.Type)
+	}
+	return props
+}
+
+func numberString(n *matter.Number) string {
+	if !n.Valid() {
+		return ""
+	}
+	return n.HexString()
+}
@@ -10,10 +10,11 @@ import (
 	"github.com/project-chip/alchemy/internal/xml"
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/sourcemap"
 	"github.com/project-chip/alchemy/zap"
 )
 
-func generateAttributes(configurator *zap.Configurator, cle *etree.Element, cluster *matter.Cluster, attributes map[*matter.Field]struct{}, clusterPrefix string, errata *zap.Errata) (err error) {
+func generateAttributes(configurator *zap.Configurator, cle *etree.Element, cluster *matter.Cluster, attributes map[*matter.Field]struct{}, clusterPrefix string, errata *zap.Errata, sm *sourcemap.Recorder) (err error) {
 
 	for _, ae := range cle.SelectElements("attribute") {
 		ce := ae.SelectAttr("code")
@@ -45,7 +46,7 @@ func generateAttributes(configurator *zap.Configurator, cle *etree.Element, clus
 			continue
 		}
 		delete(attributes, attribute)
-		err = populateAttribute(ae, attribute, cluster, clusterPrefix, errata)
+		err = populateAttribute(ae, attribute, cluster, clusterPrefix, errata, sm)
 		if err != nil {
 			return
 		}
@@ -64,7 +65,7 @@ func generateAttributes(configurator *zap.Configurator, cle *etree.Element, clus
 			continue
 		}
 		ae := etree.NewElement("attribute")
-		err = populateAttribute(ae, a, cluster, clusterPrefix, errata)
+		err = populateAttribute(ae, a, cluster, clusterPrefix, errata, sm)
 		if err != nil {
 			return
 		}
@@ -73,7 +74,8 @@ func generateAttributes(configurator *zap.Configurator, cle *etree.Element, clus
 	return
 }
 
-func populateAttribute(ae *etree.Element, attribute *matter.Field, cluster *matter.Cluster, clusterPrefix string, errata *zap.Errata) (err error) {
+func populateAttribute(ae *etree.Element, attribute *matter.Field, cluster *matter.Cluster, clusterPrefix string, errata *zap.Errata, sm *sourcemap.Recorder) (err error) {
+	sm.Record(ae, attribute)
 	patchNumberAttribute(ae, attribute.ID, "code")
 	ae.CreateAttr("side", "server")
 	define := getDefine(attribute.Name, clusterPrefix, errata)
@@ -10,10 +10,11 @@ import (
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/conformance"
 	"github.com/project-chip/alchemy/matter/types"
+	"github.com/project-chip/alchemy/sourcemap"
 	"github.com/project-chip/alchemy/zap"
 )
 
-func generateBitmaps(configurator *zap.Configurator, ce *etree.Element, errata *zap.Errata) (err error) {
+func generateBitmaps(configurator *zap.Configurator, ce *etree.Element, errata *zap.Errata, sm *sourcemap.Recorder) (err error) {
 
 	for _, eve := range ce.SelectElements("bitmap") {
 
@@ -51,7 +52,7 @@ func generateBitmaps(configurator *zap.Configurator, ce *etree.Element, errata *
 			ce.RemoveChild(eve)
 			continue
 		}
-		err = populateBitmap(eve, matchingBitmap, clusterIds, errata)
+		err = populateBitmap(eve, matchingBitmap, clusterIds, errata, sm)
 		if err != nil {
 			return
 		}
@@ -62,13 +63,14 @@ func generateBitmaps(configurator *zap.Configurator, ce *etree.Element, errata *
 			continue
 		}
 		bme := etree.NewElement("bitmap")
-		populateBitmap(bme, bm, clusterIds, errata)
+		populateBitmap(bme, bm, clusterIds, errata, sm)
 		xml.InsertElementByAttribute(ce, bme, "name", "domain")
 	}
 	return
 }
 
-func populateBitmap(ee *etree.Element, bm *matter.Bitmap, clusterIds []*matter.Number, errata *zap.Errata) (err error) {
+func populateBitmap(ee *etree.Element, bm *matter.Bitmap, clusterIds []*matter.Number, errata *zap.Errata, sm *sourcemap.Recorder) (err error) {
+	sm.Record(ee, bm)
 
 	var valFormat string
 	if bm.Name == "Feature" {
@@ -110,6 +112,7 @@ func populateBitmap(ee *etree.Element, bm *matter.Bitmap, clusterIds []*matter.N
 			if conformance.IsZigbee(bm.Bits, bit.Conformance()) || conformance.IsDisallowed(bit.Conformance()) {
 				continue
 			}
+			sm.Record(be, bit)
 			err = setBitmapFieldAttributes(be, bit, valFormat)
 			if err != nil {
 				return
@@ -124,6 +127,7 @@ func populateBitmap(ee *etree.Element, bm *matter.Bitmap, clusterIds []*matter.N
 			continue
 		}
 		fe := etree.NewElement("field")
+		sm.Record(fe, bit)
 		err = setBitmapFieldAttributes(fe, bit, valFormat)
 		if err != nil {
 			return
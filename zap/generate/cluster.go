@@ -8,10 +8,11 @@ import (
 	"github.com/project-chip/alchemy/internal/xml"
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/sourcemap"
 	"github.com/project-chip/alchemy/zap"
 )
 
-func (tg *TemplateGenerator) renderClusters(configurator *zap.Configurator, ce *etree.Element, errata *zap.Errata) (err error) {
+func (tg *TemplateGenerator) renderClusters(configurator *zap.Configurator, ce *etree.Element, errata *zap.Errata, sm *sourcemap.Recorder) (err error) {
 
 	for _, cle := range ce.SelectElements("cluster") {
 		code, ok := xml.ReadSimpleElement(cle, "code")
@@ -44,7 +45,7 @@ func (tg *TemplateGenerator) renderClusters(configurator *zap.Configurator, ce *
 			slog.Warn("unknown code ID in cluster", slog.String("path", configurator.Doc.Path), slog.String("id", clusterID.Text()))
 			continue
 		}
-		err = tg.populateCluster(configurator, cle, cluster, errata)
+		err = tg.populateCluster(configurator, cle, cluster, errata, sm)
 		if err != nil {
 			return
 		}
@@ -59,7 +60,7 @@ func (tg *TemplateGenerator) renderClusters(configurator *zap.Configurator, ce *
 		}
 		cle := etree.NewElement("cluster")
 		xml.AppendElement(ce, cle, "struct", "enum", "bitmap", "domain")
-		err = tg.populateCluster(configurator, cle, cluster, errata)
+		err = tg.populateCluster(configurator, cle, cluster, errata, sm)
 		if err != nil {
 			return
 		}
@@ -67,7 +68,8 @@ func (tg *TemplateGenerator) renderClusters(configurator *zap.Configurator, ce *
 	return
 }
 
-func (tg *TemplateGenerator) populateCluster(configurator *zap.Configurator, cle *etree.Element, cluster *matter.Cluster, errata *zap.Errata) (err error) {
+func (tg *TemplateGenerator) populateCluster(configurator *zap.Configurator, cle *etree.Element, cluster *matter.Cluster, errata *zap.Errata, sm *sourcemap.Recorder) (err error) {
+	sm.Record(cle, cluster)
 
 	var define string
 	var clusterPrefix string
@@ -123,7 +125,7 @@ func (tg *TemplateGenerator) populateCluster(configurator *zap.Configurator, cle
 		server.SetText("true")
 	}
 	if tg.generateFeaturesXML {
-		err = generateFeaturesXML(configurator, cle, cluster)
+		err = generateFeaturesXML(configurator, cle, cluster, sm)
 		if err != nil {
 			return
 		}
@@ -132,15 +134,15 @@ func (tg *TemplateGenerator) populateCluster(configurator *zap.Configurator, cle
 	if err != nil {
 		return
 	}
-	err = generateAttributes(configurator, cle, cluster, attributes, clusterPrefix, errata)
+	err = generateAttributes(configurator, cle, cluster, attributes, clusterPrefix, errata, sm)
 	if err != nil {
 		return
 	}
-	err = generateCommands(configurator, cle, cluster, commands, errata)
+	err = generateCommands(configurator, cle, cluster, commands, errata, sm)
 	if err != nil {
 		return
 	}
-	err = generateEvents(configurator, cle, cluster, events, errata)
+	err = generateEvents(configurator, cle, cluster, events, errata, sm)
 	if err != nil {
 		return
 	}
@@ -9,10 +9,11 @@ import (
 	"github.com/project-chip/alchemy/internal/xml"
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/sourcemap"
 	"github.com/project-chip/alchemy/zap"
 )
 
-func generateCommands(configurator *zap.Configurator, ce *etree.Element, cluster *matter.Cluster, commands map[*matter.Command]struct{}, errata *zap.Errata) (err error) {
+func generateCommands(configurator *zap.Configurator, ce *etree.Element, cluster *matter.Cluster, commands map[*matter.Command]struct{}, errata *zap.Errata, sm *sourcemap.Recorder) (err error) {
 
 	for _, cmde := range ce.SelectElements("command") {
 
@@ -56,7 +57,7 @@ func generateCommands(configurator *zap.Configurator, ce *etree.Element, cluster
 			ce.RemoveChild(cmde)
 			continue
 		}
-		populateCommand(cmde, matchingCommand, errata)
+		populateCommand(cmde, matchingCommand, errata, sm)
 	}
 
 	var remainingCommands []*matter.Command
@@ -71,13 +72,14 @@ func generateCommands(configurator *zap.Configurator, ce *etree.Element, cluster
 		}
 		cme := etree.NewElement("command")
 		cme.CreateAttr("code", command.ID.HexString())
-		populateCommand(cme, command, errata)
+		populateCommand(cme, command, errata, sm)
 		xml.InsertElementByAttribute(ce, cme, "code", "attribute")
 	}
 	return
 }
 
-func populateCommand(ce *etree.Element, c *matter.Command, errata *zap.Errata) {
+func populateCommand(ce *etree.Element, c *matter.Command, errata *zap.Errata, sm *sourcemap.Recorder) {
+	sm.Record(ce, c)
 	mandatory := conformance.IsMandatory(c.Conformance)
 
 	var serverSource bool
@@ -156,6 +158,7 @@ func populateCommand(ce *etree.Element, c *matter.Command, errata *zap.Errata) {
 			if conformance.IsZigbee(c.Fields, f.Conformance) || conformance.IsDisallowed(f.Conformance) {
 				continue
 			}
+			sm.Record(fe, f)
 			xml.PrependAttribute(fe, "id", f.ID.IntString())
 			setFieldAttributes(fe, f, c.Fields)
 			break
@@ -168,6 +171,7 @@ func populateCommand(ce *etree.Element, c *matter.Command, errata *zap.Errata) {
 			continue
 		}
 		fe := ce.CreateElement("arg")
+		sm.Record(fe, f)
 		fe.CreateAttr("id", f.ID.IntString())
 		setFieldAttributes(fe, f, c.Fields)
 		xml.AppendElement(ce, fe)
@@ -14,6 +14,7 @@ import (
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/spec"
 	"github.com/project-chip/alchemy/matter/types"
+	"github.com/project-chip/alchemy/sourcemap"
 	"github.com/project-chip/alchemy/zap"
 )
 
@@ -25,7 +26,7 @@ func newZapTemplate() (x *etree.Document) {
 	return
 }
 
-func (tg *TemplateGenerator) renderZapTemplate(configurator *zap.Configurator, x *etree.Document, errata *zap.Errata) (result string, err error) {
+func (tg *TemplateGenerator) renderZapTemplate(configurator *zap.Configurator, x *etree.Document, errata *zap.Errata, sm *sourcemap.Recorder) (result string, err error) {
 
 	var exampleCluster *matter.Cluster
 	for c := range configurator.Clusters {
@@ -48,28 +49,28 @@ func (tg *TemplateGenerator) renderZapTemplate(configurator *zap.Configurator, x
 	}
 
 	if exampleCluster != nil {
-		err = tg.generateFeatures(configurator, ce, exampleCluster.Features, errata)
+		err = tg.generateFeatures(configurator, ce, exampleCluster.Features, errata, sm)
 		if err != nil {
 			return
 		}
 	}
 
-	err = generateBitmaps(configurator, ce, errata)
+	err = generateBitmaps(configurator, ce, errata, sm)
 	if err != nil {
 		return
 	}
 
-	err = generateEnums(configurator, ce, errata)
+	err = generateEnums(configurator, ce, errata, sm)
 	if err != nil {
 		return
 	}
 
-	err = generateStructs(configurator, ce, errata)
+	err = generateStructs(configurator, ce, errata, sm)
 	if err != nil {
 		return
 	}
 
-	err = tg.renderClusters(configurator, ce, errata)
+	err = tg.renderClusters(configurator, ce, errata, sm)
 	if err != nil {
 		return
 	}
@@ -90,7 +91,7 @@ func postProcessTemplate(s string) string {
 	return s
 }
 
-func (tg *TemplateGenerator) generateFeatures(configurator *zap.Configurator, configuratorElement *etree.Element, features *matter.Features, errata *zap.Errata) (err error) {
+func (tg *TemplateGenerator) generateFeatures(configurator *zap.Configurator, configuratorElement *etree.Element, features *matter.Features, errata *zap.Errata, sm *sourcemap.Recorder) (err error) {
 
 	needFeatures := features != nil && len(features.Bits) > 0
 
@@ -104,7 +105,7 @@ func (tg *TemplateGenerator) generateFeatures(configurator *zap.Configurator, co
 		}
 		if needFeatures && !tg.generateFeaturesXML {
 
-			err = populateBitmap(bm, &features.Bitmap, clusterIds, errata)
+			err = populateBitmap(bm, &features.Bitmap, clusterIds, errata, sm)
 			needFeatures = false
 		} else {
 			configuratorElement.RemoveChild(bm)
@@ -115,7 +116,7 @@ func (tg *TemplateGenerator) generateFeatures(configurator *zap.Configurator, co
 	}
 	if needFeatures {
 		fe := etree.NewElement("bitmap")
-		err = populateBitmap(fe, &features.Bitmap, clusterIds, errata)
+		err = populateBitmap(fe, &features.Bitmap, clusterIds, errata, sm)
 		if err != nil {
 			return
 		}
@@ -124,7 +125,7 @@ func (tg *TemplateGenerator) generateFeatures(configurator *zap.Configurator, co
 	return
 }
 
-func generateFeaturesXML(configurator *zap.Configurator, configuratorElement *etree.Element, cluster *matter.Cluster) (err error) {
+func generateFeaturesXML(configurator *zap.Configurator, configuratorElement *etree.Element, cluster *matter.Cluster, sm *sourcemap.Recorder) (err error) {
 	features := cluster.Features
 	needFeatures := features != nil && len(features.Bits) > 0
 
@@ -150,7 +151,7 @@ func generateFeaturesXML(configurator *zap.Configurator, configuratorElement *et
 	} else {
 		fse.Child = nil
 	}
-	err = dm.RenderFeatureElements(configurator.Doc, cluster, fse)
+	err = dm.RenderFeatureElements(configurator.Doc, cluster, fse, sm)
 	return
 }
 
@@ -103,6 +103,13 @@ func (p DeviceTypesPatcher) Process(cxt context.Context, inputs []*pipeline.Data
 	return
 }
 
+// DeviceTypeElement returns the deviceType element the patcher would write for a device type not yet present in matter-devices.xml
+func DeviceTypeElement(spec *spec.Specification, deviceType *matter.DeviceType) *etree.Element {
+	dte := etree.NewElement("deviceType")
+	applyDeviceTypeToElement(spec, deviceType, dte)
+	return dte
+}
+
 type clusterRequirements struct {
 	name                    string
 	clusterRequirements     []*matter.ClusterRequirement
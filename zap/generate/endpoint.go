@@ -0,0 +1,615 @@
+package generate
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/zap"
+)
+
+// The root node device type, which every example gets on endpoint 0
+const rootNodeDeviceTypeID = 0x0016
+
+const zapProfileID = 0x0103
+
+// EndpointGenerator writes a minimal ZAP configuration for each device type, with an example endpoint holding the
+// clusters and elements the device type requires
+type EndpointGenerator struct {
+	spec       *spec.Specification
+	sdkRoot    string
+	outputRoot string
+}
+
+func NewEndpointGenerator(spec *spec.Specification, sdkRoot string, outputRoot string) *EndpointGenerator {
+	return &EndpointGenerator{spec: spec, sdkRoot: sdkRoot, outputRoot: outputRoot}
+}
+
+func (eg EndpointGenerator) Name() string {
+	return "Generating example endpoints"
+}
+
+func (eg EndpointGenerator) Type() pipeline.ProcessorType {
+	return pipeline.ProcessorTypeIndividual
+}
+
+func (eg *EndpointGenerator) Process(cxt context.Context, input *pipeline.Data[[]*matter.DeviceType], index int32, total int32) (outputs []*pipeline.Data[string], extras []*pipeline.Data[[]*matter.DeviceType], err error) {
+	for _, dt := range input.Content {
+		if dt.ID == nil || !dt.ID.Valid() {
+			slog.WarnContext(cxt, "Skipping example endpoint for device type without ID", slog.String("deviceType", dt.Name))
+			continue
+		}
+		var out []byte
+		out, err = json.MarshalIndent(eg.zapFile(dt), "", "  ")
+		if err != nil {
+			return
+		}
+		path := filepath.Join(eg.outputRoot, strcase.ToKebab(matter.Case(dt.Name))+".zap")
+		outputs = append(outputs, pipeline.NewData[string](path, string(out)+"\n"))
+	}
+	return
+}
+
+type zapFile struct {
+	FileFormat    int                `json:"fileFormat"`
+	FeatureLevel  int                `json:"featureLevel"`
+	Creator       string             `json:"creator"`
+	KeyValuePairs []zapKeyValuePair  `json:"keyValuePairs"`
+	Package       []zapPackage       `json:"package"`
+	EndpointTypes []*zapEndpointType `json:"endpointTypes"`
+	Endpoints     []zapEndpoint      `json:"endpoints"`
+}
+
+type zapKeyValuePair struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type zapPackage struct {
+	PathRelativity string `json:"pathRelativity"`
+	Path           string `json:"path"`
+	Type           string `json:"type"`
+	Category       string `json:"category"`
+	Version        any    `json:"version"`
+	Description    string `json:"description"`
+}
+
+type zapDeviceTypeRef struct {
+	Code      uint64 `json:"code"`
+	ProfileID int    `json:"profileId"`
+	Label     string `json:"label"`
+	Name      string `json:"name"`
+}
+
+type zapEndpointType struct {
+	ID                  int                `json:"id"`
+	Name                string             `json:"name"`
+	DeviceTypeRef       zapDeviceTypeRef   `json:"deviceTypeRef"`
+	DeviceTypes         []zapDeviceTypeRef `json:"deviceTypes"`
+	DeviceVersions      []int              `json:"deviceVersions"`
+	DeviceIdentifiers   []uint64           `json:"deviceIdentifiers"`
+	DeviceTypeName      string             `json:"deviceTypeName"`
+	DeviceTypeCode      uint64             `json:"deviceTypeCode"`
+	DeviceTypeProfileID int                `json:"deviceTypeProfileId"`
+	Clusters            []*zapCluster      `json:"clusters"`
+}
+
+type zapCluster struct {
+	Name       string          `json:"name"`
+	Code       uint64          `json:"code"`
+	MfgCode    *int            `json:"mfgCode"`
+	Define     string          `json:"define"`
+	Side       string          `json:"side"`
+	Enabled    int             `json:"enabled"`
+	Commands   []*zapCommand   `json:"commands,omitempty"`
+	Attributes []*zapAttribute `json:"attributes,omitempty"`
+	Events     []*zapEvent     `json:"events,omitempty"`
+}
+
+type zapCommand struct {
+	Name       string `json:"name"`
+	Code       uint64 `json:"code"`
+	MfgCode    *int   `json:"mfgCode"`
+	Source     string `json:"source"`
+	IsIncoming int    `json:"isIncoming"`
+	IsEnabled  int    `json:"isEnabled"`
+}
+
+type zapAttribute struct {
+	Name             string  `json:"name"`
+	Code             uint64  `json:"code"`
+	MfgCode          *int    `json:"mfgCode"`
+	Side             string  `json:"side"`
+	Type             string  `json:"type"`
+	Included         int     `json:"included"`
+	StorageOption    string  `json:"storageOption"`
+	Singleton        int     `json:"singleton"`
+	Bounded          int     `json:"bounded"`
+	DefaultValue     *string `json:"defaultValue"`
+	Reportable       int     `json:"reportable"`
+	MinInterval      int     `json:"minInterval"`
+	MaxInterval      int     `json:"maxInterval"`
+	ReportableChange int     `json:"reportableChange"`
+}
+
+type zapEvent struct {
+	Name     string `json:"name"`
+	Code     uint64 `json:"code"`
+	MfgCode  *int   `json:"mfgCode"`
+	Side     string `json:"side"`
+	Included int    `json:"included"`
+}
+
+type zapEndpoint struct {
+	EndpointTypeName         string `json:"endpointTypeName"`
+	EndpointTypeIndex        int    `json:"endpointTypeIndex"`
+	ProfileID                int    `json:"profileId"`
+	EndpointID               int    `json:"endpointId"`
+	NetworkID                int    `json:"networkId"`
+	ParentEndpointIdentifier *int   `json:"parentEndpointIdentifier"`
+}
+
+func (eg *EndpointGenerator) zapFile(deviceType *matter.DeviceType) *zapFile {
+	zf := &zapFile{
+		FileFormat:   2,
+		FeatureLevel: 103,
+		Creator:      "zap",
+		KeyValuePairs: []zapKeyValuePair{
+			{Key: "commandDiscovery", Value: "1"},
+			{Key: "defaultResponsePolicy", Value: "always"},
+			{Key: "manufacturerCodes", Value: "0x1002"},
+		},
+	}
+	templates := filepath.Join(eg.sdkRoot, "src/app/zap-templates")
+	zf.Package = []zapPackage{
+		{PathRelativity: "relativeToZap", Path: eg.relativePath(filepath.Join(templates, "zcl/zcl.json")), Type: "zcl-properties", Category: "matter", Version: 1, Description: "Matter SDK ZCL data"},
+		{PathRelativity: "relativeToZap", Path: eg.relativePath(filepath.Join(templates, "app-templates.json")), Type: "gen-templates-json", Category: "matter", Version: "chip-v1"},
+	}
+	deviceTypes := []*matter.DeviceType{deviceType}
+	if rootNode, ok := eg.spec.DeviceTypes[rootNodeDeviceTypeID]; ok && rootNode != deviceType {
+		deviceTypes = []*matter.DeviceType{rootNode, deviceType}
+	}
+	for i, dt := range deviceTypes {
+		et := eg.endpointType(dt, i+1)
+		zf.EndpointTypes = append(zf.EndpointTypes, et)
+		zf.Endpoints = append(zf.Endpoints, zapEndpoint{EndpointTypeName: et.Name, EndpointTypeIndex: i, ProfileID: zapProfileID, EndpointID: i})
+	}
+	return zf
+}
+
+func (eg *EndpointGenerator) relativePath(path string) string {
+	root, err := filepath.Abs(eg.outputRoot)
+	if err == nil {
+		path, err = filepath.Abs(path)
+	}
+	if err == nil {
+		path, err = filepath.Rel(root, path)
+	}
+	if err != nil {
+		slog.Warn("Unable to find SDK path relative to example endpoints", slog.String("path", path), slog.Any("error", err))
+	}
+	return filepath.ToSlash(path)
+}
+
+func (eg *EndpointGenerator) endpointType(deviceType *matter.DeviceType, id int) *zapEndpointType {
+	name := zap.DeviceTypeName(deviceType)
+	ref := zapDeviceTypeRef{Code: deviceType.ID.Value(), ProfileID: zapProfileID, Label: name, Name: name}
+	et := &zapEndpointType{
+		ID:                  id,
+		Name:                name,
+		DeviceTypeRef:       ref,
+		DeviceTypes:         []zapDeviceTypeRef{ref},
+		DeviceVersions:      []int{latestRevision(deviceType.Revisions)},
+		DeviceIdentifiers:   []uint64{ref.Code},
+		DeviceTypeName:      name,
+		DeviceTypeCode:      ref.Code,
+		DeviceTypeProfileID: zapProfileID,
+		Clusters:            []*zapCluster{},
+	}
+	clusterRequirementSets := [][]*matter.ClusterRequirement{deviceType.ClusterRequirements}
+	elementRequirementSets := [][]*matter.ElementRequirement{deviceType.ElementRequirements}
+	if eg.spec.BaseDeviceType != nil {
+		clusterRequirementSets = append([][]*matter.ClusterRequirement{eg.spec.BaseDeviceType.ClusterRequirements}, clusterRequirementSets...)
+		elementRequirementSets = append(elementRequirementSets, eg.spec.BaseDeviceType.ElementRequirements)
+	}
+	requirements := make(map[string]*clusterRequirements)
+	var order []string
+	for _, crs := range clusterRequirementSets {
+		for _, cr := range crs {
+			key := strings.ToLower(cr.ClusterName)
+			crr, ok := requirements[key]
+			if !ok {
+				crr = &clusterRequirements{name: cr.ClusterName}
+				requirements[key] = crr
+				order = append(order, key)
+			}
+			crr.clusterRequirements = append(crr.clusterRequirements, cr)
+		}
+	}
+	for _, ers := range elementRequirementSets {
+		for _, er := range ers {
+			if crr, ok := requirements[strings.ToLower(er.ClusterName)]; ok {
+				crr.elementRequirements = append(crr.elementRequirements, er)
+			}
+		}
+	}
+	for _, key := range order {
+		et.Clusters = append(et.Clusters, eg.clusters(deviceType, requirements[key])...)
+	}
+	return et
+}
+
+// clusters returns the client and server sides of a cluster the device type requires
+func (eg *EndpointGenerator) clusters(deviceType *matter.DeviceType, cr *clusterRequirements) (clusters []*zapCluster) {
+	cluster, ok := eg.spec.ClustersByName[cr.name]
+	if !ok || cluster.ID == nil || !cluster.ID.Valid() {
+		slog.Warn("Unknown cluster in device type requirements", slog.String("deviceType", deviceType.Name), slog.String("clusterName", cr.name))
+		return
+	}
+	cxt := conformance.Context{
+		Values: map[string]any{"Matter": true},
+	}
+	var client, server bool
+	for _, r := range cr.clusterRequirements {
+		conf, err := r.Conformance.Eval(cxt)
+		if err != nil {
+			slog.Warn("Error evaluating conformance of cluster requirement", slog.String("deviceType", deviceType.Name), slog.String("clusterName", cluster.Name), slog.Any("error", err))
+			continue
+		}
+		if conf != conformance.StateMandatory {
+			continue
+		}
+		switch r.Interface {
+		case matter.InterfaceServer:
+			server = true
+		case matter.InterfaceClient:
+			client = true
+		}
+	}
+	if !client && !server {
+		return
+	}
+
+	required := make(map[string]struct{})
+	for _, er := range cr.elementRequirements {
+		conf, err := er.Conformance.Eval(cxt)
+		if err != nil {
+			slog.Warn("Error evaluating conformance of element requirement", slog.String("deviceType", deviceType.Name), slog.String("clusterName", cluster.Name), slog.Any("error", err))
+			continue
+		}
+		if conf == conformance.StateMandatory {
+			required[er.Name] = struct{}{}
+			cxt.Values[er.Name] = true
+		}
+	}
+	featureMap := enableFeatures(cluster, cxt)
+	isRequired := func(name string, cs conformance.Set) bool {
+		if _, ok := required[name]; ok {
+			return true
+		}
+		conf, err := cs.Eval(cxt)
+		if err != nil {
+			slog.Warn("Error evaluating conformance", slog.String("deviceType", deviceType.Name), slog.String("clusterName", cluster.Name), slog.String("element", name), slog.Any("error", err))
+			return false
+		}
+		return conf == conformance.StateMandatory
+	}
+	// Choice groups are satisfied within each kind of element
+	var commands, attributes, events []choiceMember
+	for _, c := range cluster.Commands {
+		if c.ID != nil && c.ID.Valid() {
+			commands = append(commands, choiceMember{name: c.Name, conformance: c.Conformance, present: isRequired(c.Name, c.Conformance)})
+		}
+	}
+	for _, a := range cluster.Attributes {
+		if a.ID != nil && a.ID.Valid() && !conformance.IsZigbee(cluster.Attributes, a.Conformance) {
+			attributes = append(attributes, choiceMember{name: a.Name, conformance: a.Conformance, present: isRequired(a.Name, a.Conformance)})
+		}
+	}
+	for _, e := range cluster.Events {
+		if e.ID != nil && e.ID.Valid() {
+			events = append(events, choiceMember{name: e.Name, conformance: e.Conformance, present: isRequired(e.Name, e.Conformance)})
+		}
+	}
+	satisfyChoices(commands, required, cxt)
+	satisfyChoices(attributes, required, cxt)
+	satisfyChoices(events, required, cxt)
+
+	path := eg.spec.DocRefs[cluster]
+	errata, ok := zap.Erratas[filepath.Base(path)]
+	if !ok {
+		errata = zap.DefaultErrata
+	}
+	define := getDefine(cluster.Name+" Cluster", "", errata)
+
+	for _, side := range []string{"client", "server"} {
+		if (side == "client" && !client) || (side == "server" && !server) {
+			continue
+		}
+		zc := &zapCluster{Name: cluster.Name, Code: cluster.ID.Value(), Define: define, Side: side, Enabled: 1}
+		for _, c := range cluster.Commands {
+			if c.ID == nil || !c.ID.Valid() || !isRequired(c.Name, c.Conformance) {
+				continue
+			}
+			source := "client"
+			if c.Direction == matter.InterfaceClient {
+				source = "server"
+			}
+			zc.Commands = append(zc.Commands, &zapCommand{Name: c.Name, Code: c.ID.Value(), Source: source, IsIncoming: boolToInt(source != side), IsEnabled: 1})
+		}
+		if side == "server" {
+			for _, a := range cluster.Attributes {
+				if a.ID == nil || !a.ID.Valid() || conformance.IsZigbee(cluster.Attributes, a.Conformance) || !isRequired(a.Name, a.Conformance) {
+					continue
+				}
+				zc.Attributes = append(zc.Attributes, endpointAttribute(cluster, a))
+			}
+			for _, e := range cluster.Events {
+				if e.ID == nil || !e.ID.Valid() || !isRequired(e.Name, e.Conformance) {
+					continue
+				}
+				zc.Events = append(zc.Events, &zapEvent{Name: e.Name, Code: e.ID.Value(), Side: side, Included: 1})
+			}
+			zc.Attributes = append(zc.Attributes,
+				globalEndpointAttribute("GeneratedCommandList", 0xFFF8, side, "array", ""),
+				globalEndpointAttribute("AcceptedCommandList", 0xFFF9, side, "array", ""),
+				globalEndpointAttribute("AttributeList", 0xFFFB, side, "array", ""),
+			)
+		}
+		zc.Attributes = append(zc.Attributes,
+			globalEndpointAttribute("FeatureMap", 0xFFFC, side, "bitmap32", strconv.FormatUint(featureMap, 10)),
+			globalEndpointAttribute("ClusterRevision", 0xFFFD, side, "int16u", strconv.Itoa(latestRevision(cluster.Revisions))),
+		)
+		clusters = append(clusters, zc)
+	}
+	return
+}
+
+// enableFeatures turns on the features the device type requires, and those which are mandatory, along with enough of
+// each choice group to satisfy it, returning the resulting feature map
+func enableFeatures(cluster *matter.Cluster, cxt conformance.Context) (featureMap uint64) {
+	if cluster.Features == nil {
+		return
+	}
+	var features []*enabledFeature
+	for _, bit := range cluster.Features.Bits {
+		f, ok := bit.(*matter.Feature)
+		if !ok {
+			continue
+		}
+		mask, err := f.Mask()
+		if err != nil {
+			slog.Warn("Invalid feature bit", slog.String("clusterName", cluster.Name), slog.String("feature", f.Code), slog.Any("error", err))
+			continue
+		}
+		_, required := cxt.Values[f.Code]
+		if !required {
+			_, required = cxt.Values[f.Name()]
+		}
+		features = append(features, &enabledFeature{Feature: f, mask: mask, required: required})
+	}
+	// Enabling a feature can change which choice groups are left unsatisfied, so they're satisfied one feature at a time
+	for range features {
+		featureMap = settleFeatures(cluster, features, cxt)
+		members := make([]choiceMember, 0, len(features))
+		for _, f := range features {
+			members = append(members, choiceMember{name: f.Code, conformance: f.Conformance(), present: featureMap&f.mask != 0})
+		}
+		index := unsatisfiedChoiceMember(members, cxt)
+		if index < 0 {
+			return
+		}
+		features[index].required = true
+	}
+	return settleFeatures(cluster, features, cxt)
+}
+
+type enabledFeature struct {
+	*matter.Feature
+	mask     uint64
+	required bool
+}
+
+// settleFeatures enables the required and mandatory features, returning the feature map once it stops changing
+func settleFeatures(cluster *matter.Cluster, features []*enabledFeature, cxt conformance.Context) (featureMap uint64) {
+	// A feature's conformance can depend on features with later bits, so the feature map is recomputed until it settles
+	for pass := 0; ; pass++ {
+		var next uint64
+		for _, f := range features {
+			if f.required {
+				next |= f.mask
+				continue
+			}
+			conf, err := f.Conformance().Eval(cxt)
+			if err != nil {
+				slog.Warn("Error evaluating conformance of feature", slog.String("clusterName", cluster.Name), slog.String("feature", f.Code), slog.Any("error", err))
+				continue
+			}
+			if conf == conformance.StateMandatory {
+				next |= f.mask
+			}
+		}
+		if pass > 0 && next == featureMap {
+			return
+		}
+		if pass > len(features) {
+			slog.Warn("Feature conformance never settles", slog.String("clusterName", cluster.Name))
+			return
+		}
+		featureMap = next
+		for _, f := range features {
+			if featureMap&f.mask != 0 {
+				cxt.Values[f.Code] = true
+			} else if !f.required {
+				delete(cxt.Values, f.Code)
+			}
+		}
+	}
+}
+
+type choiceMember struct {
+	name        string
+	conformance conformance.Set
+	present     bool
+}
+
+// unsatisfiedChoiceMember returns the index of the first absent member of the first choice group with too few members
+// present, or -1 if every choice group is satisfied; members are expected in order of their bit or ID
+func unsatisfiedChoiceMember(members []choiceMember, cxt conformance.Context) int {
+	type choiceGroup struct {
+		minimum int
+		present int
+		absent  []int
+	}
+	groups := make(map[string]*choiceGroup)
+	var sets []string
+	for i, m := range members {
+		choice := applicableChoice(m.conformance, cxt)
+		if choice == nil {
+			continue
+		}
+		group, ok := groups[choice.Set]
+		if !ok {
+			group = &choiceGroup{minimum: choiceMinimum(choice.Limit)}
+			groups[choice.Set] = group
+			sets = append(sets, choice.Set)
+		}
+		if m.present {
+			group.present++
+		} else {
+			group.absent = append(group.absent, i)
+		}
+	}
+	for _, set := range sets {
+		group := groups[set]
+		if group.present < group.minimum && len(group.absent) > 0 {
+			return group.absent[0]
+		}
+	}
+	return -1
+}
+
+// applicableChoice returns the choice of the conformance entry that applies in a context, if it has one
+func applicableChoice(cs conformance.Set, cxt conformance.Context) *conformance.Choice {
+	for _, c := range cs {
+		state, err := c.Eval(cxt)
+		if err != nil {
+			return nil
+		}
+		if state == conformance.StateUnknown {
+			continue
+		}
+		if o, ok := c.(*conformance.Optional); ok {
+			return o.Choice
+		}
+		return nil
+	}
+	return nil
+}
+
+func choiceMinimum(limit conformance.ChoiceLimit) int {
+	switch limit := limit.(type) {
+	case *conformance.ChoiceExactLimit:
+		return limit.Limit
+	case *conformance.ChoiceMinLimit:
+		return limit.Min
+	case *conformance.ChoiceMaxLimit:
+		return 0
+	case *conformance.ChoiceRangeLimit:
+		return limit.Min
+	default:
+		return 1
+	}
+}
+
+// satisfyChoices adds enough members of each choice group to the required elements to satisfy it
+func satisfyChoices(members []choiceMember, required map[string]struct{}, cxt conformance.Context) {
+	for range members {
+		index := unsatisfiedChoiceMember(members, cxt)
+		if index < 0 {
+			return
+		}
+		members[index].present = true
+		required[members[index].name] = struct{}{}
+	}
+}
+
+func endpointAttribute(cluster *matter.Cluster, a *matter.Field) *zapAttribute {
+	za := &zapAttribute{
+		Name:          a.Name,
+		Code:          a.ID.Value(),
+		Side:          "server",
+		Included:      1,
+		StorageOption: "RAM",
+		Reportable:    1,
+		MinInterval:   1,
+		MaxInterval:   65534,
+	}
+	if a.Type == nil {
+		return za
+	}
+	if a.Type.IsArray() {
+		za.Type = "array"
+		za.StorageOption = "External"
+		return za
+	}
+	za.Type = zap.FieldToZapDataType(cluster.Attributes, a)
+	if _, ok := a.Type.Entity.(*matter.Struct); ok {
+		za.StorageOption = "External"
+		return za
+	}
+	if a.Default != "" {
+		defaultValue := zap.GetDefaultValue(&matter.ConstraintContext{Field: a, Fields: cluster.Attributes})
+		if defaultValue.Defined() && !defaultValue.IsNull() {
+			s := defaultValue.ZapString(a.Type)
+			za.DefaultValue = &s
+		}
+	}
+	return za
+}
+
+// globalEndpointAttribute returns one of the global attributes; those without a default are lists, kept in external storage
+func globalEndpointAttribute(name string, code uint64, side string, dataType string, defaultValue string) *zapAttribute {
+	za := &zapAttribute{
+		Name:          name,
+		Code:          code,
+		Side:          side,
+		Type:          dataType,
+		Included:      1,
+		StorageOption: "External",
+		Reportable:    1,
+		MinInterval:   1,
+		MaxInterval:   65534,
+	}
+	if defaultValue != "" {
+		za.StorageOption = "RAM"
+		za.DefaultValue = &defaultValue
+	}
+	return za
+}
+
+func latestRevision(revisions []*matter.Revision) int {
+	if len(revisions) == 0 {
+		return 1
+	}
+	revision, err := strconv.Atoi(revisions[len(revisions)-1].Number)
+	if err != nil {
+		return 1
+	}
+	return revision
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,49 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+)
+
+var enableFeaturesTests = []struct {
+	Name       string
+	Features   []string
+	Required   []string
+	FeatureMap uint64
+}{
+	{Name: "mandatory", Features: []string{"M", "O", "O"}, FeatureMap: 0x1},
+	{Name: "required by the device type", Features: []string{"O", "O", "O"}, Required: []string{"CD"}, FeatureMap: 0x2},
+	{Name: "required by name", Features: []string{"O", "O", "O"}, Required: []string{"Echo Feature"}, FeatureMap: 0x4},
+	{Name: "depends on an earlier bit", Features: []string{"M", "AB", "O"}, FeatureMap: 0x3},
+	{Name: "depends on a later bit", Features: []string{"CD", "M", "O"}, FeatureMap: 0x3},
+	{Name: "depends on a later required bit", Features: []string{"CD", "EF", "O"}, Required: []string{"EF"}, FeatureMap: 0x7},
+	{Name: "disallowed by a later bit", Features: []string{"!CD", "M", "O"}, FeatureMap: 0x2},
+	{Name: "disallowed by a later required bit", Features: []string{"!EF", "O", "O"}, Required: []string{"EF"}, FeatureMap: 0x4},
+	{Name: "chain of later bits", Features: []string{"CD", "EF", "M"}, FeatureMap: 0x7},
+	{Name: "choice", Features: []string{"O.a", "O.a", "O"}, FeatureMap: 0x1},
+	{Name: "choice of at least one", Features: []string{"O", "O.a+", "O.a+"}, FeatureMap: 0x2},
+	{Name: "choice satisfied by the device type", Features: []string{"O.a", "O.a", "O"}, Required: []string{"CD"}, FeatureMap: 0x2},
+	{Name: "separate choices", Features: []string{"O.a", "O.b", "O.a"}, FeatureMap: 0x3},
+	{Name: "choice enabling a dependent bit", Features: []string{"O.a", "AB", "O.a"}, FeatureMap: 0x3},
+}
+
+func TestEnableFeatures(t *testing.T) {
+	codes := []string{"AB", "CD", "EF"}
+	names := []string{"Alpha Feature", "Charlie Feature", "Echo Feature"}
+	for _, et := range enableFeaturesTests {
+		cluster := &matter.Cluster{Name: "Test", Features: &matter.Features{}}
+		for i, cs := range et.Features {
+			cluster.Features.Bits = append(cluster.Features.Bits, matter.NewFeature(string(rune('0'+i)), names[i], codes[i], "", conformance.ParseConformance(cs)))
+		}
+		cxt := conformance.Context{Values: make(map[string]any)}
+		for _, r := range et.Required {
+			cxt.Values[r] = true
+		}
+		featureMap := enableFeatures(cluster, cxt)
+		if featureMap != et.FeatureMap {
+			t.Errorf("%s: unexpected feature map; expected 0x%X, got 0x%X", et.Name, et.FeatureMap, featureMap)
+		}
+	}
+}
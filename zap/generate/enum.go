@@ -10,10 +10,11 @@ import (
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/conformance"
 	"github.com/project-chip/alchemy/matter/types"
+	"github.com/project-chip/alchemy/sourcemap"
 	"github.com/project-chip/alchemy/zap"
 )
 
-func generateEnums(configurator *zap.Configurator, ce *etree.Element, errata *zap.Errata) (err error) {
+func generateEnums(configurator *zap.Configurator, ce *etree.Element, errata *zap.Errata, sm *sourcemap.Recorder) (err error) {
 
 	for _, eve := range ce.SelectElements("enum") {
 
@@ -46,7 +47,7 @@ func generateEnums(configurator *zap.Configurator, ce *etree.Element, errata *za
 			ce.RemoveChild(eve)
 			continue
 		}
-		populateEnum(configurator, eve, matchingEnum, clusterIds, errata)
+		populateEnum(configurator, eve, matchingEnum, clusterIds, errata, sm)
 	}
 
 	var remainingEnums []*matter.Enum
@@ -66,14 +67,15 @@ func generateEnums(configurator *zap.Configurator, ce *etree.Element, errata *za
 	for _, en := range remainingEnums {
 		bme := etree.NewElement("enum")
 		clusterIds := clusterIdsForEntity(configurator.Spec, en)
-		populateEnum(configurator, bme, en, clusterIds, errata)
+		populateEnum(configurator, bme, en, clusterIds, errata, sm)
 		xml.InsertElementByAttribute(ce, bme, "name", "bitmap", "domain")
 	}
 
 	return
 }
 
-func populateEnum(configurator *zap.Configurator, ee *etree.Element, en *matter.Enum, clusterIds []*matter.Number, errata *zap.Errata) (err error) {
+func populateEnum(configurator *zap.Configurator, ee *etree.Element, en *matter.Enum, clusterIds []*matter.Number, errata *zap.Errata, sm *sourcemap.Recorder) (err error) {
+	sm.Record(ee, en)
 
 	var valFormat string
 	switch en.Type.BaseType {
@@ -102,6 +104,7 @@ func populateEnum(configurator *zap.Configurator, ee *etree.Element, en *matter.
 			if conformance.IsZigbee(en.Values, value.Conformance) || conformance.IsDisallowed(value.Conformance) {
 				continue
 			}
+			sm.Record(be, value)
 			setEnumItemAttributes(be, value, valFormat)
 			break
 		}
@@ -113,6 +116,7 @@ func populateEnum(configurator *zap.Configurator, ee *etree.Element, en *matter.
 			continue
 		}
 		ie := etree.NewElement("item")
+		sm.Record(ie, value)
 		setEnumItemAttributes(ie, value, valFormat)
 		xml.AppendElement(ee, ie, "cluster")
 	}
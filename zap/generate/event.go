@@ -9,10 +9,11 @@ import (
 	axml "github.com/project-chip/alchemy/internal/xml"
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/sourcemap"
 	"github.com/project-chip/alchemy/zap"
 )
 
-func generateEvents(configurator *zap.Configurator, ce *etree.Element, cluster *matter.Cluster, events map[*matter.Event]struct{}, errata *zap.Errata) (err error) {
+func generateEvents(configurator *zap.Configurator, ce *etree.Element, cluster *matter.Cluster, events map[*matter.Event]struct{}, errata *zap.Errata, sm *sourcemap.Recorder) (err error) {
 
 	for _, eve := range ce.SelectElements("event") {
 
@@ -44,18 +45,19 @@ func generateEvents(configurator *zap.Configurator, ce *etree.Element, cluster *
 			ce.RemoveChild(eve)
 			continue
 		}
-		populateEvent(eve, matchingEvent, cluster, errata)
+		populateEvent(eve, matchingEvent, cluster, errata, sm)
 	}
 
 	for event := range events {
 		ee := etree.NewElement("event")
-		populateEvent(ee, event, cluster, errata)
+		populateEvent(ee, event, cluster, errata, sm)
 		axml.InsertElementByAttribute(ce, ee, "code", "command", "attribute")
 	}
 	return
 }
 
-func populateEvent(ee *etree.Element, e *matter.Event, cluster *matter.Cluster, errata *zap.Errata) {
+func populateEvent(ee *etree.Element, e *matter.Event, cluster *matter.Cluster, errata *zap.Errata, sm *sourcemap.Recorder) {
+	sm.Record(ee, e)
 	needsAccess := e.Access.Read != matter.PrivilegeUnknown && e.Access.Read != matter.PrivilegeView
 
 	patchNumberAttribute(ee, e.ID, "code")
@@ -87,6 +89,7 @@ func populateEvent(ee *etree.Element, e *matter.Event, cluster *matter.Cluster,
 			if conformance.IsZigbee(e.Fields, f.Conformance) || conformance.IsDisallowed(f.Conformance) {
 				continue
 			}
+			sm.Record(fe, f)
 			fe.CreateAttr("id", f.ID.IntString())
 			setFieldAttributes(fe, f, e.Fields)
 			break
@@ -99,6 +102,7 @@ func populateEvent(ee *etree.Element, e *matter.Event, cluster *matter.Cluster,
 			continue
 		}
 		fe := etree.NewElement("field")
+		sm.Record(fe, f)
 		fe.CreateAttr("id", f.ID.IntString())
 		setFieldAttributes(fe, f, e.Fields)
 		axml.AppendElement(ee, fe)
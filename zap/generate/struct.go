@@ -9,10 +9,11 @@ import (
 	"github.com/project-chip/alchemy/internal/xml"
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/sourcemap"
 	"github.com/project-chip/alchemy/zap"
 )
 
-func generateStructs(configurator *zap.Configurator, configuratorElement *etree.Element, errata *zap.Errata) (err error) {
+func generateStructs(configurator *zap.Configurator, configuratorElement *etree.Element, errata *zap.Errata, sm *sourcemap.Recorder) (err error) {
 
 	for _, se := range configuratorElement.SelectElements("struct") {
 
@@ -49,12 +50,12 @@ func generateStructs(configurator *zap.Configurator, configuratorElement *etree.
 
 				amendedClusterCodes, remainingClusterIds := amendExistingClusterCodes(se, matchingStruct, clusterIds)
 
-				populateStruct(se, matchingStruct, amendedClusterCodes, false)
+				populateStruct(se, matchingStruct, amendedClusterCodes, false, sm)
 				configurator.Structs[matchingStruct] = remainingClusterIds
 				continue
 			}
 		}
-		populateStruct(se, matchingStruct, clusterIds, false)
+		populateStruct(se, matchingStruct, clusterIds, false, sm)
 		configurator.Structs[matchingStruct] = nil
 	}
 
@@ -77,21 +78,22 @@ func generateStructs(configurator *zap.Configurator, configuratorElement *etree.
 
 				for _, clusterID := range clusterIds {
 					bme := etree.NewElement("struct")
-					populateStruct(bme, s, []*matter.Number{clusterID}, false)
+					populateStruct(bme, s, []*matter.Number{clusterID}, false, sm)
 					xml.AppendElement(configuratorElement, bme, "enum", "bitmap")
 				}
 				continue
 			}
 		}
 		bme := etree.NewElement("struct")
-		populateStruct(bme, s, clusterIds, true)
+		populateStruct(bme, s, clusterIds, true, sm)
 		xml.InsertElementByAttribute(configuratorElement, bme, "name", "enum", "bitmap", "domain")
 	}
 
 	return
 }
 
-func populateStruct(ee *etree.Element, s *matter.Struct, clusterIDs []*matter.Number, provisional bool) (remainingClusterIDs []*matter.Number) {
+func populateStruct(ee *etree.Element, s *matter.Struct, clusterIDs []*matter.Number, provisional bool, sm *sourcemap.Recorder) (remainingClusterIDs []*matter.Number) {
+	sm.Record(ee, s)
 
 	ee.CreateAttr("name", s.Name)
 	if provisional {
@@ -119,6 +121,7 @@ func populateStruct(ee *etree.Element, s *matter.Struct, clusterIDs []*matter.Nu
 			if conformance.IsZigbee(s.Fields, f.Conformance) || conformance.IsDisallowed(f.Conformance) {
 				continue
 			}
+			sm.Record(fe, f)
 			setStructFieldAttributes(fe, s, f)
 			break
 		}
@@ -130,6 +133,7 @@ func populateStruct(ee *etree.Element, s *matter.Struct, clusterIDs []*matter.Nu
 			continue
 		}
 		fe := etree.NewElement("item")
+		sm.Record(fe, field)
 		setStructFieldAttributes(fe, s, field)
 		xml.AppendElement(ee, fe, "cluster")
 	}
@@ -14,6 +14,7 @@ import (
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/spec"
 	"github.com/project-chip/alchemy/matter/types"
+	"github.com/project-chip/alchemy/sourcemap"
 	"github.com/project-chip/alchemy/zap"
 )
 
@@ -24,6 +25,7 @@ type TemplateGenerator struct {
 	sdkRoot  string
 
 	generateFeaturesXML bool
+	sourceMaps          *sourcemap.Generator
 
 	ProvisionalZclFiles pipeline.Map[string, *pipeline.Data[struct{}]]
 }
@@ -36,6 +38,13 @@ func GenerateFeatureXML(generate bool) TemplateOption {
 	}
 }
 
+// GenerateSourceMaps records the spec entity each element is generated from, and writes a source map alongside each ZAP template
+func GenerateSourceMaps(g *sourcemap.Generator) TemplateOption {
+	return func(tg *TemplateGenerator) {
+		tg.sourceMaps = g
+	}
+}
+
 func NewTemplateGenerator(spec *spec.Specification, fileOptions files.Options, pipelineOptions pipeline.Options, sdkRoot string, options ...TemplateOption) *TemplateGenerator {
 	tg := &TemplateGenerator{
 		spec:                spec,
@@ -136,12 +145,21 @@ func (tg *TemplateGenerator) render(cxt context.Context, input *pipeline.Data[*s
 			}
 
 		}
-		result, err = tg.renderZapTemplate(configurator, doc, errata)
+		sm := tg.sourceMaps.Recorder(doc)
+		result, err = tg.renderZapTemplate(configurator, doc, errata, sm)
 		if err != nil {
 			err = fmt.Errorf("failed rendering %s: %w", input.Content.Path, err)
 			return
 		}
 		outputs = append(outputs, &pipeline.Data[string]{Path: newPath, Content: result})
+		var sourceMap *pipeline.Data[string]
+		sourceMap, err = sm.Data(newPath)
+		if err != nil {
+			return
+		}
+		if sourceMap != nil {
+			outputs = append(outputs, sourceMap)
+		}
 		if provisional {
 			tg.ProvisionalZclFiles.Store(filepath.Base(newPath), pipeline.NewData[struct{}](newPath, struct{}{}))
 		}
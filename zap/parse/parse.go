@@ -91,6 +91,14 @@ func (sp *ZapParser) Process(cxt context.Context, input *pipeline.Data[[]byte],
 }
 
 func (sp *ZapParser) ResolveReferences() {
+	for cid, b := range sp.bitmapReferences {
+		c, ok := sp.clusterReferences[cid]
+		if !ok {
+			slog.Warn("unknown cluster reference for bitmap", "clusterId", cid)
+			continue
+		}
+		c.Bitmaps = append(c.Bitmaps, b...)
+	}
 	for cid, e := range sp.enumReferences {
 		c, ok := sp.clusterReferences[cid]
 		if !ok {